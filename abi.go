@@ -0,0 +1,499 @@
+package flashxroute
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupportedABIType is returned for event arguments of types the decoder doesn't handle, e.g. tuples and
+// non-indexed arrays
+var ErrUnsupportedABIType = errors.New("unsupported abi type")
+
+// eventArgument - argument of an event signature
+type eventArgument struct {
+	Type    string
+	Name    string
+	Indexed bool
+}
+
+// dynamic reports whether the argument is encoded by reference
+func (arg eventArgument) dynamic() bool {
+	return arg.Type == "string" || arg.Type == "bytes" || strings.HasSuffix(arg.Type, "]")
+}
+
+// eventSignature - parsed event declaration like "Transfer(address indexed from, address indexed to, uint256 value)"
+type eventSignature struct {
+	Name      string
+	Arguments []eventArgument
+}
+
+// canonical returns the signature hashed into the first topic, e.g. "Transfer(address,address,uint256)"
+func (sig eventSignature) canonical() string {
+	types := make([]string, len(sig.Arguments))
+	for i, arg := range sig.Arguments {
+		types[i] = arg.Type
+	}
+
+	return sig.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// topic returns the first topic of the event logs
+func (sig eventSignature) topic() string {
+	return Keccak256([]byte(sig.canonical()))
+}
+
+// parseEventSignature parses event declaration, arguments are a type optionally followed by indexed and a name
+func parseEventSignature(signature string) (eventSignature, error) {
+	signature = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(signature), "event "))
+	open, end := strings.IndexByte(signature, '('), strings.LastIndexByte(signature, ')')
+	if open <= 0 || end != len(signature)-1 {
+		return eventSignature{}, errors.Errorf("invalid event signature %q", signature)
+	}
+
+	sig := eventSignature{Name: strings.TrimSpace(signature[:open])}
+	args := strings.TrimSpace(signature[open+1 : end])
+	if args == "" {
+		return sig, nil
+	}
+	if strings.ContainsAny(args, "()") {
+		return sig, errors.Wrapf(ErrUnsupportedABIType, "tuple in %q", signature)
+	}
+
+	for _, field := range strings.Split(args, ",") {
+		words := strings.Fields(field)
+		if len(words) == 0 || len(words) > 3 {
+			return sig, errors.Errorf("invalid event argument %q", field)
+		}
+		arg := eventArgument{Type: canonicalABIType(words[0])}
+		for _, word := range words[1:] {
+			if word == "indexed" && !arg.Indexed && arg.Name == "" {
+				arg.Indexed = true
+			} else if arg.Name == "" {
+				arg.Name = word
+			} else {
+				return sig, errors.Errorf("invalid event argument %q", field)
+			}
+		}
+		if err := checkABIType(arg); err != nil {
+			return sig, err
+		}
+		sig.Arguments = append(sig.Arguments, arg)
+	}
+
+	return sig, nil
+}
+
+// canonicalABIType expands the uint and int aliases
+func canonicalABIType(t string) string {
+	base, suffix := t, ""
+	if i := strings.IndexByte(t, '['); i >= 0 {
+		base, suffix = t[:i], t[i:]
+	}
+	switch base {
+	case "uint", "int":
+		base += "256"
+	}
+
+	return base + suffix
+}
+
+// checkABIType returns ErrUnsupportedABIType for types the decoder can't read
+func checkABIType(arg eventArgument) error {
+	t := arg.Type
+	switch {
+	case strings.HasSuffix(t, "]"):
+		if arg.Indexed {
+			return nil // only the hash is logged
+		}
+	case t == "address" || t == "bool" || t == "string" || t == "bytes":
+		return nil
+	case strings.HasPrefix(t, "bytes"):
+		if size, err := strconv.Atoi(t[5:]); err == nil && size >= 1 && size <= 32 {
+			return nil
+		}
+	case strings.HasPrefix(t, "uint") || strings.HasPrefix(t, "int"):
+		if bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(t, "u"), "int")); err == nil && bits >= 8 && bits <= 256 && bits%8 == 0 {
+			return nil
+		}
+	}
+
+	return errors.Wrap(ErrUnsupportedABIType, t)
+}
+
+// decodeEvent decodes topics and data of a log of the event into the exported fields of out in argument order
+func decodeEvent(sig eventSignature, topics []string, data []byte, out reflect.Value) error {
+	fields := exportedFields(out)
+	if len(fields) < len(sig.Arguments) {
+		return errors.Errorf("%s has %d exported fields, event %s has %d arguments", out.Type(), len(fields), sig.Name, len(sig.Arguments))
+	}
+
+	topic, head := 1, 0
+	for i, arg := range sig.Arguments {
+		var word []byte
+		if arg.Indexed {
+			if topic >= len(topics) {
+				return errors.Errorf("log has %d topics, %s needs more", len(topics), sig.canonical())
+			}
+			value, err := ParseBytes(topics[topic])
+			if err != nil {
+				return err
+			}
+			if len(value) != 32 {
+				return errors.Errorf("invalid topic %s", topics[topic])
+			}
+			word = value
+			topic++
+		} else {
+			if head+32 > len(data) {
+				return errors.Errorf("log data too short for %s", sig.canonical())
+			}
+			word = data[head : head+32]
+			head += 32
+			if arg.dynamic() {
+				value, err := dynamicABIValue(data, word)
+				if err != nil {
+					return errors.Wrapf(err, "argument %d of %s", i, sig.canonical())
+				}
+				word = value
+			}
+		}
+
+		if err := setABIValue(fields[i], arg, word); err != nil {
+			return errors.Wrapf(err, "field %s", out.Type().Field(fieldIndex(out, i)).Name)
+		}
+	}
+
+	return nil
+}
+
+// exportedFields returns settable exported fields of struct value in declaration order
+func exportedFields(value reflect.Value) []reflect.Value {
+	var fields []reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).IsExported() {
+			fields = append(fields, value.Field(i))
+		}
+	}
+
+	return fields
+}
+
+// fieldIndex returns the struct index of the n-th exported field
+func fieldIndex(value reflect.Value, n int) int {
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).IsExported() {
+			if n == 0 {
+				return i
+			}
+			n--
+		}
+	}
+
+	return -1
+}
+
+// dynamicABIValue returns bytes of a string or bytes value at the offset in head
+func dynamicABIValue(data, head []byte) ([]byte, error) {
+	offset := new(big.Int).SetBytes(head)
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(data)) {
+		return nil, errors.New("offset out of data")
+	}
+	start := int(offset.Int64()) + 32
+	length := new(big.Int).SetBytes(data[start-32 : start])
+	if !length.IsInt64() || int64(start)+length.Int64() > int64(len(data)) {
+		return nil, errors.New("length out of data")
+	}
+
+	return data[start : start+int(length.Int64())], nil
+}
+
+var bigType = reflect.TypeOf(big.Int{})
+
+// setABIValue stores value of arg into target, value is the 32 bytes word of static types and indexed arguments and
+// the content of non-indexed strings and bytes
+func setABIValue(target reflect.Value, arg eventArgument, value []byte) error {
+	t := arg.Type
+	switch {
+	case arg.Indexed && arg.dynamic():
+		return setABIBytes(target, value, false)
+
+	case t == "string":
+		if target.Kind() == reflect.String {
+			target.SetString(string(value))
+			return nil
+		}
+		return setABIBytes(target, value, false)
+
+	case t == "bytes":
+		return setABIBytes(target, value, false)
+
+	case t == "address":
+		address := value[12:]
+		if target.Kind() == reflect.String {
+			target.SetString(BytesToHex(address))
+			return nil
+		}
+		return setABIBytes(target, address, true)
+
+	case t == "bool":
+		if target.Kind() != reflect.Bool {
+			return fmt.Errorf("cannot store bool in %s", target.Type())
+		}
+		target.SetBool(value[31] != 0)
+		return nil
+
+	case strings.HasPrefix(t, "bytes"):
+		size, _ := strconv.Atoi(t[5:])
+		return setABIBytes(target, value[:size], true)
+	}
+
+	number := new(big.Int).SetBytes(value)
+	if strings.HasPrefix(t, "int") && value[0]&0x80 != 0 {
+		number.Sub(number, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	switch {
+	case target.Type() == bigType:
+		target.Set(reflect.ValueOf(*number))
+	case target.Kind() == reflect.Ptr && target.Type().Elem() == bigType:
+		target.Set(reflect.ValueOf(number))
+	case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+		if !number.IsInt64() || target.OverflowInt(number.Int64()) {
+			return fmt.Errorf("%s overflows %s", number, target.Type())
+		}
+		target.SetInt(number.Int64())
+	case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+		if !number.IsUint64() || target.OverflowUint(number.Uint64()) {
+			return fmt.Errorf("%s overflows %s", number, target.Type())
+		}
+		target.SetUint(number.Uint64())
+	case target.Kind() == reflect.String:
+		target.SetString(number.String())
+	default:
+		return fmt.Errorf("cannot store %s in %s", t, target.Type())
+	}
+
+	return nil
+}
+
+// setABIBytes stores value into []byte, byte array of exactly its size or 0x prefixed hex string
+func setABIBytes(target reflect.Value, value []byte, fixed bool) error {
+	switch {
+	case target.Kind() == reflect.String:
+		target.SetString(BytesToHex(value))
+	case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8:
+		target.SetBytes(append([]byte{}, value...))
+	case target.Kind() == reflect.Array && target.Type().Elem().Kind() == reflect.Uint8 && target.Len() == len(value):
+		reflect.Copy(target, reflect.ValueOf(value))
+	default:
+		kind := "bytes"
+		if fixed {
+			kind = fmt.Sprintf("bytes%d", len(value))
+		}
+		return fmt.Errorf("cannot store %s in %s", kind, target.Type())
+	}
+
+	return nil
+}
+
+// canonicalTypes returns the comma separated types of a parameter list dropping names and keywords like indexed
+// and memory, e.g. "address indexed from, uint value" becomes "address,uint256"
+func canonicalTypes(params string) (string, error) {
+	var types []string
+	depth, start := 0, 0
+	for i := 0; i <= len(params); i++ {
+		if i < len(params) {
+			switch params[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				if depth < 0 {
+					return "", errors.Errorf("unbalanced parentheses in %q", params)
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if depth != 0 {
+			return "", errors.Errorf("unbalanced parentheses in %q", params)
+		}
+
+		param := strings.TrimSpace(params[start:i])
+		start = i + 1
+		if param == "" {
+			if i == len(params) && len(types) == 0 {
+				break
+			}
+			return "", errors.Errorf("empty parameter in %q", params)
+		}
+
+		var t string
+		if strings.HasPrefix(param, "(") {
+			end := strings.LastIndexByte(param, ')')
+			inner, err := canonicalTypes(param[1:end])
+			if err != nil {
+				return "", err
+			}
+			t = "(" + inner + ")"
+			if suffix := strings.Fields(param[end+1:]); len(suffix) > 0 && strings.HasPrefix(suffix[0], "[") {
+				t += suffix[0]
+			}
+		} else {
+			t = canonicalABIType(strings.Fields(param)[0])
+		}
+		types = append(types, t)
+	}
+
+	return strings.Join(types, ","), nil
+}
+
+// canonicalSignature returns signature like "transfer(address to, uint amount)" as "transfer(address,uint256)"
+func canonicalSignature(signature string) (string, error) {
+	signature = strings.TrimSpace(signature)
+	for _, keyword := range []string{"function ", "event ", "error "} {
+		signature = strings.TrimSpace(strings.TrimPrefix(signature, keyword))
+	}
+	open := strings.IndexByte(signature, '(')
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", errors.Errorf("invalid signature %q", signature)
+	}
+	end := strings.LastIndexByte(signature, ')')
+
+	types, err := canonicalTypes(signature[open+1 : end])
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(signature[:open]) + "(" + types + ")", nil
+}
+
+// EventTopic returns the first topic of the logs of the event, signature is canonical like
+// "Transfer(address,address,uint256)" or a declaration with names and indexed keywords
+func EventTopic(signature string) (string, error) {
+	canonical, err := canonicalSignature(signature)
+	if err != nil {
+		return "", err
+	}
+
+	return Keccak256([]byte(canonical)), nil
+}
+
+// MethodSelector returns the 0x prefixed 4 bytes selector of the function, e.g. "0xa9059cbb" for
+// "transfer(address,uint256)", parameter names are allowed
+func MethodSelector(signature string) (string, error) {
+	canonical, err := canonicalSignature(signature)
+	if err != nil {
+		return "", err
+	}
+
+	return Keccak256([]byte(canonical))[:10], nil
+}
+
+// TopicValue returns the topic of an indexed argument value: addresses and integers are left padded to 32 bytes,
+// []byte (bytesN) is right padded. Accepted values are 0x prefixed hex strings of addresses or 32 bytes,
+// common.Address, common.Hash, byte arrays of those sizes, []byte, big.Int, *big.Int, sized integers and bool.
+// Indexed strings and bytes are logged as their hash, pass Keccak256 of them.
+func TopicValue(value interface{}) (string, error) {
+	word := make([]byte, 32)
+	switch v := value.(type) {
+	case string:
+		data, err := ParseBytes(v)
+		if err != nil {
+			return "", err
+		}
+		if len(data) != 20 && len(data) != 32 {
+			return "", errors.Wrapf(ErrInvalidHex, "%q is neither address nor 32 bytes", v)
+		}
+		copy(word[32-len(data):], data)
+	case []byte:
+		if len(v) > 32 {
+			return "", errors.Errorf("%d bytes don't fit a topic", len(v))
+		}
+		copy(word, v)
+	case bool:
+		if v {
+			word[31] = 1
+		}
+	case big.Int:
+		return TopicValue(&v)
+	case *big.Int:
+		if v.BitLen() > 255 && v.Sign() < 0 || v.BitLen() > 256 {
+			return "", errors.Errorf("%s doesn't fit a topic", v)
+		}
+		number := new(big.Int).Set(v)
+		if number.Sign() < 0 {
+			number.Add(number, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		number.FillBytes(word)
+	default:
+		rv := reflect.ValueOf(value)
+		switch {
+		case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+			return TopicValue(big.NewInt(rv.Int()))
+		case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uint64:
+			return TopicValue(new(big.Int).SetUint64(rv.Uint()))
+		case rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 && (rv.Len() == 20 || rv.Len() == 32):
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			copy(word[32-len(data):], data)
+		default:
+			return "", errors.Errorf("unsupported topic value %T", value)
+		}
+	}
+
+	return BytesToHex(word), nil
+}
+
+// EventTopics returns FilterParams.Topics of the logs of the event with indexed arguments matching values in order.
+// A nil value matches any, a slice matches any of its elements, e.g.
+//
+//	topics, err := EventTopics("Transfer(address,address,uint256)", nil, []string{alice, bob})
+//
+// filters transfers to alice or bob.
+func EventTopics(signature string, values ...interface{}) ([][]string, error) {
+	topic, err := EventTopic(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := [][]string{{topic}}
+	for i, value := range values {
+		var alternatives []string
+		rv := reflect.ValueOf(value)
+		switch {
+		case value == nil:
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8:
+			for j := 0; j < rv.Len(); j++ {
+				topic, err := TopicValue(rv.Index(j).Interface())
+				if err != nil {
+					return nil, errors.Wrapf(err, "indexed argument %d", i)
+				}
+				alternatives = append(alternatives, topic)
+			}
+		default:
+			topic, err := TopicValue(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "indexed argument %d", i)
+			}
+			alternatives = []string{topic}
+		}
+		topics = append(topics, alternatives)
+	}
+	for len(topics) > 1 && topics[len(topics)-1] == nil {
+		topics = topics[:len(topics)-1]
+	}
+
+	return topics, nil
+}
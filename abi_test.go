@@ -0,0 +1,64 @@
+package flashxroute
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodSelector(t *testing.T) {
+	for signature, selector := range map[string]string{
+		"transfer(address,uint256)":                                               "0xa9059cbb",
+		"function transfer(address to, uint amount)":                              "0xa9059cbb",
+		"balanceOf(address)":                                                      "0x70a08231",
+		"approve(address spender, uint256 amount)":                                "0x095ea7b3",
+		"swap(uint amount0Out, uint amount1Out, address to, bytes calldata data)": "0x022c0d9f",
+	} {
+		result, err := MethodSelector(signature)
+		require.Nil(t, err, signature)
+		require.Equal(t, selector, result, signature)
+	}
+
+	canonical, err := canonicalSignature("exactInputSingle((address tokenIn, address tokenOut, uint24 fee)[] params)")
+	require.Nil(t, err)
+	require.Equal(t, "exactInputSingle((address,address,uint24)[])", canonical)
+
+	_, err = MethodSelector("transfer(address,uint256")
+	require.NotNil(t, err)
+}
+
+func TestEventTopics(t *testing.T) {
+	topic, err := EventTopic("event Transfer(address indexed from, address indexed to, uint value)")
+	require.Nil(t, err)
+	require.Equal(t, transferTopic, topic)
+
+	alice := "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf"
+	topics, err := EventTopics("Transfer(address,address,uint256)", nil, []interface{}{alice, common.HexToAddress("0x1")}, nil)
+	require.Nil(t, err)
+	require.Equal(t, [][]string{
+		{transferTopic},
+		nil,
+		{"0x000000000000000000000000d10e3be2bc8f959bc8c41cf65f60de721cf89adf", "0x0000000000000000000000000000000000000000000000000000000000000001"},
+	}, topics)
+
+	for value, expected := range map[interface{}]string{
+		-1:         "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		uint8(255): "0x00000000000000000000000000000000000000000000000000000000000000ff",
+		true:       "0x0000000000000000000000000000000000000000000000000000000000000001",
+	} {
+		result, err := TopicValue(value)
+		require.Nil(t, err)
+		require.Equal(t, expected, result)
+	}
+
+	result, err := TopicValue([]byte{0xca, 0xfe})
+	require.Nil(t, err)
+	require.Equal(t, "0xcafe000000000000000000000000000000000000000000000000000000000000", result)
+
+	_, err = TopicValue(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.NotNil(t, err)
+	_, err = TopicValue("0x1234")
+	require.ErrorIs(t, err, ErrInvalidHex)
+}
@@ -0,0 +1,114 @@
+package flashxroute
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AccessTuple - EIP-2930 access list entry
+type AccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// AccessListResult - eth_createAccessList result, Error is the revert reason of the call when it failed
+type AccessListResult struct {
+	AccessList []AccessTuple
+	GasUsed    int // gas used by the call with the access list
+	Error      string
+}
+
+type proxyAccessListResult struct {
+	AccessList []AccessTuple `json:"accessList"`
+	GasUsed    hexInt        `json:"gasUsed"`
+	Error      string        `json:"error"`
+}
+
+func (proxy proxyAccessListResult) toResult() AccessListResult {
+	return AccessListResult{AccessList: proxy.AccessList, GasUsed: int(proxy.GasUsed), Error: proxy.Error}
+}
+
+// EthCreateAccessList returns the addresses and storage slots transaction accesses at block and the gas it uses
+// with them as access list
+func (rpc *FlashXRoute) EthCreateAccessList(transaction T, block string) (AccessListResult, error) {
+	var proxy proxyAccessListResult
+	err := rpc.call("eth_createAccessList", &proxy, transaction, block)
+
+	return proxy.toResult(), err
+}
+
+// StorageHotspot - storage slot accessed by several transactions of a bundle
+type StorageHotspot struct {
+	Address string
+	Slot    string
+	Txs     []int // indexes of the transactions accessing the slot
+}
+
+// BundleAccessList - access lists of the transactions of a bundle, see BundleAccessList
+type BundleAccessList struct {
+	Txs      []AccessListResult // by transaction
+	Union    []AccessTuple      // every address and slot accessed by the bundle, sorted
+	Hotspots []StorageHotspot   // slots shared by transactions, most shared first
+}
+
+// BundleAccessList creates the access list of every transaction of a bundle at block in one batch and merges them.
+// Each transaction is evaluated alone on the state of block, not after the previous ones, so the lists of later
+// legs may miss slots reached only through earlier legs. Legs without shared Hotspots don't depend on each other
+// and can be reordered, e.g. to move the legs worth pre-warming with their access list first.
+func (rpc *FlashXRoute) BundleAccessList(txs []T, block string) (BundleAccessList, error) {
+	bundle := BundleAccessList{Txs: make([]AccessListResult, len(txs))}
+	proxies := make([]proxyAccessListResult, len(txs))
+	requests := make([]BatchRequest, len(txs))
+	for i, tx := range txs {
+		requests[i] = BatchRequest{Method: "eth_createAccessList", Params: []interface{}{tx, block}, Result: &proxies[i]}
+	}
+	for i, res := range rpc.batchOrEach(requests...) {
+		if res.Err != nil {
+			return bundle, errors.Wrapf(res.Err, "access list of transaction %d", i)
+		}
+		bundle.Txs[i] = proxies[i].toResult()
+	}
+
+	slots := map[string]map[string][]int{} // txs by slot by address
+	for i, result := range bundle.Txs {
+		for _, tuple := range result.AccessList {
+			address := strings.ToLower(tuple.Address)
+			if slots[address] == nil {
+				slots[address] = map[string][]int{}
+			}
+			for _, key := range tuple.StorageKeys {
+				key = strings.ToLower(key)
+				if accessing := slots[address][key]; len(accessing) == 0 || accessing[len(accessing)-1] != i {
+					slots[address][key] = append(accessing, i)
+				}
+			}
+		}
+	}
+
+	for address, keys := range slots {
+		tuple := AccessTuple{Address: address, StorageKeys: make([]string, 0, len(keys))}
+		for key, accessing := range keys {
+			tuple.StorageKeys = append(tuple.StorageKeys, key)
+			if len(accessing) > 1 {
+				bundle.Hotspots = append(bundle.Hotspots, StorageHotspot{Address: address, Slot: key, Txs: accessing})
+			}
+		}
+		sort.Strings(tuple.StorageKeys)
+		bundle.Union = append(bundle.Union, tuple)
+	}
+	sort.Slice(bundle.Union, func(i, j int) bool { return bundle.Union[i].Address < bundle.Union[j].Address })
+	sort.Slice(bundle.Hotspots, func(i, j int) bool {
+		a, b := bundle.Hotspots[i], bundle.Hotspots[j]
+		if len(a.Txs) != len(b.Txs) {
+			return len(a.Txs) > len(b.Txs)
+		}
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		return a.Slot < b.Slot
+	})
+
+	return bundle, nil
+}
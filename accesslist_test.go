@@ -0,0 +1,37 @@
+package flashxroute
+
+import "strings"
+
+func (s *FlashXRouteTestSuite) TestBundleAccessList() {
+	pool := "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640"
+	weth := "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"
+	word := func(value string) string { return strings.Repeat("0", 64-len(value)) + value }
+	slot0, slot1, slot2 := "0x"+word("0"), "0x"+word("1"), "0x"+word("2")
+	s.registerMethods(map[string]string{
+		`eth_createAccessList [{"data":"0x01","from":"","to":"` + pool + `"},"latest"]`: `{"accessList": [
+			{"address": "0x88E6A0C2DDD26FEEB64F039A2C41296FCB3F5640", "storageKeys": ["` + slot0 + `", "` + slot1 + `"]},
+			{"address": "` + weth + `", "storageKeys": ["` + slot2 + `"]}], "gasUsed": "0x1d4c0"}`,
+		`eth_createAccessList [{"data":"0x02","from":"","to":"` + pool + `"},"latest"]`: `{"accessList": [
+			{"address": "` + pool + `", "storageKeys": ["` + slot1 + `"]}], "gasUsed": "0xea60"}`,
+		`eth_createAccessList [{"data":"0x03","from":"","to":"` + weth + `"},"latest"]`: `{"accessList": [
+			{"address": "` + weth + `", "storageKeys": ["` + slot2 + `"]}], "gasUsed": "0x7530", "error": "execution reverted"}`,
+	})
+
+	single, err := s.rpc.EthCreateAccessList(T{To: pool, Data: "0x02"}, "latest")
+	s.Require().Nil(err)
+	s.Require().Equal(60000, single.GasUsed)
+	s.Require().Equal([]AccessTuple{{Address: pool, StorageKeys: []string{slot1}}}, single.AccessList)
+
+	bundle, err := s.rpc.BundleAccessList([]T{{To: pool, Data: "0x01"}, {To: pool, Data: "0x02"}, {To: weth, Data: "0x03"}}, "latest")
+	s.Require().Nil(err)
+	s.Require().Len(bundle.Txs, 3)
+	s.Require().Equal("execution reverted", bundle.Txs[2].Error)
+	s.Require().Equal([]AccessTuple{
+		{Address: pool, StorageKeys: []string{slot0, slot1}},
+		{Address: weth, StorageKeys: []string{slot2}},
+	}, bundle.Union)
+	s.Require().Equal([]StorageHotspot{
+		{Address: pool, Slot: slot1, Txs: []int{0, 1}},
+		{Address: weth, Slot: slot2, Txs: []int{0, 2}},
+	}, bundle.Hotspots)
+}
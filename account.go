@@ -0,0 +1,51 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Account - state of an address at a block
+type Account struct {
+	Address string
+	Balance big.Int
+	Nonce   int
+	Code    string // 0x prefixed runtime code, "0x" for externally owned accounts
+}
+
+// IsContract reports whether the account has code
+func (a *Account) IsContract() bool {
+	return len(trimHexPrefix(a.Code)) > 0
+}
+
+// GetAccount returns balance, nonce and code of address at block in one batch request, a common pre-flight check
+// before signing bundle transactions. Endpoints refusing batches are queried call by call.
+func (rpc *FlashXRoute) GetAccount(address, block string) (*Account, error) {
+	if err := rpc.checkAddress(address); err != nil {
+		return nil, err
+	}
+
+	var balance, nonce, code string
+	results := rpc.batchOrEach(
+		BatchRequest{Method: "eth_getBalance", Params: []interface{}{address, block}, Result: &balance},
+		BatchRequest{Method: "eth_getTransactionCount", Params: []interface{}{address, block}, Result: &nonce},
+		BatchRequest{Method: "eth_getCode", Params: []interface{}{address, block}, Result: &code},
+	)
+	for _, result := range results {
+		if result.Err != nil {
+			return nil, errors.Wrap(result.Err, result.Method)
+		}
+	}
+
+	account := &Account{Address: address, Code: code}
+	var err error
+	if account.Balance, err = ParseBigInt(balance); err != nil {
+		return nil, err
+	}
+	if account.Nonce, err = ParseInt(nonce); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
@@ -0,0 +1,42 @@
+package flashxroute
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestGetAccount() {
+	address := "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf"
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := string(s.getBody(request))
+		s.Require().True(strings.HasPrefix(body, "["), body)
+		return httpmock.NewStringResponse(200, `[
+			{"jsonrpc":"2.0", "id":3, "result": "0x6080"},
+			{"jsonrpc":"2.0", "id":1, "result": "0xde0b6b3a7640000"},
+			{"jsonrpc":"2.0", "id":2, "result": "0x5"}
+		]`), nil
+	})
+
+	account, err := s.rpc.GetAccount(address, "latest")
+	s.Require().Nil(err)
+	s.Require().Equal("1", FormatEther(account.Balance))
+	s.Require().Equal(5, account.Nonce)
+	s.Require().Equal("0x6080", account.Code)
+	s.Require().True(account.IsContract())
+
+	results := map[string]string{"eth_getBalance": `"0x1"`, "eth_getTransactionCount": `"0x0"`, "eth_getCode": `"0x"`}
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		if strings.HasPrefix(string(body), "[") {
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":null, "error": {"code": -32600, "message": "batch not supported"}}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": `+results[gjson.GetBytes(body, "method").String()]+`}`), nil
+	})
+	account, err = s.rpc.GetAccount(address, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(int64(1), account.Balance.Int64())
+	s.Require().False(account.IsContract())
+}
@@ -0,0 +1,103 @@
+package flashxroute
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Address and hash validation errors
+var (
+	ErrInvalidAddress  = errors.New("invalid address")
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+	ErrInvalidHash     = errors.New("invalid hash")
+)
+
+// isHexDigits reports whether value is 0x followed by size hex digits
+func isHexDigits(value string, size int) bool {
+	if len(value) != size+2 || value[0] != '0' || value[1] != 'x' && value[1] != 'X' {
+		return false
+	}
+	_, err := hex.DecodeString(value[2:])
+	return err == nil
+}
+
+// checksum returns the EIP-55 mixed case form of 40 hex digits
+func checksum(digits string) string {
+	digits = strings.ToLower(digits)
+	hash := crypto.Keccak256([]byte(digits))
+
+	result := []byte(digits)
+	for i, c := range result {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0xf
+		}
+		if c >= 'a' && nibble >= 8 {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(result)
+}
+
+// ValidateAddress returns ErrInvalidAddress unless address is 0x followed by 40 hex digits, and ErrInvalidChecksum
+// when mixed case digits don't match the EIP-55 checksum. All lower or upper case addresses carry no checksum.
+func ValidateAddress(address string) error {
+	if !isHexDigits(address, 40) {
+		return fmt.Errorf("%w: %q", ErrInvalidAddress, address)
+	}
+	digits := address[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+	if checksum(digits) != "0x"+digits {
+		return fmt.Errorf("%w: %q", ErrInvalidChecksum, address)
+	}
+
+	return nil
+}
+
+// IsValidAddress reports whether address is valid, see ValidateAddress
+func IsValidAddress(address string) bool {
+	return ValidateAddress(address) == nil
+}
+
+// ToChecksumAddress returns address in EIP-55 mixed case, e.g. 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+func ToChecksumAddress(address string) (string, error) {
+	if err := ValidateAddress(address); err != nil {
+		return "", err
+	}
+
+	return checksum(address[2:]), nil
+}
+
+// ValidateHash returns ErrInvalidHash unless hash is 0x followed by 64 hex digits
+func ValidateHash(hash string) error {
+	if !isHexDigits(hash, 64) {
+		return fmt.Errorf("%w: %q", ErrInvalidHash, hash)
+	}
+
+	return nil
+}
+
+// checkAddress validates address parameter when the client validates parameters, see WithValidation
+func (rpc *FlashXRoute) checkAddress(address string) error {
+	if !rpc.validate {
+		return nil
+	}
+
+	return ValidateAddress(address)
+}
+
+// checkHash validates hash parameter when the client validates parameters, see WithValidation
+func (rpc *FlashXRoute) checkHash(hash string) error {
+	if !rpc.validate {
+		return nil
+	}
+
+	return ValidateHash(hash)
+}
@@ -0,0 +1,47 @@
+package flashxroute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	for _, address := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		require.True(t, IsValidAddress(address), address)
+
+		result, err := ToChecksumAddress(strings.ToLower(address))
+		require.Nil(t, err)
+		require.Equal(t, address, result)
+	}
+
+	require.True(t, IsValidAddress("0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"))
+	require.ErrorIs(t, ValidateAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"), ErrInvalidChecksum)
+	require.ErrorIs(t, ValidateAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"), ErrInvalidAddress)
+	require.ErrorIs(t, ValidateAddress("5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00"), ErrInvalidAddress)
+	require.ErrorIs(t, ValidateHash("0x1234"), ErrInvalidHash)
+	require.Nil(t, ValidateHash("0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"))
+}
+
+func (s *FlashXRouteTestSuite) TestValidation() {
+	s.registerMethods(map[string]string{"eth_getBalance": `"0x1"`})
+
+	_, err := s.rpc.EthGetBalance("0x1234", "latest")
+	s.Require().Nil(err)
+
+	rpc := New(s.rpc.url, WithValidation(true))
+	_, err = rpc.EthGetBalance("0x1234", "latest")
+	s.Require().ErrorIs(err, ErrInvalidAddress)
+	_, err = rpc.EthGetTransactionReceipt("0xabc")
+	s.Require().ErrorIs(err, ErrInvalidHash)
+
+	balance, err := rpc.EthGetBalance("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "latest")
+	s.Require().Nil(err)
+	s.Require().Equal(int64(1), balance.Int64())
+}
@@ -0,0 +1,132 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PeerNetwork - connection of a peer
+type PeerNetwork struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+}
+
+// PeerInfo - peer of admin_peers
+type PeerInfo struct {
+	ENR       string                     `json:"enr"`
+	Enode     string                     `json:"enode"`
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"` // client version, e.g. Geth/v1.13.5-stable/linux-amd64/go1.21.4
+	Caps      []string                   `json:"caps"`
+	Network   PeerNetwork                `json:"network"`
+	Protocols map[string]json.RawMessage `json:"protocols"` // protocol details by name, "handshake" while connecting
+}
+
+// ProtocolVersion returns the negotiated version of the protocol, e.g. "eth", zero when unknown or still
+// handshaking
+func (p PeerInfo) ProtocolVersion(protocol string) int {
+	var info struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(p.Protocols[protocol], &info); err != nil {
+		return 0
+	}
+
+	return info.Version
+}
+
+// Client returns the client name of the peer, e.g. Geth or erigon
+func (p PeerInfo) Client() string {
+	return strings.SplitN(p.Name, "/", 2)[0]
+}
+
+// NodePorts - ports of admin_nodeInfo
+type NodePorts struct {
+	Discovery int `json:"discovery"`
+	Listener  int `json:"listener"`
+}
+
+// NodeInfo - result of admin_nodeInfo
+type NodeInfo struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	Enode      string                     `json:"enode"`
+	ENR        string                     `json:"enr"`
+	IP         string                     `json:"ip"`
+	Ports      NodePorts                  `json:"ports"`
+	ListenAddr string                     `json:"listenAddr"`
+	Protocols  map[string]json.RawMessage `json:"protocols"` // e.g. eth with network, genesis and head
+}
+
+// AdminPeers returns the peers connected to the node, the admin namespace must be exposed by the node.
+func (rpc *FlashXRoute) AdminPeers() ([]PeerInfo, error) {
+	peers := []PeerInfo{}
+	err := rpc.call("admin_peers", &peers)
+	return peers, err
+}
+
+// AdminNodeInfo returns information about the node, the admin namespace must be exposed by the node.
+func (rpc *FlashXRoute) AdminNodeInfo() (*NodeInfo, error) {
+	info := new(NodeInfo)
+	if err := rpc.call("admin_nodeInfo", info); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// PeerHealth - summary of the node's connectivity, see NetHealth
+type PeerHealth struct {
+	NetworkID string
+	Listening bool
+	PeerCount int
+	Inbound   int // counted from admin_peers, zero when the admin namespace isn't available
+	Outbound  int
+	Trusted   int
+	Static    int
+	Clients   map[string]int // peers by client name
+	Peers     []PeerInfo     // nil when the admin namespace isn't available
+}
+
+// NetHealth returns network id, listening state and peer count of the node, and when the node exposes the admin
+// namespace the peers broken down by direction, trust and client. Failures of net_* methods are returned, a failing
+// admin_peers only leaves the breakdown empty.
+func (rpc *FlashXRoute) NetHealth() (PeerHealth, error) {
+	var health PeerHealth
+	var err error
+	if health.NetworkID, err = rpc.NetVersion(); err != nil {
+		return health, err
+	}
+	if health.Listening, err = rpc.NetListening(); err != nil {
+		return health, err
+	}
+	if health.PeerCount, err = rpc.NetPeerCount(); err != nil {
+		return health, err
+	}
+
+	peers, err := rpc.AdminPeers()
+	if err != nil {
+		return health, nil
+	}
+	health.Peers = peers
+	health.Clients = map[string]int{}
+	for _, peer := range peers {
+		if peer.Network.Inbound {
+			health.Inbound++
+		} else {
+			health.Outbound++
+		}
+		if peer.Network.Trusted {
+			health.Trusted++
+		}
+		if peer.Network.Static {
+			health.Static++
+		}
+		health.Clients[peer.Client()]++
+	}
+
+	return health, nil
+}
@@ -0,0 +1,48 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestNetHealth() {
+	s.registerMethods(map[string]string{
+		"net_version":   `"1"`,
+		"net_listening": `true`,
+		"net_peerCount": `"0x2"`,
+		"admin_peers": `[
+			{"enode": "enode://a@1.2.3.4:30303", "id": "a", "name": "Geth/v1.13.5-stable/linux-amd64/go1.21.4", "caps": ["eth/68"],
+				"network": {"localAddress": "10.0.0.1:30303", "remoteAddress": "1.2.3.4:30303", "inbound": true, "trusted": true, "static": false},
+				"protocols": {"eth": {"version": 68}}},
+			{"enode": "enode://b@5.6.7.8:30303", "id": "b", "name": "erigon/v2.55.0/linux-amd64/go1.21.4", "caps": ["eth/68"],
+				"network": {"localAddress": "10.0.0.1:40000", "remoteAddress": "5.6.7.8:30303", "inbound": false, "trusted": false, "static": true},
+				"protocols": {"eth": "handshake"}}
+		]`,
+		"admin_nodeInfo": `{"id": "c", "name": "Geth/v1.13.5", "enode": "enode://c@10.0.0.1:30303", "ip": "10.0.0.1",
+			"ports": {"discovery": 30303, "listener": 30303}, "listenAddr": "[::]:30303", "protocols": {"eth": {"network": 1}}}`,
+	})
+
+	health, err := s.rpc.NetHealth()
+	s.Require().Nil(err)
+	s.Require().Equal("1", health.NetworkID)
+	s.Require().True(health.Listening)
+	s.Require().Equal(2, health.PeerCount)
+	s.Require().Equal(1, health.Inbound)
+	s.Require().Equal(1, health.Outbound)
+	s.Require().Equal(1, health.Trusted)
+	s.Require().Equal(1, health.Static)
+	s.Require().Equal(map[string]int{"Geth": 1, "erigon": 1}, health.Clients)
+	s.Require().Equal(68, health.Peers[0].ProtocolVersion("eth"))
+	s.Require().Equal(0, health.Peers[1].ProtocolVersion("eth"))
+
+	info, err := s.rpc.AdminNodeInfo()
+	s.Require().Nil(err)
+	s.Require().Equal(30303, info.Ports.Listener)
+	s.Require().Equal("10.0.0.1", info.IP)
+
+	s.registerMethods(map[string]string{
+		"net_version":   `"1"`,
+		"net_listening": `true`,
+		"net_peerCount": `"0x2"`,
+		"admin_peers":   `error:{"code": -32601, "message": "the method admin_peers does not exist/is not available"}`,
+	})
+	health, err = s.rpc.NetHealth()
+	s.Require().Nil(err)
+	s.Require().Equal(2, health.PeerCount)
+	s.Require().Nil(health.Peers)
+}
@@ -0,0 +1,146 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// archiveHeadTTL - how long the head block number used to tell old blocks from recent ones is reused
+const archiveHeadTTL = 12 * time.Second
+
+// archiveBlockParams - index of the block parameter of methods reading state or blocks at a given block
+var archiveBlockParams = map[string]int{
+	"eth_getBalance":                          1,
+	"eth_getCode":                             1,
+	"eth_getTransactionCount":                 1,
+	"eth_getStorageAt":                        2,
+	"eth_getProof":                            2,
+	"eth_call":                                1,
+	"eth_estimateGas":                         1,
+	"eth_createAccessList":                    1,
+	"eth_feeHistory":                          1,
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockReceipts":                    0,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getUncleCountByBlockNumber":          0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getUncleByBlockNumberAndIndex":       0,
+	"eth_getLogs":                             0,
+	"debug_traceBlockByNumber":                0,
+	"debug_traceCall":                         1,
+	"trace_block":                             0,
+	"trace_call":                              2,
+	"trace_replayBlockTransactions":           0,
+}
+
+// archive - archive node serving calls at blocks older than the recent ones kept by a full node, see WithArchive
+type archive struct {
+	url    string
+	recent int // blocks behind the head still served by the full node
+
+	mu      sync.Mutex
+	head    int
+	fetched time.Time
+}
+
+// archiveURL returns the archive url when the call of method reads a block too old for the full node: "earliest",
+// a block number more than recent blocks behind the head or, for eth_getLogs, such a fromBlock
+func (rpc *FlashXRoute) archiveURL(method string, body []byte) (string, bool) {
+	if rpc.archive == nil {
+		return "", false
+	}
+	index, ok := archiveBlockParams[method]
+	if !ok {
+		return "", false
+	}
+
+	request := struct {
+		Params []json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil || index >= len(request.Params) {
+		return "", false
+	}
+	block := blockParam(request.Params[index])
+	switch block {
+	case "", "latest", "pending", "safe", "finalized":
+		return "", false
+	case "earliest":
+		return rpc.archive.url, true
+	}
+	number, err := ParseInt(block)
+	if err != nil {
+		return "", false
+	}
+	head, err := rpc.archiveHead()
+	if err != nil {
+		return "", false
+	}
+
+	return rpc.archive.url, number < head-rpc.archive.recent
+}
+
+// blockParam returns the block tag or number of a block parameter: a string, an EIP-1898 object with blockNumber
+// or a filter object with fromBlock
+func blockParam(raw json.RawMessage) string {
+	var tag string
+	if err := json.Unmarshal(raw, &tag); err == nil {
+		return tag
+	}
+
+	var object struct {
+		BlockNumber string `json:"blockNumber"`
+		FromBlock   string `json:"fromBlock"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return ""
+	}
+	if object.BlockNumber != "" {
+		return object.BlockNumber
+	}
+
+	return object.FromBlock
+}
+
+// archiveHead returns the head block number of the full node, fetched at most once per archiveHeadTTL
+func (rpc *FlashXRoute) archiveHead() (int, error) {
+	rpc.archive.mu.Lock()
+	defer rpc.archive.mu.Unlock()
+
+	if time.Since(rpc.archive.fetched) < archiveHeadTTL {
+		return rpc.archive.head, nil
+	}
+	head, err := rpc.EthBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+	rpc.archive.head, rpc.archive.fetched = head, time.Now()
+
+	return head, nil
+}
+
+// isMissingState reports whether err is a full node refusing a call for lack of historical state
+func isMissingState(err error) bool {
+	rpcErr, ok := err.(RpcError)
+	if !ok {
+		return false
+	}
+	message := strings.ToLower(rpcErr.Message)
+
+	return strings.Contains(message, "missing trie node") || strings.Contains(message, "historical state") ||
+		strings.Contains(message, "state not available") || strings.Contains(message, "pruned")
+}
+
+// postArchive sends the call to the archive node when the full node can't serve it, see WithArchive
+func (rpc *FlashXRoute) postArchive(ctx context.Context, method string, body []byte, result json.RawMessage, err error) (json.RawMessage, error) {
+	if rpc.archive == nil || !isMissingState(err) {
+		return result, err
+	}
+	if _, ok := archiveBlockParams[method]; !ok {
+		return result, err
+	}
+
+	return rpc.post(ctx, rpc.archive.url, method, body)
+}
@@ -0,0 +1,51 @@
+package flashxroute
+
+import (
+	"net/http"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestArchiveRouting() {
+	s.registerMethods(map[string]string{
+		"eth_blockNumber": `"0x100"`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","0xff"]`:   `"0x2"`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","latest"]`: `"0x2"`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","0x80"]`:   `error:{"code": -32000, "message": "missing trie node abc (path )"}`,
+		"eth_gasPrice": `"0x2"`,
+	})
+	var archived []string
+	archiveNode := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		archived = append(archived, gjson.GetBytes(body, "method").String()+" "+gjson.GetBytes(body, "params").Raw)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	})
+	defer archiveNode.Close()
+
+	rpc := s.rpc.With(WithArchive(archiveNode.URL, 128))
+	address := "0x0000000000000000000000000000000000000001"
+
+	for _, block := range []string{"0xff", "latest"} {
+		balance, err := rpc.EthGetBalance(address, block)
+		s.Require().Nil(err)
+		s.Require().Equal(int64(2), balance.Int64())
+	}
+	s.Require().Empty(archived)
+
+	for _, block := range []string{"0x7f", "earliest", "0x80"} {
+		balance, err := rpc.EthGetBalance(address, block)
+		s.Require().Nil(err)
+		s.Require().Equal(int64(1), balance.Int64())
+	}
+	_, err := rpc.Call("eth_getLogs", FilterParams{FromBlock: "0x1", ToBlock: "0x2"})
+	s.Require().Nil(err)
+	_, err = rpc.EthGasPrice()
+	s.Require().Nil(err)
+
+	s.Require().Equal([]string{
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","0x7f"]`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","earliest"]`,
+		`eth_getBalance ["0x0000000000000000000000000000000000000001","0x80"]`,
+		`eth_getLogs [{"fromBlock":"0x1","toBlock":"0x2"}]`,
+	}, archived)
+}
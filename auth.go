@@ -0,0 +1,182 @@
+package flashxroute
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AuthProvider - authenticates outgoing requests, body is the request body which must not be modified
+type AuthProvider interface {
+	Authenticate(req *http.Request, body []byte) error
+}
+
+// AuthFunc - function adapter of AuthProvider
+type AuthFunc func(req *http.Request, body []byte) error
+
+// Authenticate calls f
+func (f AuthFunc) Authenticate(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// HeaderAuth - sets a static header, e.g. an API key
+type HeaderAuth struct {
+	Name  string
+	Value string
+}
+
+// Authenticate sets the header
+func (a HeaderAuth) Authenticate(req *http.Request, body []byte) error {
+	req.Header.Set(a.Name, a.Value)
+	return nil
+}
+
+// BearerToken returns provider setting "Authorization: Bearer <token>"
+func BearerToken(token string) AuthProvider {
+	return HeaderAuth{Name: "Authorization", Value: "Bearer " + token}
+}
+
+// HMACAuth - signs every request with hex HMAC-SHA256 of timestamp and body using Secret, the unix timestamp is
+// sent in TimestampHeader (default X-Timestamp) and the signature in SignatureHeader (default X-Signature)
+type HMACAuth struct {
+	KeyID           string // sent in KeyIDHeader if set
+	KeyIDHeader     string
+	Secret          []byte
+	TimestampHeader string
+	SignatureHeader string
+	now             func() time.Time
+}
+
+// Authenticate signs the request
+func (a HMACAuth) Authenticate(req *http.Request, body []byte) error {
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	timestampHeader, signatureHeader := a.TimestampHeader, a.SignatureHeader
+	if timestampHeader == "" {
+		timestampHeader = "X-Timestamp"
+	}
+	if signatureHeader == "" {
+		signatureHeader = "X-Signature"
+	}
+
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+	mac := hmac.New(sha256.New, a.Secret)
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	if a.KeyIDHeader != "" {
+		req.Header.Set(a.KeyIDHeader, a.KeyID)
+	}
+
+	return nil
+}
+
+// RefreshingToken - bearer token fetched on demand and cached until shortly before it expires
+type RefreshingToken struct {
+	Fetch  func(ctx context.Context) (token string, expiry time.Time, err error)
+	Margin time.Duration // refresh this long before expiry (default: 30s)
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewRefreshingToken create refreshing bearer token provider
+func NewRefreshingToken(fetch func(ctx context.Context) (string, time.Time, error)) *RefreshingToken {
+	return &RefreshingToken{Fetch: fetch}
+}
+
+// Authenticate sets the cached token, fetching a new one if it is about to expire
+func (t *RefreshingToken) Authenticate(req *http.Request, body []byte) error {
+	token, err := t.Token(req.Context())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns the cached token, fetching a new one if it is about to expire
+func (t *RefreshingToken) Token(ctx context.Context) (string, error) {
+	margin := t.Margin
+	if margin == 0 {
+		margin = 30 * time.Second
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && time.Now().Add(margin).Before(t.expiry) {
+		return t.token, nil
+	}
+
+	token, expiry, err := t.Fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expiry = token, expiry
+
+	return token, nil
+}
+
+// authTransport - applies the auth provider to requests before passing them to base
+type authTransport struct {
+	base http.RoundTripper
+	auth AuthProvider
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+
+	// round trippers must not modify the request
+	authenticated := req.Clone(req.Context())
+	authenticated.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err := t.auth.Authenticate(authenticated, body); err != nil {
+		return nil, err
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(authenticated)
+}
+
+// newHTTPClient returns client for method sending requests through base (default: http.DefaultTransport, replaced
+// by the transport set with WithTransport) after applying the auth provider to the uncompressed body and compression
+func (rpc *FlashXRoute) newHTTPClient(method string, base http.RoundTripper) *http.Client {
+	if rpc.transport != nil {
+		base = rpc.transport
+	}
+	if rpc.compress != nil {
+		base = &compressTransport{base: base, compression: rpc.compress}
+	}
+	if rpc.auth != nil {
+		base = &authTransport{base: base, auth: rpc.auth}
+	}
+
+	return &http.Client{
+		Transport: base,
+		Timeout:   rpc.timeout(method),
+	}
+}
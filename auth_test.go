@@ -0,0 +1,80 @@
+package flashxroute
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestBearerTokenAuth() {
+	rpc := s.rpc.With(WithAuth(BearerToken("token")))
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		s.Require().Equal("Bearer token", request.Header.Get("Authorization"))
+		s.methodEqual(s.getBody(request), "eth_blockNumber")
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x10"}`), nil
+	})
+
+	number, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(16, number)
+}
+
+func (s *FlashXRouteTestSuite) TestHMACAuth() {
+	secret := []byte("secret")
+	auth := HMACAuth{KeyID: "key", KeyIDHeader: "X-Key", Secret: secret, now: func() time.Time { return time.Unix(1700000000, 0) }}
+
+	// bloXroute calls use their own transport, the provider applies there as well
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte("1700000000"))
+		mac.Write(body)
+		s.Require().Equal("1700000000", r.Header.Get("X-Timestamp"))
+		s.Require().Equal(hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Signature"))
+		s.Require().Equal("key", r.Header.Get("X-Key"))
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	})
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL), WithAuth(auth))
+	_, err := rpc.BloxrouteSendTransaction("", BloxrouteSendTransactionRequest{Transaction: "01"})
+	s.Require().Nil(err)
+	_, err = rpc.Call("eth_blockNumber")
+	s.Require().Nil(err)
+}
+
+func (s *FlashXRouteTestSuite) TestRefreshingTokenAuth() {
+	fetched := 0
+	token := NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		fetched++
+		if fetched == 3 {
+			return "", time.Time{}, errors.New("unauthorized")
+		}
+		// the second token expires within the margin and is refreshed on next use
+		return "token", time.Now().Add(time.Duration(2-fetched) * time.Hour), nil
+	})
+	rpc := s.rpc.With(WithAuth(token))
+	s.registerResponse(`"0x10"`, func(body []byte) {})
+
+	_, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	_, err = rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(1, fetched)
+
+	token.expiry = time.Now()
+	_, err = rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(2, fetched)
+
+	_, err = rpc.EthBlockNumber()
+	s.Require().ErrorContains(err, "unauthorized")
+	s.Require().Equal(3, fetched)
+}
@@ -0,0 +1,120 @@
+package flashxroute
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BalanceAlert - balance of a watched address crossing one of its thresholds
+type BalanceAlert struct {
+	Address   string
+	Balance   big.Int
+	Threshold big.Int
+	Below     bool   // the balance dropped below the threshold, false when it rose back to it
+	Block     string // block the balance was read at
+}
+
+// BalanceWatcher - checks the balances of watched addresses, e.g. bot wallets paying gas, and calls OnCross when one
+// crosses a threshold. An address already below a threshold when first checked is reported as crossing it.
+type BalanceWatcher struct {
+	OnCross func(alert BalanceAlert)
+
+	rpc *FlashXRoute
+
+	mu         sync.Mutex
+	thresholds map[string][]*big.Int
+	last       map[string]*big.Int
+}
+
+// NewBalanceWatcher create balance watcher calling onCross for every threshold crossed
+func NewBalanceWatcher(rpc *FlashXRoute, onCross func(alert BalanceAlert)) *BalanceWatcher {
+	return &BalanceWatcher{OnCross: onCross, rpc: rpc, thresholds: map[string][]*big.Int{}, last: map[string]*big.Int{}}
+}
+
+// Watch adds thresholds in wei of address
+func (w *BalanceWatcher) Watch(address string, thresholds ...*big.Int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := strings.ToLower(address)
+	for _, threshold := range thresholds {
+		w.thresholds[key] = append(w.thresholds[key], new(big.Int).Set(threshold))
+	}
+}
+
+// Unwatch removes address and its thresholds
+func (w *BalanceWatcher) Unwatch(address string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := strings.ToLower(address)
+	delete(w.thresholds, key)
+	delete(w.last, key)
+}
+
+// Check reads the balances of the watched addresses at block in one batch and returns the thresholds crossed since
+// the previous check, after passing them to OnCross
+func (w *BalanceWatcher) Check(block string) ([]BalanceAlert, error) {
+	w.mu.Lock()
+	addresses := make([]string, 0, len(w.thresholds))
+	for address := range w.thresholds {
+		addresses = append(addresses, address)
+	}
+	w.mu.Unlock()
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	balances := make([]hexBig, len(addresses))
+	requests := make([]BatchRequest, len(addresses))
+	for i, address := range addresses {
+		requests[i] = BatchRequest{Method: "eth_getBalance", Params: []interface{}{address, block}, Result: &balances[i]}
+	}
+	for i, result := range w.rpc.batchOrEach(requests...) {
+		if result.Err != nil {
+			return nil, errors.Wrapf(result.Err, "balance of %s", addresses[i])
+		}
+	}
+
+	var alerts []BalanceAlert
+	w.mu.Lock()
+	for i, address := range addresses {
+		balance := (*big.Int)(&balances[i])
+		previous, seen := w.last[address]
+		for _, threshold := range w.thresholds[address] {
+			below := balance.Cmp(threshold) < 0
+			if seen && below == (previous.Cmp(threshold) < 0) || !seen && !below {
+				continue
+			}
+			alert := BalanceAlert{Address: address, Balance: *new(big.Int).Set(balance), Threshold: *new(big.Int).Set(threshold), Below: below, Block: block}
+			alerts = append(alerts, alert)
+		}
+		if _, watched := w.thresholds[address]; watched {
+			w.last[address] = balance
+		}
+	}
+	w.mu.Unlock()
+
+	if w.OnCross != nil {
+		for _, alert := range alerts {
+			w.OnCross(alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+// Run checks the balances at every new head seen by WatchHeads until ctx is done, errors are passed to onError
+// when not nil
+func (w *BalanceWatcher) Run(ctx context.Context, pollInterval time.Duration, onError func(err error)) error {
+	return w.rpc.WatchHeads(ctx, pollInterval, func(head int) {
+		if _, err := w.Check(IntToHex(head)); err != nil && onError != nil {
+			onError(err)
+		}
+	}, onError)
+}
@@ -0,0 +1,65 @@
+package flashxroute
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+func (s *FlashXRouteTestSuite) TestBalanceWatcher() {
+	bot, other := "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"
+	set := func(botBalance, otherBalance string) {
+		s.registerMethods(map[string]string{
+			`eth_getBalance ["` + bot + `","0x10"]`:   `"` + botBalance + `"`,
+			`eth_getBalance ["` + other + `","0x10"]`: `"` + otherBalance + `"`,
+		})
+	}
+
+	var crossed []BalanceAlert
+	watcher := NewBalanceWatcher(s.rpc, func(alert BalanceAlert) { crossed = append(crossed, alert) })
+	watcher.Watch("0x00000000000000000000000000000000000000AA", big.NewInt(100), big.NewInt(10))
+	watcher.Watch(other, big.NewInt(100))
+
+	set("0x32", "0xc8")
+	alerts, err := watcher.Check("0x10")
+	s.Require().Nil(err)
+	s.Require().Equal([]BalanceAlert{{Address: bot, Balance: *big.NewInt(50), Threshold: *big.NewInt(100), Below: true, Block: "0x10"}}, alerts)
+
+	set("0x5", "0x63")
+	alerts, err = watcher.Check("0x10")
+	s.Require().Nil(err)
+	s.Require().Len(alerts, 2)
+	for _, alert := range alerts {
+		s.Require().True(alert.Below)
+		if alert.Address == bot {
+			s.Require().Equal(*big.NewInt(10), alert.Threshold)
+		}
+	}
+
+	set("0x64", "0x63")
+	alerts, err = watcher.Check("0x10")
+	s.Require().Nil(err)
+	s.Require().Len(alerts, 2)
+	s.Require().False(alerts[0].Below)
+	s.Require().False(alerts[1].Below)
+	s.Require().Len(crossed, 5)
+
+	watcher.Unwatch(bot)
+	set("0x0", "0x63")
+	alerts, err = watcher.Check("0x10")
+	s.Require().Nil(err)
+	s.Require().Empty(alerts)
+}
+
+func (s *FlashXRouteTestSuite) TestWatchHeads() {
+	s.registerMethods(map[string]string{"eth_blockNumber": `"0x10"`})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var heads []int
+	err := s.rpc.WatchHeads(ctx, time.Millisecond, func(head int) {
+		heads = append(heads, head)
+		time.AfterFunc(20*time.Millisecond, cancel)
+	}, nil)
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Require().Equal([]int{16}, heads)
+}
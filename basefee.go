@@ -0,0 +1,102 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// EIP-1559 base fee parameters
+const (
+	BaseFeeChangeDenominator = 8
+	ElasticityMultiplier     = 2
+)
+
+// ErrNoBaseFee is returned when predicting base fees from a block without one, e.g. before London
+var ErrNoBaseFee = errors.New("block has no base fee")
+
+// BaseFeePrediction - base fees of the blocks following Head. Next is exact, the bounds of later blocks depend on
+// how full the blocks in between will be: Min assumes they are all empty, Max that they are all full.
+type BaseFeePrediction struct {
+	Head int      // number of the block the prediction is based on
+	Next *big.Int // base fee of block Head+1
+	Min  []*big.Int
+	Max  []*big.Int // Min[i] and Max[i] bound the base fee of block Head+1+i
+}
+
+// Range returns the base fee bounds of the given block, ok is false if it is outside of the prediction
+func (p BaseFeePrediction) Range(block int) (min, max *big.Int, ok bool) {
+	i := block - p.Head - 1
+	if i < 0 || i >= len(p.Min) {
+		return nil, nil, false
+	}
+
+	return p.Min[i], p.Max[i], true
+}
+
+// CalcNextBaseFee returns the base fee of the block following a block with the given base fee, gas used and gas limit
+func CalcNextBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	target := gasLimit / ElasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+
+	if gasUsed > target {
+		delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, big.NewInt(BaseFeeChangeDenominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(BaseFeeChangeDenominator))
+	next := delta.Sub(baseFee, delta)
+	if next.Sign() < 0 {
+		next.SetInt64(0)
+	}
+	return next
+}
+
+// ProjectBaseFee returns base fee bounds of the given number of blocks starting with a block of base fee next,
+// assuming the gas limit stays the same
+func ProjectBaseFee(next *big.Int, gasLimit uint64, blocks int) (min, max []*big.Int) {
+	if blocks <= 0 {
+		return nil, nil
+	}
+
+	min, max = make([]*big.Int, blocks), make([]*big.Int, blocks)
+	min[0], max[0] = next, next
+	for i := 1; i < blocks; i++ {
+		min[i] = CalcNextBaseFee(min[i-1], 0, gasLimit)
+		max[i] = CalcNextBaseFee(max[i-1], gasLimit, gasLimit)
+	}
+
+	return min, max
+}
+
+// PredictBaseFee returns the exact base fee of the next block and the bounds of the following ones, blocks is the
+// number of blocks to predict including the next one
+func (rpc *FlashXRoute) PredictBaseFee(blocks int) (BaseFeePrediction, error) {
+	head, err := rpc.getBlockHeader("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return BaseFeePrediction{}, err
+	}
+	if head == nil || head.BaseFeePerGas.Sign() == 0 {
+		return BaseFeePrediction{}, ErrNoBaseFee
+	}
+
+	return PredictBaseFee(&Block{Number: head.Number, GasLimit: head.GasLimit, GasUsed: head.GasUsed, BaseFeePerGas: head.BaseFeePerGas}, blocks), nil
+}
+
+// PredictBaseFee returns the base fee prediction for the blocks following head
+func PredictBaseFee(head *Block, blocks int) BaseFeePrediction {
+	next := CalcNextBaseFee(&head.BaseFeePerGas, uint64(head.GasUsed), uint64(head.GasLimit))
+	prediction := BaseFeePrediction{Head: head.Number, Next: next}
+	prediction.Min, prediction.Max = ProjectBaseFee(next, uint64(head.GasLimit), blocks)
+
+	return prediction
+}
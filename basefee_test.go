@@ -0,0 +1,55 @@
+package flashxroute
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcNextBaseFee(t *testing.T) {
+	baseFee := big.NewInt(1000000000)
+
+	require.Equal(t, big.NewInt(1000000000), CalcNextBaseFee(baseFee, 15000000, 30000000))
+	require.Equal(t, big.NewInt(1125000000), CalcNextBaseFee(baseFee, 30000000, 30000000))
+	require.Equal(t, big.NewInt(875000000), CalcNextBaseFee(baseFee, 0, 30000000))
+	require.Equal(t, big.NewInt(1062500000), CalcNextBaseFee(baseFee, 22500000, 30000000))
+	// the base fee always increases by at least 1 wei above target
+	require.Equal(t, big.NewInt(8), CalcNextBaseFee(big.NewInt(7), 15000001, 30000000))
+	require.Equal(t, big.NewInt(1000000000), baseFee)
+}
+
+func TestProjectBaseFee(t *testing.T) {
+	min, max := ProjectBaseFee(big.NewInt(1000000000), 30000000, 3)
+	require.Equal(t, []*big.Int{big.NewInt(1000000000), big.NewInt(875000000), big.NewInt(765625000)}, min)
+	require.Equal(t, []*big.Int{big.NewInt(1000000000), big.NewInt(1125000000), big.NewInt(1265625000)}, max)
+
+	min, max = ProjectBaseFee(big.NewInt(1), 30000000, 0)
+	require.Nil(t, min)
+	require.Nil(t, max)
+}
+
+func (s *FlashXRouteTestSuite) TestPredictBaseFee() {
+	s.registerResponse(`{"number": "0x10", "gasLimit": "0x1c9c380", "gasUsed": "0x1c9c380", "baseFeePerGas": "0x3b9aca00", "transactions": []}`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockByNumber")
+		s.paramsEqual(body, `["latest", false]`)
+	})
+
+	prediction, err := s.rpc.PredictBaseFee(2)
+	s.Require().Nil(err)
+	s.Require().Equal(16, prediction.Head)
+	s.Require().Equal(big.NewInt(1125000000), prediction.Next)
+
+	min, max, ok := prediction.Range(18)
+	s.Require().True(ok)
+	s.Require().Equal(big.NewInt(984375000), min)
+	s.Require().Equal(big.NewInt(1265625000), max)
+	_, _, ok = prediction.Range(19)
+	s.Require().False(ok)
+	_, _, ok = prediction.Range(16)
+	s.Require().False(ok)
+
+	s.registerResponse(`{"number": "0x10", "gasLimit": "0x1c9c380", "gasUsed": "0x0", "transactions": []}`, func(body []byte) {})
+	_, err = s.rpc.PredictBaseFee(2)
+	s.Require().ErrorIs(err, ErrNoBaseFee)
+}
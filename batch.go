@@ -0,0 +1,137 @@
+package flashxroute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMissingBatchResponse is the error of a batch entry the endpoint didn't answer
+var ErrMissingBatchResponse = errors.New("no response for batch request")
+
+// BatchRequest - call of a batch
+type BatchRequest struct {
+	Method string
+	Params []interface{}
+	Result interface{} // optional pointer the result is unmarshalled into
+}
+
+// BatchResult - outcome of a BatchRequest, a failed entry doesn't affect the others
+type BatchResult struct {
+	Method string
+	Result json.RawMessage
+	Err    error // RpcError answered for the entry, ErrMissingBatchResponse or the error unmarshalling into Result
+}
+
+// Decode unmarshals the result into target, returning the error of the entry if it failed
+func (res BatchResult) Decode(target interface{}) error {
+	if res.Err != nil {
+		return res.Err
+	}
+
+	return json.Unmarshal(res.Result, target)
+}
+
+// BatchCall sends requests as one json-rpc batch and returns their results in request order. Responses are matched
+// to requests by id, as endpoints may answer in any order. The error is only set when the batch as a whole failed,
+// errors of single entries are in their BatchResult.
+func (rpc *FlashXRoute) BatchCall(requests ...BatchRequest) ([]BatchResult, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	stateChanging := false
+	batch := make([]rpcRequest, len(requests))
+	for i, request := range requests {
+		if submissionMethods[request.Method] {
+			if rpc.recorder != nil {
+				return nil, errors.Errorf("dry run can't record %s in a batch", request.Method)
+			}
+			stateChanging = true
+		}
+		batch[i] = rpcRequest{ID: i + 1, JSONRPC: "2.0", Method: request.Method, Params: request.Params}
+		if batch[i].Params == nil {
+			batch[i].Params = []interface{}{}
+		}
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := rpc.retry("batch", stateChanging, func() (json.RawMessage, error) {
+		if rpc.ipc != nil {
+			return rpc.roundTripIPC("batch", body)
+		}
+		return rpc.postData(context.Background(), rpc.url, "batch", body)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.matchBatch(requests, data)
+}
+
+// matchBatch correlates the responses of data with requests by id
+func (rpc *FlashXRoute) matchBatch(requests []BatchRequest, data []byte) ([]BatchResult, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// the endpoint rejected the whole batch, e.g. too many requests
+		if _, err := decodeResponse(trimmed); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("batch answered with a single response")
+	}
+
+	var responses []rpcResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(requests))
+	answered := make([]bool, len(requests))
+	for _, resp := range responses {
+		i := resp.ID - 1
+		if i < 0 || i >= len(requests) || answered[i] {
+			continue
+		}
+		answered[i] = true
+		if resp.Error != nil {
+			results[i].Err = resp.Error.toError()
+			continue
+		}
+		results[i].Result = resp.Result
+	}
+
+	for i, request := range requests {
+		results[i].Method = request.Method
+		switch {
+		case !answered[i]:
+			results[i].Err = errors.Wrap(ErrMissingBatchResponse, request.Method)
+		case results[i].Err == nil && request.Result != nil:
+			results[i].Err = rpc.decode(results[i].Result, request.Result)
+		}
+	}
+
+	return results, nil
+}
+
+// batchOrEach sends requests with BatchCall or, when the endpoint refuses batches, one by one
+func (rpc *FlashXRoute) batchOrEach(requests ...BatchRequest) []BatchResult {
+	results, err := rpc.BatchCall(requests...)
+	if err == nil {
+		return results
+	}
+
+	results = make([]BatchResult, len(requests))
+	for i, request := range requests {
+		results[i].Method = request.Method
+		results[i].Result, results[i].Err = rpc.Call(request.Method, request.Params...)
+		if results[i].Err == nil && request.Result != nil {
+			results[i].Err = rpc.decode(results[i].Result, request.Result)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,43 @@
+package flashxroute
+
+import (
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestBatchCall() {
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.Require().Equal("eth_blockNumber", gjson.GetBytes(body, "0.method").String())
+		s.Require().Equal(int64(1), gjson.GetBytes(body, "0.id").Int())
+		s.Require().Equal(`[]`, gjson.GetBytes(body, "0.params").Raw)
+		s.Require().Equal("0x1", gjson.GetBytes(body, "1.params.0").String())
+		return httpmock.NewStringResponse(200, `[
+			{"jsonrpc":"2.0", "id":3, "error": {"code": -32000, "message": "header not found"}},
+			{"jsonrpc":"2.0", "id":1, "result": "0x10"},
+			{"jsonrpc":"2.0", "id":9, "result": "0x0"}
+		]`), nil
+	})
+
+	var number string
+	results, err := s.rpc.BatchCall(
+		BatchRequest{Method: "eth_blockNumber", Result: &number},
+		BatchRequest{Method: "eth_getBalance", Params: []interface{}{"0x1", "latest"}},
+		BatchRequest{Method: "eth_getBlockByNumber", Params: []interface{}{"0x1", false}},
+	)
+	s.Require().Nil(err)
+	s.Require().Len(results, 3)
+	s.Require().Equal("0x10", number)
+	s.Require().Nil(results[0].Err)
+	s.Require().Equal("eth_getBalance", results[1].Method)
+	s.Require().ErrorIs(results[1].Err, ErrMissingBatchResponse)
+	s.Require().Equal(RpcError{Code: -32000, Message: "header not found"}, results[2].Err)
+	var block Block
+	s.Require().Equal(results[2].Err, results[2].Decode(&block))
+
+	httpmock.RegisterResponder("POST", s.rpc.url, httpmock.NewStringResponder(200, `{"jsonrpc":"2.0", "id":null, "error": {"code": -32600, "message": "batch too large"}}`))
+	_, err = s.rpc.BatchCall(BatchRequest{Method: "eth_blockNumber"})
+	s.Require().Equal(RpcError{Code: -32600, Message: "batch too large"}, err)
+}
@@ -0,0 +1,135 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlotsPerEpoch - number of slots in a beacon chain epoch
+const SlotsPerEpoch = 32
+
+// BeaconError - beacon node API error response
+type BeaconError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err BeaconError) Error() string {
+	return fmt.Sprintf("Beacon error %d (%s)", err.Code, err.Message)
+}
+
+// ProposerDuty - validator scheduled to propose the block of a slot
+type ProposerDuty struct {
+	Pubkey         string
+	ValidatorIndex uint64
+	Slot           uint64
+}
+
+type proxyProposerDuty struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+}
+
+// BeaconClient - minimal beacon node REST API client
+type BeaconClient struct {
+	url     string
+	Headers map[string]string // Additional headers to send with the request
+	Timeout time.Duration
+}
+
+// NewBeaconClient create beacon node API client with given url, e.g. http://127.0.0.1:5052
+func NewBeaconClient(url string) *BeaconClient {
+	return &BeaconClient{
+		url:     strings.TrimSuffix(url, "/"),
+		Headers: make(map[string]string),
+		Timeout: 10 * time.Second,
+	}
+}
+
+// URL returns beacon node url
+func (c *BeaconClient) URL() string {
+	return c.url
+}
+
+func (c *BeaconClient) get(path string, target interface{}) error {
+	req, err := http.NewRequest("GET", c.url+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Accept", "application/json")
+	for k, v := range c.Headers {
+		req.Header.Add(k, v)
+	}
+	httpClient := &http.Client{
+		Timeout: c.Timeout,
+	}
+
+	response, err := httpClient.Do(req)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		beaconErr := BeaconError{Code: response.StatusCode}
+		json.Unmarshal(data, &beaconErr)
+		return beaconErr
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+// ProposerDuties returns block proposers of all slots in epoch
+func (c *BeaconClient) ProposerDuties(epoch uint64) ([]ProposerDuty, error) {
+	response := struct {
+		Data []proxyProposerDuty `json:"data"`
+	}{}
+	if err := c.get(fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), &response); err != nil {
+		return nil, err
+	}
+
+	duties := make([]ProposerDuty, len(response.Data))
+	for i, duty := range response.Data {
+		index, err := strconv.ParseUint(duty.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		slot, err := strconv.ParseUint(duty.Slot, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		duties[i] = ProposerDuty{Pubkey: duty.Pubkey, ValidatorIndex: index, Slot: slot}
+	}
+
+	return duties, nil
+}
+
+// ProposerForSlot returns the proposer duty of slot, nil if the beacon node doesn't report one
+func (c *BeaconClient) ProposerForSlot(slot uint64) (*ProposerDuty, error) {
+	duties, err := c.ProposerDuties(slot / SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range duties {
+		if duties[i].Slot == slot {
+			return &duties[i], nil
+		}
+	}
+
+	return nil, nil
+}
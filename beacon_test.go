@@ -0,0 +1,41 @@
+package flashxroute
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeaconProposerDuties(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := NewBeaconClient("http://127.0.0.1:5052/")
+	httpmock.RegisterResponder("GET", "http://127.0.0.1:5052/eth/v1/validator/duties/proposer/2", httpmock.NewStringResponder(200, `{
+		"dependent_root": "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+		"execution_optimistic": false,
+		"data": [
+			{"pubkey": "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a", "validator_index": "1", "slot": "64"},
+			{"pubkey": "0xa1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c", "validator_index": "12345", "slot": "65"}
+		]
+	}`))
+	httpmock.RegisterResponder("GET", "http://127.0.0.1:5052/eth/v1/validator/duties/proposer/9", httpmock.NewStringResponder(400, `{"code": 400, "message": "Invalid epoch"}`))
+
+	duties, err := client.ProposerDuties(2)
+	require.Nil(t, err)
+	require.Len(t, duties, 2)
+	require.Equal(t, uint64(12345), duties[1].ValidatorIndex)
+	require.Equal(t, uint64(65), duties[1].Slot)
+
+	duty, err := client.ProposerForSlot(65)
+	require.Nil(t, err)
+	require.Equal(t, uint64(12345), duty.ValidatorIndex)
+
+	duty, err = client.ProposerForSlot(70)
+	require.Nil(t, err)
+	require.Nil(t, duty)
+
+	_, err = client.ProposerDuties(9)
+	require.Equal(t, BeaconError{Code: 400, Message: "Invalid epoch"}, err)
+}
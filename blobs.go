@@ -0,0 +1,293 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Blob transaction errors
+var (
+	ErrNotBlobTx          = errors.New("not a blob transaction")
+	ErrMissingBlobSidecar = errors.New("blob transaction without sidecar")
+	ErrBlobsUnsupported   = errors.New("builder doesn't accept blob transactions")
+)
+
+// EIP-4844 blob gas parameters (Cancun)
+const (
+	BlobGasPerBlob            = 1 << 17
+	TargetBlobGasPerBlock     = 3 * BlobGasPerBlob
+	MaxBlobGasPerBlock        = 6 * BlobGasPerBlob
+	MinBlobBaseFee            = 1
+	BlobBaseFeeUpdateFraction = 3338477
+)
+
+// EIP-4844 transaction encoding
+const (
+	BlobTxType        = 0x03
+	BlobSize          = 4096 * 32
+	KZGCommitmentSize = 48
+	KZGProofSize      = 48
+)
+
+// fields of the blob transaction body list
+const (
+	blobTxBodyFields    = 14
+	blobTxFeeCapField   = 9
+	blobTxBlobHashField = 10
+)
+
+// EthBlobBaseFee returns the base fee per blob gas in wei expected for the next block.
+func (rpc *FlashXRoute) EthBlobBaseFee() (big.Int, error) {
+	var response string
+	if err := rpc.call("eth_blobBaseFee", &response); err != nil {
+		return big.Int{}, err
+	}
+
+	return ParseBigInt(response)
+}
+
+// CalcExcessBlobGas returns the excess blob gas of a block given its parent's excess and used blob gas
+func CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed uint64) uint64 {
+	if parentExcessBlobGas+parentBlobGasUsed < TargetBlobGasPerBlock {
+		return 0
+	}
+
+	return parentExcessBlobGas + parentBlobGasUsed - TargetBlobGasPerBlock
+}
+
+// CalcBlobBaseFee returns the base fee per blob gas for a block with the given excess blob gas
+func CalcBlobBaseFee(excessBlobGas uint64) *big.Int {
+	return fakeExponential(big.NewInt(MinBlobBaseFee), new(big.Int).SetUint64(excessBlobGas), big.NewInt(BlobBaseFeeUpdateFraction))
+}
+
+// BlobGas returns the blob gas consumed by the given number of blobs
+func BlobGas(blobs int) uint64 {
+	return uint64(blobs) * BlobGasPerBlob
+}
+
+// BlobCost returns the fee in wei paid for the given number of blobs at blobBaseFee
+func BlobCost(blobs int, blobBaseFee *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(BlobGas(blobs)), blobBaseFee)
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using Taylor expansion, as specified by EIP-4844
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	var (
+		output = new(big.Int)
+		accum  = new(big.Int).Mul(factor, denominator)
+	)
+	for i := 1; accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(int64(i)))
+	}
+
+	return output.Div(output, denominator)
+}
+
+// BlobSidecar - blobs with their KZG commitments and proofs, carried by blob transactions in their network form
+type BlobSidecar struct {
+	Blobs       [][]byte
+	Commitments [][]byte
+	Proofs      [][]byte
+}
+
+// validate checks the sidecar carries blobs matching the number of versioned hashes, KZG proofs are not verified
+func (s BlobSidecar) validate(blobHashes int) error {
+	if len(s.Blobs) != blobHashes || len(s.Commitments) != blobHashes || len(s.Proofs) != blobHashes {
+		return errors.Errorf("sidecar has %d blobs, %d commitments and %d proofs for %d blob hashes", len(s.Blobs), len(s.Commitments), len(s.Proofs), blobHashes)
+	}
+	for i := range s.Blobs {
+		if len(s.Blobs[i]) != BlobSize || len(s.Commitments[i]) != KZGCommitmentSize || len(s.Proofs[i]) != KZGProofSize {
+			return errors.Errorf("sidecar blob %d has invalid size", i)
+		}
+	}
+
+	return nil
+}
+
+// BlobTx - decoded fields of a raw EIP-4844 transaction
+type BlobTx struct {
+	Hash             string // hash of the canonical form, without sidecar
+	MaxFeePerBlobGas big.Int
+	BlobHashes       []string     // versioned hashes of the blobs
+	Sidecar          *BlobSidecar // nil for the canonical form
+	body             []byte       // encoded transaction body list
+}
+
+// BlobGas returns the blob gas consumed by the transaction
+func (tx *BlobTx) BlobGas() uint64 {
+	return BlobGas(len(tx.BlobHashes))
+}
+
+// Canonical returns the 0x prefixed transaction without sidecar, as included in blocks
+func (tx *BlobTx) Canonical() string {
+	return BytesToHex(append([]byte{BlobTxType}, tx.body...))
+}
+
+// Network returns the 0x prefixed transaction with its sidecar, as builders and the mempool expect it
+func (tx *BlobTx) Network() (string, error) {
+	if tx.Sidecar == nil {
+		return "", ErrMissingBlobSidecar
+	}
+
+	encode := func(values [][]byte) []byte {
+		items := make([][]byte, len(values))
+		for i, value := range values {
+			items[i] = rlpBytes(value)
+		}
+		return rlpList(items...)
+	}
+	wrapper := rlpList(tx.body, encode(tx.Sidecar.Blobs), encode(tx.Sidecar.Commitments), encode(tx.Sidecar.Proofs))
+
+	return BytesToHex(append([]byte{BlobTxType}, wrapper...)), nil
+}
+
+// DecodeBlobTx decodes raw blob transaction in canonical or network form, ErrNotBlobTx is returned for other
+// transaction types
+func DecodeBlobTx(raw string) (*BlobTx, error) {
+	data, err := ParseBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || data[0] != BlobTxType {
+		return nil, ErrNotBlobTx
+	}
+
+	list, content, rest, err := rlpSplit(data[1:])
+	if err == nil && (!list || len(rest) > 0) {
+		err = errors.Wrap(errInvalidRLP, "transaction is not a single list")
+	}
+	if err != nil {
+		return nil, err
+	}
+	items, err := rlpItems(content)
+	if err == nil && len(items) == 0 {
+		err = errors.Wrap(errInvalidRLP, "empty transaction")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(BlobTx)
+	tx.body = data[1:]
+	if first, _, _, _ := rlpSplit(items[0]); first {
+		// network form: [body, blobs, commitments, proofs]
+		if len(items) != 4 {
+			return nil, errors.Wrapf(errInvalidRLP, "network form has %d items", len(items))
+		}
+		tx.body = items[0]
+		tx.Sidecar = new(BlobSidecar)
+		for i, target := range []*[][]byte{&tx.Sidecar.Blobs, &tx.Sidecar.Commitments, &tx.Sidecar.Proofs} {
+			_, content, _, _ := rlpSplit(items[i+1])
+			values, err := rlpItems(content)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				_, bytes, _, err := rlpSplit(value)
+				if err != nil {
+					return nil, err
+				}
+				*target = append(*target, bytes)
+			}
+		}
+	}
+
+	_, content, _, _ = rlpSplit(tx.body)
+	fields, err := rlpItems(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != blobTxBodyFields {
+		return nil, errors.Wrapf(errInvalidRLP, "blob transaction has %d fields", len(fields))
+	}
+	_, feeCap, _, _ := rlpSplit(fields[blobTxFeeCapField])
+	tx.MaxFeePerBlobGas.SetBytes(feeCap)
+	_, hashes, _, _ := rlpSplit(fields[blobTxBlobHashField])
+	hashItems, err := rlpItems(hashes)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range hashItems {
+		_, hash, _, _ := rlpSplit(item)
+		tx.BlobHashes = append(tx.BlobHashes, BytesToHex(hash))
+	}
+	if tx.Sidecar != nil {
+		if err := tx.Sidecar.validate(len(tx.BlobHashes)); err != nil {
+			return nil, err
+		}
+	}
+	tx.Hash = Keccak256(append([]byte{BlobTxType}, tx.body...))
+
+	return tx, nil
+}
+
+// IsBlobTx reports whether raw is an EIP-4844 transaction
+func IsBlobTx(raw string) bool {
+	data, err := ParseBytes(raw)
+	return err == nil && len(data) > 0 && data[0] == BlobTxType
+}
+
+// BlobTxWithSidecar returns the network form of raw blob transaction carrying sidecar
+func BlobTxWithSidecar(raw string, sidecar BlobSidecar) (string, error) {
+	tx, err := DecodeBlobTx(raw)
+	if err != nil {
+		return "", err
+	}
+	if err := sidecar.validate(len(tx.BlobHashes)); err != nil {
+		return "", err
+	}
+	tx.Sidecar = &sidecar
+
+	return tx.Network()
+}
+
+// RawTxHash returns the hash of a raw transaction, blob transactions in network form are hashed without sidecar
+func RawTxHash(raw string) (string, error) {
+	if IsBlobTx(raw) {
+		tx, err := DecodeBlobTx(raw)
+		if err != nil {
+			return "", err
+		}
+		return tx.Hash, nil
+	}
+
+	data, err := ParseBytes(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return Keccak256(data), nil
+}
+
+// BundleBlobGas returns the blob gas of the blob transactions in txs
+func BundleBlobGas(txs []string) (uint64, error) {
+	var gas uint64
+	for _, raw := range txs {
+		if !IsBlobTx(raw) {
+			continue
+		}
+		tx, err := DecodeBlobTx(raw)
+		if err != nil {
+			return 0, err
+		}
+		gas += tx.BlobGas()
+	}
+
+	return gas, nil
+}
+
+// BundleBlobCost returns the blob fees in wei the blob transactions in txs pay at blobBaseFee, burned instead of
+// paid to the builder, so they reduce the searcher profit without showing in coinbaseDiff
+func BundleBlobCost(txs []string, blobBaseFee *big.Int) (*big.Int, error) {
+	gas, err := BundleBlobGas(txs)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), blobBaseFee), nil
+}
@@ -0,0 +1,133 @@
+package flashxroute
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcBlobBaseFee(t *testing.T) {
+	tests := []struct {
+		excessBlobGas uint64
+		blobBaseFee   int64
+	}{
+		{0, 1},
+		{2314057, 1},
+		{2314058, 2},
+		{10 * 1024 * 1024, 23},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.blobBaseFee, CalcBlobBaseFee(test.excessBlobGas).Int64(), test.excessBlobGas)
+	}
+}
+
+func TestCalcExcessBlobGas(t *testing.T) {
+	require.Equal(t, uint64(0), CalcExcessBlobGas(0, BlobGas(2)))
+	require.Equal(t, uint64(0), CalcExcessBlobGas(0, TargetBlobGasPerBlock))
+	require.Equal(t, uint64(BlobGasPerBlob), CalcExcessBlobGas(0, BlobGas(4)))
+	require.Equal(t, uint64(BlobGasPerBlob), CalcExcessBlobGas(2*BlobGasPerBlob, BlobGas(2)))
+}
+
+func TestBlobCost(t *testing.T) {
+	require.Equal(t, big.NewInt(2*BlobGasPerBlob*7), BlobCost(2, big.NewInt(7)))
+}
+
+func (s *FlashXRouteTestSuite) TestEthBlobBaseFee() {
+	s.registerResponse(`"0x3b9aca00"`, func(body []byte) {
+		s.methodEqual(body, "eth_blobBaseFee")
+		s.paramsEqual(body, "null")
+	})
+
+	fee, err := s.rpc.EthBlobBaseFee()
+	s.Require().Nil(err)
+	s.Require().Equal(int64(1000000000), fee.Int64())
+}
+
+// canonicalBlobTx encodes a blob transaction body with the given blob hashes and max fee per blob gas
+func canonicalBlobTx(maxFeePerBlobGas byte, blobHashes ...[]byte) string {
+	hashes := make([][]byte, len(blobHashes))
+	for i, hash := range blobHashes {
+		hashes[i] = rlpBytes(hash)
+	}
+	fields := [][]byte{
+		rlpBytes([]byte{1}), rlpBytes(nil), rlpBytes([]byte{2}), rlpBytes([]byte{3}), rlpBytes([]byte{0x52, 0x08}),
+		rlpBytes(make([]byte, 20)), rlpBytes(nil), rlpBytes(nil), rlpList(),
+		rlpBytes([]byte{maxFeePerBlobGas}), rlpList(hashes...),
+		rlpBytes([]byte{1}), rlpBytes([]byte{4}), rlpBytes([]byte{5}),
+	}
+
+	return BytesToHex(append([]byte{BlobTxType}, rlpList(fields...)...))
+}
+
+func testSidecar(blobs int) BlobSidecar {
+	var sidecar BlobSidecar
+	for i := 0; i < blobs; i++ {
+		sidecar.Blobs = append(sidecar.Blobs, make([]byte, BlobSize))
+		sidecar.Commitments = append(sidecar.Commitments, make([]byte, KZGCommitmentSize))
+		sidecar.Proofs = append(sidecar.Proofs, make([]byte, KZGProofSize))
+	}
+
+	return sidecar
+}
+
+func TestDecodeBlobTx(t *testing.T) {
+	hash1, hash2 := append([]byte{1}, make([]byte, 31)...), append([]byte{1}, make([]byte, 30)...)
+	hash2 = append(hash2, 2)
+	canonical := canonicalBlobTx(7, hash1, hash2)
+
+	tx, err := DecodeBlobTx(canonical)
+	require.NoError(t, err)
+	require.Nil(t, tx.Sidecar)
+	require.Equal(t, int64(7), tx.MaxFeePerBlobGas.Int64())
+	require.Equal(t, []string{BytesToHex(hash1), BytesToHex(hash2)}, tx.BlobHashes)
+	require.Equal(t, uint64(2*BlobGasPerBlob), tx.BlobGas())
+	require.Equal(t, canonical, tx.Canonical())
+	_, err = tx.Network()
+	require.ErrorIs(t, err, ErrMissingBlobSidecar)
+
+	_, err = BlobTxWithSidecar(canonical, testSidecar(1))
+	require.Error(t, err)
+	network, err := BlobTxWithSidecar(canonical, testSidecar(2))
+	require.NoError(t, err)
+	require.Greater(t, len(network), 2*2*BlobSize)
+
+	decoded, err := DecodeBlobTx(network)
+	require.NoError(t, err)
+	require.NotNil(t, decoded.Sidecar)
+	require.Len(t, decoded.Sidecar.Blobs, 2)
+	require.Equal(t, tx.Hash, decoded.Hash)
+	require.Equal(t, canonical, decoded.Canonical())
+
+	hash, err := RawTxHash(network)
+	require.NoError(t, err)
+	require.Equal(t, Keccak256(mustParseBytes(canonical)), hash)
+	hash, err = RawTxHash("0x02c0")
+	require.NoError(t, err)
+	require.Equal(t, Keccak256([]byte{2, 0xc0}), hash)
+
+	_, err = DecodeBlobTx("0x02c0")
+	require.ErrorIs(t, err, ErrNotBlobTx)
+	_, err = DecodeBlobTx("0x03c0")
+	require.ErrorIs(t, err, errInvalidRLP)
+	require.True(t, IsBlobTx(network))
+	require.False(t, IsBlobTx("02c0"))
+
+	cost, err := BundleBlobCost([]string{"0x02c0", network, canonicalBlobTx(1, hash1)}, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3*BlobGasPerBlob*10), cost)
+}
+
+func TestBuilderCheckBlobs(t *testing.T) {
+	canonical := canonicalBlobTx(1, make([]byte, 32))
+	network, err := BlobTxWithSidecar(canonical, testSidecar(1))
+	require.NoError(t, err)
+
+	builder := Builder{Name: "plain"}
+	require.NoError(t, builder.checkBlobs([]string{"0x02c0"}))
+	require.ErrorIs(t, builder.checkBlobs([]string{"0x02c0", network}), ErrBlobsUnsupported)
+
+	builder.BlobTransactions = true
+	require.NoError(t, builder.checkBlobs([]string{"0x02c0", network}))
+	require.ErrorIs(t, builder.checkBlobs([]string{canonical}), ErrMissingBlobSidecar)
+}
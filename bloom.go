@@ -0,0 +1,126 @@
+package flashxroute
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// BloomLength - size in bytes of a logs bloom
+const BloomLength = 256
+
+// ErrInvalidBloom is returned for logs blooms which aren't 256 bytes of hex data
+var ErrInvalidBloom = errors.New("invalid logs bloom")
+
+// Bloom - 2048 bit logs bloom of a block or receipt, set for the address and topics of every log. A false Test
+// proves the block has no matching log, a true one may be a false positive.
+type Bloom [BloomLength]byte
+
+// ParseBloom parses the logsBloom of a block or receipt
+func ParseBloom(logsBloom string) (Bloom, error) {
+	var bloom Bloom
+	data, err := ParseBytes(logsBloom)
+	if err != nil {
+		return bloom, err
+	}
+	if len(data) != BloomLength {
+		return bloom, errors.Wrapf(ErrInvalidBloom, "%d bytes", len(data))
+	}
+	copy(bloom[:], data)
+
+	return bloom, nil
+}
+
+// CreateBloom returns the bloom of logs as computed by the node
+func CreateBloom(logs []Log) (Bloom, error) {
+	var bloom Bloom
+	for _, log := range logs {
+		for _, value := range append([]string{log.Address}, log.Topics...) {
+			data, err := ParseBytes(value)
+			if err != nil {
+				return bloom, err
+			}
+			bloom.Add(data)
+		}
+	}
+
+	return bloom, nil
+}
+
+// bloomBits returns the byte indexes and masks of the 3 bits set for data
+func bloomBits(data []byte) (indexes [3]int, masks [3]byte) {
+	hash := crypto.Keccak256(data)
+	for i := 0; i < 3; i++ {
+		bit := (uint(hash[2*i])<<8 | uint(hash[2*i+1])) & 2047
+		indexes[i] = BloomLength - 1 - int(bit/8)
+		masks[i] = 1 << (bit % 8)
+	}
+
+	return indexes, masks
+}
+
+// Add sets the bits of data, an address or topic
+func (b *Bloom) Add(data []byte) {
+	indexes, masks := bloomBits(data)
+	for i := range indexes {
+		b[indexes[i]] |= masks[i]
+	}
+}
+
+// Test reports whether data, an address or topic, may be in the bloom
+func (b Bloom) Test(data []byte) bool {
+	indexes, masks := bloomBits(data)
+	for i := range indexes {
+		if b[indexes[i]]&masks[i] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestHex reports whether hex encoded address or topic may be in the bloom, invalid hex can't be ruled out
+func (b Bloom) TestHex(value string) bool {
+	data, err := ParseBytes(value)
+	return err != nil || b.Test(data)
+}
+
+// MatchFilter reports whether the bloom may contain logs matching the address and topics of params, the block
+// range is ignored. Use it to skip fetching logs and receipts of blocks which can't match.
+func (b Bloom) MatchFilter(params FilterParams) bool {
+	if !b.matchAny(params.Address) {
+		return false
+	}
+	for _, topics := range params.Topics {
+		if !b.matchAny(topics) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchAny reports whether any of values may be in the bloom, no values match anything
+func (b Bloom) matchAny(values []string) bool {
+	for _, value := range values {
+		if b.TestHex(value) {
+			return true
+		}
+	}
+
+	return len(values) == 0
+}
+
+// Hex returns the 0x prefixed logsBloom representation
+func (b Bloom) Hex() string {
+	return BytesToHex(b[:])
+}
+
+// BloomMatches reports whether logsBloom of a block or receipt may contain logs matching params
+func BloomMatches(logsBloom string, params FilterParams) (bool, error) {
+	bloom, err := ParseBloom(logsBloom)
+	if err != nil {
+		return false, err
+	}
+
+	return bloom.MatchFilter(params), nil
+}
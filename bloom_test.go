@@ -0,0 +1,46 @@
+package flashxroute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloom(t *testing.T) {
+	var bloom Bloom
+	for _, data := range []string{"testtest", "test", "hallo", "other"} {
+		bloom.Add([]byte(data))
+	}
+	for _, data := range []string{"testtest", "test", "hallo", "other"} {
+		require.True(t, bloom.Test([]byte(data)), data)
+	}
+	for _, data := range []string{"tes", "lo"} {
+		require.False(t, bloom.Test([]byte(data)), data)
+	}
+
+	parsed, err := ParseBloom(bloom.Hex())
+	require.NoError(t, err)
+	require.Equal(t, bloom, parsed)
+	_, err = ParseBloom("0x111")
+	require.Error(t, err)
+	_, err = ParseBloom("0x1111")
+	require.ErrorIs(t, err, ErrInvalidBloom)
+}
+
+func TestBloomMatchFilter(t *testing.T) {
+	bloom, err := CreateBloom([]Log{transferLog})
+	require.NoError(t, err)
+
+	other := "0x000000000000000000000000000000000000dead"
+	require.True(t, bloom.MatchFilter(FilterParams{}))
+	require.True(t, bloom.MatchFilter(FilterParams{Address: []string{other, transferLog.Address}, Topics: [][]string{{transferTopic}, nil, {transferLog.Topics[2]}}}))
+	require.False(t, bloom.MatchFilter(FilterParams{Address: []string{other}}))
+	require.False(t, bloom.MatchFilter(FilterParams{Topics: [][]string{{transferTopic}, {other}}}))
+
+	matches, err := BloomMatches(bloom.Hex(), FilterParams{Address: []string{transferLog.Address}})
+	require.NoError(t, err)
+	require.True(t, matches)
+	matches, err = BloomMatches(Bloom{}.Hex(), FilterParams{Address: []string{transferLog.Address}})
+	require.NoError(t, err)
+	require.False(t, matches)
+}
@@ -0,0 +1,92 @@
+// Package bloxroute narrows flashxroute to the bloXroute MEV APIs, forwarding to the root package. Methods use the
+// Authorization header the client was created with instead of taking one per call.
+package bloxroute
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/saman-pasha/flashxroute"
+)
+
+// Option - client option, any flashxroute.With* option
+type Option = func(rpc *flashxroute.FlashXRoute)
+
+// bloXroute request and response types
+type (
+	SimulateBundleRequest         = flashxroute.BloxrouteSimulateBundleRequest
+	SimulateBundleResponse        = flashxroute.BloxrouteSimulateBundleResponse
+	SubmitBundleRequest           = flashxroute.BloxrouteSubmitBundleRequest
+	SubmitBundleResponse          = flashxroute.BloxrouteSubmitBundleResponse
+	BrmSimulateBundleRequest      = flashxroute.BloxrouteBrmSimulateBundleRequest
+	BrmSubmitBundleRequest        = flashxroute.BloxrouteBrmSubmitBundleRequest
+	SendTransactionRequest        = flashxroute.BloxrouteSendTransactionRequest
+	SendPrivateTransactionRequest = flashxroute.BloxrouteSendPrivateTransactionRequest
+	SimulateBlockOptions          = flashxroute.SimulateBlockOptions
+	QuotaUsage                    = flashxroute.BloxrouteQuotaUsage
+	Region                        = flashxroute.BloxrouteRegion
+)
+
+// Client - bloXroute MEV API client
+type Client struct {
+	rpc *flashxroute.FlashXRoute
+}
+
+// New create client for the bloXroute ethereum cloud API in region authenticated with authHeader, see
+// flashxroute.AuthorizationHeader
+func New(region Region, authHeader string, options ...Option) *Client {
+	return &Client{rpc: flashxroute.NewBloxrouteCloud(region, authHeader, options...)}
+}
+
+// Wrap returns bloXroute surface of rpc, e.g. a client of a local gateway
+func Wrap(rpc *flashxroute.FlashXRoute) *Client {
+	return &Client{rpc: rpc}
+}
+
+// RPC returns the underlying client
+func (c *Client) RPC() *flashxroute.FlashXRoute {
+	return c.rpc
+}
+
+// SimulateBundle simulates bundle, see flashxroute.FlashXRoute.BloxrouteSimulateBundle
+func (c *Client) SimulateBundle(params SimulateBundleRequest) (SimulateBundleResponse, error) {
+	return c.rpc.BloxrouteSimulateBundle("", params)
+}
+
+// SubmitBundle submits bundle, see flashxroute.FlashXRoute.BloxrouteSubmitBundle
+func (c *Client) SubmitBundle(params SubmitBundleRequest) (SubmitBundleResponse, error) {
+	return c.rpc.BloxrouteSubmitBundle("", params)
+}
+
+// BrmSimulateBundle simulates BackRunMe bundle
+func (c *Client) BrmSimulateBundle(params BrmSimulateBundleRequest) (SimulateBundleResponse, error) {
+	return c.rpc.BloxrouteBrmSimulateBundle("", params)
+}
+
+// BrmSubmitBundle submits BackRunMe bundle
+func (c *Client) BrmSubmitBundle(params BrmSubmitBundleRequest) (SubmitBundleResponse, error) {
+	return c.rpc.BloxrouteBrmSubmitBundle("", params)
+}
+
+// SimulateBlock simulates the transactions of block with options
+func (c *Client) SimulateBlock(block *types.Block, options SimulateBlockOptions) (SimulateBundleResponse, error) {
+	return c.rpc.BloxrouteSimulateBlockWithOptions("", block, options)
+}
+
+// SendTransaction sends raw transaction through the BDN
+func (c *Client) SendTransaction(params SendTransactionRequest) (string, error) {
+	return c.rpc.BloxrouteSendTransaction("", params)
+}
+
+// SendSignedTransaction encodes and sends signed transaction through the BDN
+func (c *Client) SendSignedTransaction(tx *types.Transaction, params SendTransactionRequest) (string, error) {
+	return c.rpc.BloxrouteSendSignedTransaction("", tx, params)
+}
+
+// SendPrivateTransaction sends raw transaction to builders only
+func (c *Client) SendPrivateTransaction(params SendPrivateTransactionRequest) (string, error) {
+	return c.rpc.BloxrouteSendPrivateTransaction("", params)
+}
+
+// QuotaUsage returns daily quota usage of the account
+func (c *Client) QuotaUsage() (QuotaUsage, error) {
+	return c.rpc.BloxrouteQuotaUsage("")
+}
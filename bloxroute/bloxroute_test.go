@@ -0,0 +1,28 @@
+package bloxroute
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saman-pasha/flashxroute"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, "auth", r.Header.Get("Authorization"))
+		require.Equal(t, "blxr_submit_bundle", gjson.GetBytes(body, "method").String())
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	}))
+	defer server.Close()
+
+	client := New(flashxroute.BloxrouteRegionGlobal, "auth", flashxroute.WithURL(server.URL))
+	res, err := client.SubmitBundle(SubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	require.Nil(t, err)
+	require.Equal(t, "0xb", res.BundleHash)
+	require.Equal(t, server.URL, client.RPC().URL())
+}
@@ -0,0 +1,196 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownBuilder is returned for builders not in the BuilderRegistry
+var ErrUnknownBuilder = errors.New("builder not registered")
+
+// BuilderEndpoint - builder endpoint kept by a BuilderRegistry with its rate limit and health
+type BuilderEndpoint struct {
+	Builder
+	RateLimit float64 `json:"rateLimit,omitempty"` // submissions per second, 0 for no limit
+
+	LastSeen    time.Time `json:"lastSeen,omitempty"`    // last successful submission
+	LastError   string    `json:"lastError,omitempty"`   // error of the last failed submission
+	LastErrorAt time.Time `json:"lastErrorAt,omitempty"` // time of the last failed submission
+	Failures    int       `json:"failures,omitempty"`    // consecutive failed submissions
+}
+
+// BuilderRegistry - builder endpoints with their rate limits and health, saved to and loaded from a JSON file so
+// health survives restarts. Endpoints failing MaxFailures submissions in a row are left out of Available until
+// RetryAfter passed since their last failure.
+type BuilderRegistry struct {
+	MaxFailures int           // consecutive failures disabling an endpoint (default: 3)
+	RetryAfter  time.Duration // time a disabled endpoint is skipped (default: 1 minute)
+
+	mu        sync.Mutex
+	endpoints map[string]*BuilderEndpoint
+	submitted map[string]time.Time // last submission of rate limited endpoints
+}
+
+// NewBuilderRegistry create registry of the builders with a direct endpoint, e.g. KnownBuilders
+func NewBuilderRegistry(builders ...Builder) *BuilderRegistry {
+	r := &BuilderRegistry{endpoints: map[string]*BuilderEndpoint{}, submitted: map[string]time.Time{}}
+	for _, builder := range builders {
+		if builder.URL != "" {
+			r.Set(BuilderEndpoint{Builder: builder})
+		}
+	}
+
+	return r
+}
+
+// LoadBuilderRegistry reads the registry saved at path
+func LoadBuilderRegistry(path string) (*BuilderRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []BuilderEndpoint
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	r := NewBuilderRegistry()
+	for _, endpoint := range endpoints {
+		r.Set(endpoint)
+	}
+
+	return r, nil
+}
+
+// Save writes the endpoints to path as JSON, replacing the file at once so readers never see a partial registry
+func (r *BuilderRegistry) Save(path string) error {
+	data, err := json.MarshalIndent(r.Endpoints(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Set adds endpoint or replaces the endpoint of the same name
+func (r *BuilderRegistry) Set(endpoint BuilderEndpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints[endpoint.Name] = &endpoint
+}
+
+// Remove removes the endpoint of builder name
+func (r *BuilderRegistry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.endpoints, name)
+	delete(r.submitted, name)
+}
+
+// Get returns the endpoint of builder name
+func (r *BuilderRegistry) Get(name string) (BuilderEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	endpoint, ok := r.endpoints[name]
+	if !ok {
+		return BuilderEndpoint{}, errors.Wrap(ErrUnknownBuilder, name)
+	}
+
+	return *endpoint, nil
+}
+
+// Endpoints returns the endpoints sorted by name
+func (r *BuilderRegistry) Endpoints() []BuilderEndpoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoints := make([]BuilderEndpoint, 0, len(r.endpoints))
+	for _, endpoint := range r.endpoints {
+		endpoints = append(endpoints, *endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+
+	return endpoints
+}
+
+// Available returns the builders of healthy endpoints whose rate limit allows a submission now, sorted by name, and
+// counts the submission against their rate limit
+func (r *BuilderRegistry) Available() []Builder {
+	maxFailures, retryAfter := r.MaxFailures, r.RetryAfter
+	if maxFailures <= 0 {
+		maxFailures = 3
+	}
+	if retryAfter <= 0 {
+		retryAfter = time.Minute
+	}
+
+	now := time.Now()
+	var builders []Builder
+	for _, endpoint := range r.Endpoints() {
+		if endpoint.Failures >= maxFailures && now.Sub(endpoint.LastErrorAt) < retryAfter {
+			continue
+		}
+		if endpoint.RateLimit > 0 {
+			interval := time.Duration(float64(time.Second) / endpoint.RateLimit)
+			r.mu.Lock()
+			last, limited := r.submitted[endpoint.Name]
+			if limited = limited && now.Sub(last) < interval; !limited {
+				r.submitted[endpoint.Name] = now
+			}
+			r.mu.Unlock()
+			if limited {
+				continue
+			}
+		}
+		builders = append(builders, endpoint.Builder)
+	}
+
+	return builders
+}
+
+// Record updates the health of the endpoints with the outcome of submissions, e.g. of SendBundleToBuilders
+func (r *BuilderRegistry) Record(submissions []BuilderSubmission) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, submission := range submissions {
+		endpoint, ok := r.endpoints[submission.Builder]
+		if !ok {
+			continue
+		}
+		if submission.Err != nil {
+			endpoint.Failures++
+			endpoint.LastError, endpoint.LastErrorAt = submission.Err.Error(), now
+			continue
+		}
+		endpoint.Failures, endpoint.LastSeen = 0, now
+	}
+}
+
+// SendBundle submits params to the Available builders with SendBundleToBuilders and records the outcome
+func (r *BuilderRegistry) SendBundle(params SendBundleRequest, options ...func(rpc *FlashXRoute)) []BuilderSubmission {
+	submissions := SendBundleToBuilders(r.Available(), params, options...)
+	r.Record(submissions)
+
+	return submissions
+}
@@ -0,0 +1,64 @@
+package flashxroute
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+func (s *FlashXRouteTestSuite) TestBuilderRegistry() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Builder") == "down" {
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "overloaded"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	registry := NewBuilderRegistry(
+		Builder{Name: "up", URL: server.URL, Headers: map[string]string{"X-Builder": "up"}, Fields: []string{BundleFieldReplacementUUID}},
+		Builder{Name: "down", URL: server.URL, Headers: map[string]string{"X-Builder": "down"}},
+		Builder{Name: "bloxroute"},
+	)
+	registry.MaxFailures = 2
+	s.Require().Len(registry.Endpoints(), 2)
+
+	params := SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"}
+	for i := 0; i < 2; i++ {
+		submissions := registry.SendBundle(params, WithHttpClient(http.DefaultClient))
+		s.Require().Len(submissions, 2)
+	}
+	up, err := registry.Get("up")
+	s.Require().NoError(err)
+	s.Require().Zero(up.Failures)
+	s.Require().False(up.LastSeen.IsZero())
+	s.Require().True(up.Supports(BundleFieldReplacementUUID))
+	s.Require().False(up.Supports(BundleFieldRefundPercent))
+	down, _ := registry.Get("down")
+	s.Require().Equal(2, down.Failures)
+	s.Require().Contains(down.LastError, "overloaded")
+
+	// down is disabled until RetryAfter passes
+	submissions := registry.SendBundle(params, WithHttpClient(http.DefaultClient))
+	s.Require().Equal([]BuilderSubmission{{Builder: "up", BundleHash: "0xb"}}, submissions)
+
+	up.RateLimit = 0.5
+	registry.Set(up)
+	s.Require().Len(registry.Available(), 1)
+	s.Require().Empty(registry.Available())
+
+	path := filepath.Join(s.T().TempDir(), "builders.json")
+	s.Require().NoError(registry.Save(path))
+	loaded, err := LoadBuilderRegistry(path)
+	s.Require().NoError(err)
+	down, _ = loaded.Get("down")
+	s.Require().Equal(2, down.Failures)
+	s.Require().WithinDuration(time.Now(), down.LastErrorAt, time.Minute)
+	s.Require().Equal(0.5, loaded.Endpoints()[1].RateLimit)
+	s.Require().Equal([]string{BundleFieldReplacementUUID}, loaded.Endpoints()[1].Fields)
+
+	loaded.Remove("down")
+	_, err = loaded.Get("down")
+	s.Require().ErrorIs(err, ErrUnknownBuilder)
+}
@@ -0,0 +1,224 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoBuilderEndpoint is returned when submitting directly to a builder without a known eth_sendBundle endpoint
+var ErrNoBuilderEndpoint = errors.New("builder has no direct endpoint")
+
+// ErrCancelUnsupported is returned when cancelling bundles on a builder not accepting replacementUuid
+var ErrCancelUnsupported = errors.New("builder doesn't support bundle cancellation")
+
+// Optional eth_sendBundle fields, see Builder.Fields
+const (
+	BundleFieldMinTimestamp      = "minTimestamp"
+	BundleFieldMaxTimestamp      = "maxTimestamp"
+	BundleFieldRevertingTxHashes = "revertingTxHashes"
+	BundleFieldReplacementUUID   = "replacementUuid"
+	BundleFieldRefundPercent     = "refundPercent"
+	BundleFieldRefundRecipient   = "refundRecipient"
+	BundleFieldRefundTxHashes    = "refundTxHashes"
+)
+
+// SendBundleRequest - eth_sendBundle parameters understood by builders and the Flashbots relay
+type SendBundleRequest struct {
+	Txs               []string `json:"txs"`         // signed raw transactions, 0x prefix is added when missing
+	BlockNumber       string   `json:"blockNumber"` // hex number of the target block
+	MinTimestamp      *uint64  `json:"minTimestamp,omitempty"`
+	MaxTimestamp      *uint64  `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []string `json:"revertingTxHashes,omitempty"`
+	ReplacementUUID   string   `json:"replacementUuid,omitempty"`
+	RefundPercent     *int     `json:"refundPercent,omitempty"`
+	RefundRecipient   string   `json:"refundRecipient,omitempty"`
+	RefundTxHashes    []string `json:"refundTxHashes,omitempty"`
+}
+
+// SendBundleResponse - eth_sendBundle result, BundleHash is empty for builders answering null
+type SendBundleResponse struct {
+	BundleHash string `json:"bundleHash"`
+}
+
+// CancelBundleRequest - eth_cancelBundle parameters
+type CancelBundleRequest struct {
+	ReplacementUUID string `json:"replacementUuid"`
+}
+
+// BuilderClient - rpc client of a builder's eth_sendBundle endpoint applying its quirks
+type BuilderClient struct {
+	*FlashXRoute
+	Builder Builder
+}
+
+// NewBuilderClient create client submitting bundles directly to builder.URL with builder.Headers
+func NewBuilderClient(builder Builder, options ...func(rpc *FlashXRoute)) (*BuilderClient, error) {
+	if builder.URL == "" {
+		return nil, errors.Wrap(ErrNoBuilderEndpoint, builder.Name)
+	}
+
+	rpc := New(builder.URL, options...)
+	if len(builder.Headers) > 0 {
+		headers := make(map[string]string, len(rpc.Headers)+len(builder.Headers))
+		for k, v := range rpc.Headers {
+			headers[k] = v
+		}
+		for k, v := range builder.Headers {
+			headers[k] = v
+		}
+		rpc.Headers = headers
+	}
+
+	return &BuilderClient{FlashXRoute: rpc, Builder: builder}, nil
+}
+
+// SendBundle submits bundle with eth_sendBundle after dropping the fields the builder doesn't accept and adding the
+// 0x prefix to raw transactions and hashes missing it, the request is signed with X-Flashbots-Signature when the
+// client has a signer, builders requiring it fail with ErrNoSigner. Blob transactions must be in network form
+// and are refused for builders without Builder.BlobTransactions. Builders the policy of WithPolicy doesn't allow
+// fail with ErrBuilderExcluded, bundles are checked by the profit guard first, see ProfitGuard.Simulator.
+func (c *BuilderClient) SendBundle(params SendBundleRequest) (res SendBundleResponse, err error) {
+	return c.sendBundle(params, true)
+}
+
+// sendBundle submits params with eth_sendBundle, checked by the profit guard when guarded
+func (c *BuilderClient) sendBundle(params SendBundleRequest, guarded bool) (res SendBundleResponse, err error) {
+	params.MinTimestamp, params.MaxTimestamp = c.clock.adjusted(params.MinTimestamp, params.MaxTimestamp)
+	params.Txs = mapHex(params.Txs, AddHexPrefix)
+	params.RevertingTxHashes = mapHex(params.RevertingTxHashes, AddHexPrefix)
+	params.RefundTxHashes = mapHex(params.RefundTxHashes, AddHexPrefix)
+	if c.policy != nil && !c.policy.Allows(c.network, false, c.Builder) {
+		return res, errors.Wrap(ErrBuilderExcluded, c.Builder.Name)
+	}
+	if err := c.Builder.checkBlobs(params.Txs); err != nil {
+		return res, err
+	}
+	if guarded {
+		if err := c.guardBundle("", params.Txs, params.BlockNumber, params.MinTimestamp); err != nil {
+			return res, err
+		}
+	}
+	bundle, err := c.Builder.bundleParams(params)
+	if err != nil {
+		return res, err
+	}
+
+	var rawMsg json.RawMessage
+	ctx, duplicate := contextWithDuplicateFlag(context.Background())
+	switch {
+	case c.signer != nil:
+		rawMsg, err = c.CallWithFlashbotsSignerContext(ctx, "eth_sendBundle", c.signer, bundle)
+	case c.Builder.SignatureRequired:
+		return res, errors.Wrap(ErrNoSigner, c.Builder.Name)
+	default:
+		rawMsg, err = c.CallContext(ctx, "eth_sendBundle", bundle)
+	}
+	if err == nil && string(rawMsg) != "null" {
+		err = json.Unmarshal(rawMsg, &res)
+	}
+	c.bundleSubmitted("eth_sendBundle", params.BlockNumber, params.Txs, nil, res.BundleHash, err, *duplicate)
+	return res, err
+}
+
+// CancelBundle cancels the bundles submitted with replacementUuid uuid with eth_cancelBundle, signed like SendBundle
+func (c *BuilderClient) CancelBundle(uuid string) error {
+	if c.Builder.Fields != nil && !containsString(c.Builder.Fields, BundleFieldReplacementUUID) {
+		return errors.Wrap(ErrCancelUnsupported, c.Builder.Name)
+	}
+
+	var err error
+	switch {
+	case c.signer != nil:
+		_, err = c.CallWithFlashbotsSigner("eth_cancelBundle", c.signer, CancelBundleRequest{ReplacementUUID: uuid})
+	case c.Builder.SignatureRequired:
+		return errors.Wrap(ErrNoSigner, c.Builder.Name)
+	default:
+		_, err = c.Call("eth_cancelBundle", CancelBundleRequest{ReplacementUUID: uuid})
+	}
+	return err
+}
+
+// Supports reports whether the builder accepts optional eth_sendBundle field, see BundleField*
+func (b Builder) Supports(field string) bool {
+	return b.Fields == nil || containsString(b.Fields, field)
+}
+
+// checkBlobs fails with ErrBlobsUnsupported when txs contain blob transactions the builder doesn't accept and with
+// ErrMissingBlobSidecar for blob transactions in canonical form, builders need the sidecar to include them
+func (b Builder) checkBlobs(txs []string) error {
+	for i, raw := range txs {
+		if !IsBlobTx(raw) {
+			continue
+		}
+		if !b.BlobTransactions {
+			return errors.Wrap(ErrBlobsUnsupported, b.Name)
+		}
+		tx, err := DecodeBlobTx(raw)
+		if err != nil {
+			return errors.Wrapf(err, "transaction %d", i)
+		}
+		if tx.Sidecar == nil {
+			return errors.Wrapf(ErrMissingBlobSidecar, "transaction %d", i)
+		}
+	}
+
+	return nil
+}
+
+// bundleParams returns eth_sendBundle params with the fields allowed by the builder
+func (b Builder) bundleParams(params SendBundleRequest) (map[string]interface{}, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	bundle := map[string]interface{}{}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, err
+	}
+
+	if b.Fields != nil {
+		for field := range bundle {
+			if field != "txs" && field != "blockNumber" && !b.Supports(field) {
+				delete(bundle, field)
+			}
+		}
+	}
+
+	return bundle, nil
+}
+
+// BuilderSubmission - outcome of a direct submission to one builder
+type BuilderSubmission struct {
+	Builder    string
+	BundleHash string
+	Err        error
+}
+
+// SendBundleToBuilders submits bundle concurrently to every builder with a direct endpoint, e.g. the builders
+// selected by Policy.SelectBuilders, builders without one are reported with ErrNoBuilderEndpoint and builders the
+// policy of WithPolicy in options doesn't allow with ErrBuilderExcluded
+func SendBundleToBuilders(builders []Builder, params SendBundleRequest, options ...func(rpc *FlashXRoute)) []BuilderSubmission {
+	submissions := make([]BuilderSubmission, len(builders))
+	var wg sync.WaitGroup
+	for i, builder := range builders {
+		submissions[i].Builder = builder.Name
+		client, err := NewBuilderClient(builder, options...)
+		if err != nil {
+			submissions[i].Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := client.SendBundle(params)
+			submissions[i].BundleHash, submissions[i].Err = res.BundleHash, err
+		}(i)
+	}
+	wg.Wait()
+
+	return submissions
+}
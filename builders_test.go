@@ -0,0 +1,60 @@
+package flashxroute
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestSendBundleToBuilders() {
+	var mu sync.Mutex
+	requests := map[string]*http.Request{}
+	bodies := map[string][]byte{}
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body := s.getBody(r)
+		name := r.Header.Get("X-Builder")
+		requests[name], bodies[name] = r, body
+		if name == "quiet" {
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": null}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	refund := 90
+	params := SendBundleRequest{Txs: []string{"01"}, BlockNumber: "0x10", ReplacementUUID: "u1", RefundPercent: &refund}
+	builders := []Builder{
+		{Name: "strict", URL: server.URL, Headers: map[string]string{"X-Builder": "strict"}, Fields: []string{BundleFieldReplacementUUID}},
+		{Name: "quiet", URL: server.URL, Headers: map[string]string{"X-Builder": "quiet"}},
+		{Name: "signed", URL: server.URL, Headers: map[string]string{"X-Builder": "signed"}, SignatureRequired: true},
+		{Name: "bloxroute"},
+	}
+
+	submissions := SendBundleToBuilders(builders, params, WithHttpClient(http.DefaultClient))
+	s.Require().Equal(BuilderSubmission{Builder: "strict", BundleHash: "0xb"}, submissions[0])
+	s.Require().Equal(BuilderSubmission{Builder: "quiet"}, submissions[1])
+	s.Require().ErrorIs(submissions[2].Err, ErrNoSigner)
+	s.Require().ErrorIs(submissions[3].Err, ErrNoBuilderEndpoint)
+
+	s.Require().Equal("eth_sendBundle", gjson.GetBytes(bodies["strict"], "method").String())
+	s.Require().Equal("0x01", gjson.GetBytes(bodies["strict"], "params.0.txs.0").String())
+	s.Require().Equal("u1", gjson.GetBytes(bodies["strict"], "params.0.replacementUuid").String())
+	s.Require().False(gjson.GetBytes(bodies["strict"], "params.0.refundPercent").Exists())
+	s.Require().Equal(int64(90), gjson.GetBytes(bodies["quiet"], "params.0.refundPercent").Int())
+	s.Require().Empty(requests["quiet"].Header.Get("X-Flashbots-Signature"))
+
+	key, _ := crypto.GenerateKey()
+	submissions = SendBundleToBuilders(builders[2:3], params, WithSigner(NewPrivateKeySigner(key)))
+	s.Require().Nil(submissions[0].Err)
+	s.Require().NotEmpty(requests["signed"].Header.Get("X-Flashbots-Signature"))
+
+	flashbots, ok := KnownBuilder("flashbots")
+	s.Require().True(ok)
+	s.Require().Equal(FlashbotsRelayURL, flashbots.URL)
+	s.Require().Len(Policy{Include: []string{"titan", "bloxroute"}}.SelectBuilders(NetworkMainnet, false), 2)
+}
@@ -0,0 +1,133 @@
+package flashxroute
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ErrTransactionNotFound is returned when the node doesn't know a transaction
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// BundleBuilder - ordered signed transactions of a bundle and the ones allowed to revert, the zero value is an
+// empty bundle
+type BundleBuilder struct {
+	txs       []string // 0x prefixed raw transactions
+	reverting []string // hashes of the transactions allowed to revert
+}
+
+// NewBundleBuilder create builder of a bundle starting with the raw transactions, which must not revert
+func NewBundleBuilder(rawTxs ...string) (*BundleBuilder, error) {
+	bundle := new(BundleBuilder)
+	for _, raw := range rawTxs {
+		if err := bundle.AddRawTransaction(raw, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
+// AddRawTransaction appends signed raw transaction given with or without 0x prefix, blob transactions should be in
+// network form with their sidecar
+func (b *BundleBuilder) AddRawTransaction(raw string, canRevert bool) error {
+	data, err := ParseBytes(raw)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return errors.Wrap(ErrEmptyHex, "raw transaction")
+	}
+	hash, err := RawTxHash(raw)
+	if err != nil {
+		return err
+	}
+
+	b.txs = append(b.txs, BytesToHex(data))
+	if canRevert {
+		b.reverting = append(b.reverting, hash)
+	}
+
+	return nil
+}
+
+// AddTransaction appends signed transaction
+func (b *BundleBuilder) AddTransaction(tx *types.Transaction, canRevert bool) error {
+	raw, err := TxToHex(tx)
+	if err != nil {
+		return err
+	}
+
+	return b.AddRawTransaction(raw, canRevert)
+}
+
+// Len returns the number of transactions
+func (b *BundleBuilder) Len() int {
+	return len(b.txs)
+}
+
+// Transactions returns the 0x prefixed raw transactions in bundle order
+func (b *BundleBuilder) Transactions() []string {
+	return append([]string{}, b.txs...)
+}
+
+// Hashes returns the transaction hashes in bundle order, blob transactions are hashed without sidecar
+func (b *BundleBuilder) Hashes() []string {
+	hashes := make([]string, len(b.txs))
+	for i, raw := range b.txs {
+		hashes[i], _ = RawTxHash(raw)
+	}
+
+	return hashes
+}
+
+// RevertingHashes returns the hashes of the transactions allowed to revert
+func (b *BundleBuilder) RevertingHashes() []string {
+	return append([]string{}, b.reverting...)
+}
+
+// BloxrouteSubmitBundleRequest returns blxr_submit_bundle params of the bundle targeting blockNumber
+func (b *BundleBuilder) BloxrouteSubmitBundleRequest(blockNumber int) BloxrouteSubmitBundleRequest {
+	params := BloxrouteSubmitBundleRequest{Transaction: mapHex(b.txs, StripHexPrefix), BlockNumber: IntToHex(blockNumber)}
+	if len(b.reverting) > 0 {
+		reverting := b.RevertingHashes()
+		params.RevertingHashes = &reverting
+	}
+
+	return params
+}
+
+// BloxrouteSimulateBundleRequest returns blxr_simulate_bundle params of the bundle targeting blockNumber
+func (b *BundleBuilder) BloxrouteSimulateBundleRequest(blockNumber int) BloxrouteSimulateBundleRequest {
+	return BloxrouteSimulateBundleRequest{Transaction: mapHex(b.txs, StripHexPrefix), BlockNumber: IntToHex(blockNumber)}
+}
+
+// SendBundleRequest returns eth_sendBundle params of the bundle targeting blockNumber
+func (b *BundleBuilder) SendBundleRequest(blockNumber int) SendBundleRequest {
+	return SendBundleRequest{Txs: b.Transactions(), BlockNumber: IntToHex(blockNumber), RevertingTxHashes: b.RevertingHashes()}
+}
+
+// AddTransactionsByHash fetches the raw transactions of hashes, e.g. victims seen in a mempool feed, with
+// eth_getRawTransactionByHash and appends them to bundle in order as not allowed to revert, so a backrun bundle can
+// be assembled from hashes alone. Nothing is appended when a transaction fails to fetch.
+func (rpc *FlashXRoute) AddTransactionsByHash(bundle *BundleBuilder, hashes ...string) error {
+	raws := make([]string, len(hashes))
+	for i, hash := range hashes {
+		raw, err := rpc.EthGetRawTransactionByHash(hash)
+		if err != nil {
+			return errors.Wrapf(err, "raw transaction %s", hash)
+		}
+		if raw == "" || raw == "0x" {
+			return errors.Wrap(ErrTransactionNotFound, hash)
+		}
+		raws[i] = raw
+	}
+
+	for i, raw := range raws {
+		if err := bundle.AddRawTransaction(raw, false); err != nil {
+			bundle.txs = bundle.txs[:len(bundle.txs)-i]
+			return errors.Wrapf(err, "raw transaction %s", hashes[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestAddTransactionsByHash() {
+	s.registerMethods(map[string]string{
+		`eth_getRawTransactionByHash ["0xa"]`: `"0x02f801"`,
+		`eth_getRawTransactionByHash ["0xb"]`: `"0x02f802"`,
+		`eth_getRawTransactionByHash ["0xc"]`: `null`,
+	})
+
+	raw, err := s.rpc.EthGetRawTransactionByHash("0xc")
+	s.Require().Nil(err)
+	s.Require().Empty(raw)
+
+	bundle := new(BundleBuilder)
+	s.Require().Nil(s.rpc.AddTransactionsByHash(bundle, "0xa", "0xb"))
+	s.Require().Nil(bundle.AddRawTransaction("02f803", true))
+	s.Require().Equal([]string{"0x02f801", "0x02f802", "0x02f803"}, bundle.Transactions())
+	s.Require().Equal(Keccak256([]byte{0x02, 0xf8, 0x02}), bundle.Hashes()[1])
+
+	err = s.rpc.AddTransactionsByHash(bundle, "0xa", "0xc")
+	s.Require().ErrorIs(err, ErrTransactionNotFound)
+	s.Require().Equal(3, bundle.Len())
+
+	params := bundle.BloxrouteSubmitBundleRequest(16)
+	s.Require().Equal([]string{"02f801", "02f802", "02f803"}, params.Transaction)
+	s.Require().Equal("0x10", params.BlockNumber)
+	s.Require().Equal([]string{Keccak256([]byte{0x02, 0xf8, 0x03})}, *params.RevertingHashes)
+
+	request := bundle.SendBundleRequest(16)
+	s.Require().Equal(bundle.Transactions(), request.Txs)
+	s.Require().Equal(*params.RevertingHashes, request.RevertingTxHashes)
+
+	_, err = NewBundleBuilder("0x")
+	s.Require().ErrorIs(err, ErrEmptyHex)
+}
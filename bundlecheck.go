@@ -0,0 +1,178 @@
+package flashxroute
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Rules checked by BundleValidator
+const (
+	BundleRuleEmpty       = "empty"        // no transactions without a uuid cancelling a previous bundle
+	BundleRuleBlockNumber = "block_number" // target block isn't a 0x prefixed hex number
+	BundleRuleEncoding    = "encoding"     // transaction can't be decoded
+	BundleRuleNonce       = "nonce"        // nonces of a sender don't follow each other
+	BundleRuleChainID     = "chain_id"     // transaction signed for another chain or without replay protection
+	BundleRuleGasLimit    = "gas_limit"    // gas limit above the block gas limit
+)
+
+// BundleViolation - rule broken by a bundle, Tx is the index of the offending transaction, -1 for the whole bundle
+type BundleViolation struct {
+	Rule    string
+	Tx      int
+	Message string
+}
+
+func (v BundleViolation) Error() string {
+	if v.Tx < 0 {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s: transaction %d %s", v.Rule, v.Tx, v.Message)
+}
+
+// BundleValidator - checks bundles client side before submission, relays reject or silently drop bundles breaking
+// these rules
+type BundleValidator struct {
+	ChainID       *big.Int // chain transactions must be signed for, nil only requires them to agree
+	BlockGasLimit uint64   // gas limit of the target block, 0 skips the gas checks
+}
+
+// BundleValidator returns validator of bundles for the chain of the endpoint and the gas limit of the latest block
+func (rpc *FlashXRoute) BundleValidator() (BundleValidator, error) {
+	chainID, err := rpc.ChainID()
+	if err != nil {
+		return BundleValidator{}, err
+	}
+	head, err := rpc.getBlockHeader("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return BundleValidator{}, err
+	}
+	if head == nil {
+		return BundleValidator{}, errors.New("latest block not found")
+	}
+
+	return BundleValidator{ChainID: chainID, BlockGasLimit: uint64(head.GasLimit)}, nil
+}
+
+// ValidateSubmitBundle returns the violations of blxr_submit_bundle params, none when the bundle is valid
+func (v BundleValidator) ValidateSubmitBundle(params BloxrouteSubmitBundleRequest) []BundleViolation {
+	return v.validate(params.Transaction, params.BlockNumber, params.Uuid)
+}
+
+// ValidateSendBundle returns the violations of eth_sendBundle params, none when the bundle is valid
+func (v BundleValidator) ValidateSendBundle(params SendBundleRequest) []BundleViolation {
+	return v.validate(params.Txs, params.BlockNumber, params.ReplacementUUID)
+}
+
+func (v BundleValidator) validate(txs []string, blockNumber, uuid string) []BundleViolation {
+	var violations []BundleViolation
+	violate := func(rule string, tx int, format string, args ...interface{}) {
+		violations = append(violations, BundleViolation{Rule: rule, Tx: tx, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(txs) == 0 && uuid == "" {
+		violate(BundleRuleEmpty, -1, "no transactions and no uuid")
+	}
+	if !strings.HasPrefix(blockNumber, "0x") || len(blockNumber) == 2 {
+		violate(BundleRuleBlockNumber, -1, "%q is not a 0x prefixed hex number", blockNumber)
+	} else if _, err := ParseInt(blockNumber); err != nil {
+		violate(BundleRuleBlockNumber, -1, "%q is not a 0x prefixed hex number", blockNumber)
+	}
+
+	chainID := v.ChainID
+	nonces := map[string]uint64{}
+	var gas uint64
+	for i, raw := range txs {
+		tx, err := decodeBundleTx(raw)
+		if err != nil {
+			violate(BundleRuleEncoding, i, "%v", err)
+			continue
+		}
+
+		switch {
+		case tx.chainID == nil:
+			violate(BundleRuleChainID, i, "has no replay protection")
+		case chainID == nil:
+			chainID = tx.chainID
+		case tx.chainID.Cmp(chainID) != 0:
+			violate(BundleRuleChainID, i, "signed for chain %v, expected %v", tx.chainID, chainID)
+		}
+
+		if last, ok := nonces[tx.sender]; ok && tx.nonce != last+1 {
+			violate(BundleRuleNonce, i, "nonce %d of %s follows nonce %d", tx.nonce, tx.sender, last)
+		}
+		nonces[tx.sender] = tx.nonce
+
+		if v.BlockGasLimit > 0 && tx.gas > v.BlockGasLimit {
+			violate(BundleRuleGasLimit, i, "gas limit %d above the block gas limit %d", tx.gas, v.BlockGasLimit)
+		}
+		gas += tx.gas
+	}
+	if v.BlockGasLimit > 0 && len(txs) > 1 && gas > v.BlockGasLimit {
+		violate(BundleRuleGasLimit, -1, "gas limit %d above the block gas limit %d", gas, v.BlockGasLimit)
+	}
+
+	return violations
+}
+
+// bundleTx - fields of a raw transaction checked by BundleValidator
+type bundleTx struct {
+	sender  string
+	nonce   uint64
+	gas     uint64
+	chainID *big.Int // nil for legacy transactions without EIP-155 replay protection
+}
+
+// decodeBundleTx decodes nonce, gas limit and chain id of raw transaction and recovers its sender from the signature
+// over the decoded fields, so every type rawTxFields decodes is checked, blob transactions too
+func decodeBundleTx(raw string) (bundleTx, error) {
+	var tx bundleTx
+	txType, fields, err := rawTxFields(raw)
+	if err != nil {
+		return tx, err
+	}
+	if minFields := map[byte]int{0: 9, 1: 11, 2: 12, BlobTxType: 14}[txType]; len(fields) < minFields {
+		return tx, errors.Wrapf(errInvalidRLP, "transaction type %d has %d fields", txType, len(fields))
+	}
+
+	field := func(i int) *big.Int {
+		_, content, _, _ := rlpSplit(fields[i])
+		return new(big.Int).SetBytes(content)
+	}
+	signed := len(fields) - 3
+	v, r, s := field(signed), field(signed+1), field(signed+2)
+	var payload []byte
+	if txType == 0 {
+		tx.nonce = field(0).Uint64()
+		payload = rlpList(fields[:6]...)
+		// EIP-155: v = chainID * 2 + 35 or 36, 27 or 28 without replay protection
+		if v.Cmp(big.NewInt(35)) >= 0 {
+			tx.chainID = new(big.Int).Rsh(new(big.Int).Sub(v, big.NewInt(35)), 1)
+			payload = rlpList(append(fields[:6:6], rlpBytes(tx.chainID.Bytes()), rlpBytes(nil), rlpBytes(nil))...)
+			v.Sub(v, new(big.Int).Add(new(big.Int).Lsh(tx.chainID, 1), big.NewInt(8)))
+		}
+		v.Sub(v, big.NewInt(27))
+	} else {
+		tx.chainID, tx.nonce = field(0), field(1).Uint64()
+		payload = append([]byte{txType}, rlpList(fields[:signed]...)...)
+	}
+	tx.gas = field(rawTxGasFields[txType][0]).Uint64()
+
+	if !v.IsUint64() || v.Uint64() > 1 || !crypto.ValidateSignatureValues(byte(v.Uint64()), r, s, true) {
+		return tx, errors.New("invalid signature values")
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(v.Uint64())
+	pub, err := crypto.Ecrecover(crypto.Keccak256(payload), sig)
+	if err != nil {
+		return tx, err
+	}
+	tx.sender = BytesToHex(crypto.Keccak256(pub[1:])[12:])
+
+	return tx, nil
+}
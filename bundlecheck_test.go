@@ -0,0 +1,103 @@
+package flashxroute
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// signedTestTx returns raw EIP-1559 transaction of key, or legacy one without replay protection for a nil chainID
+func signedTestTx(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, nonce, gas uint64) string {
+	integer := func(value uint64) []byte { return rlpBytes(new(big.Int).SetUint64(value).Bytes()) }
+	to := rlpBytes(make([]byte, 20))
+	if chainID == nil {
+		return signTestTx(t, key, 0, integer(nonce), integer(1e9), integer(gas), to, integer(0), rlpBytes(nil))
+	}
+
+	return signTestTx(t, key, 2, rlpBytes(chainID.Bytes()), integer(nonce), integer(1e9), integer(2e9), integer(gas), to, integer(0), rlpBytes(nil), rlpList())
+}
+
+// signedBlobTestTx returns raw blob transaction of key in canonical form, with one versioned hash and no sidecar
+func signedBlobTestTx(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, nonce, gas uint64) string {
+	integer := func(value uint64) []byte { return rlpBytes(new(big.Int).SetUint64(value).Bytes()) }
+	versionedHash := append([]byte{1}, make([]byte, 31)...)
+	return signTestTx(t, key, BlobTxType, rlpBytes(chainID.Bytes()), integer(nonce), integer(1e9), integer(2e9), integer(gas),
+		rlpBytes(make([]byte, 20)), integer(0), rlpBytes(nil), rlpList(), integer(1), rlpList(rlpBytes(versionedHash)))
+}
+
+// signTestTx signs the rlp encoded fields of a transaction of txType with key, legacy ones without replay protection
+func signTestTx(t *testing.T, key *ecdsa.PrivateKey, txType byte, fields ...[]byte) string {
+	payload := rlpList(fields...)
+	if txType != 0 {
+		payload = append([]byte{txType}, payload...)
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(payload), key)
+	require.Nil(t, err)
+
+	v := uint64(sig[64])
+	if txType == 0 {
+		v += 27
+	}
+	integer := func(value []byte) []byte { return rlpBytes(new(big.Int).SetBytes(value).Bytes()) }
+	fields = append(fields, integer(new(big.Int).SetUint64(v).Bytes()), integer(sig[:32]), integer(sig[32:64]))
+	raw := rlpList(fields...)
+	if txType != 0 {
+		raw = append([]byte{txType}, raw...)
+	}
+
+	return BytesToHex(raw)
+}
+
+func TestBundleValidator(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	mainnet := big.NewInt(1)
+	validator := BundleValidator{ChainID: mainnet, BlockGasLimit: 30000000}
+	rules := func(violations []BundleViolation) map[string]int {
+		result := map[string]int{}
+		for _, violation := range violations {
+			result[violation.Rule] = violation.Tx
+		}
+		return result
+	}
+
+	valid := []string{signedTestTx(t, key, mainnet, 5, 21000), signedTestTx(t, key, mainnet, 6, 100000)}
+	require.Empty(t, validator.ValidateSendBundle(SendBundleRequest{Txs: valid, BlockNumber: "0x10"}))
+	require.Empty(t, validator.ValidateSubmitBundle(BloxrouteSubmitBundleRequest{Transaction: mapHex(valid, StripHexPrefix), BlockNumber: "0x10"}))
+	require.Empty(t, validator.ValidateSendBundle(SendBundleRequest{BlockNumber: "0x10", ReplacementUUID: "u1"}))
+
+	require.Equal(t, map[string]int{BundleRuleEmpty: -1, BundleRuleBlockNumber: -1}, rules(validator.ValidateSendBundle(SendBundleRequest{BlockNumber: "16"})))
+	require.Equal(t, map[string]int{BundleRuleBlockNumber: -1}, rules(validator.ValidateSendBundle(SendBundleRequest{Txs: valid, BlockNumber: "0xzz"})))
+
+	gap := []string{valid[0], signedTestTx(t, key, mainnet, 7, 21000)}
+	require.Equal(t, map[string]int{BundleRuleNonce: 1}, rules(validator.ValidateSendBundle(SendBundleRequest{Txs: gap, BlockNumber: "0x10"})))
+
+	chains := []string{valid[0], signedTestTx(t, key, big.NewInt(5), 6, 21000), signedTestTx(t, key, nil, 7, 21000)}
+	violations := validator.ValidateSendBundle(SendBundleRequest{Txs: chains, BlockNumber: "0x10"})
+	require.Len(t, violations, 2)
+	require.Equal(t, BundleViolation{Rule: BundleRuleChainID, Tx: 1, Message: "signed for chain 5, expected 1"}, violations[0])
+	require.Equal(t, "chain_id: transaction 2 has no replay protection", violations[1].Error())
+
+	// without ChainID the first transaction sets the chain
+	require.Equal(t, map[string]int{BundleRuleChainID: 1}, rules(BundleValidator{}.ValidateSendBundle(SendBundleRequest{Txs: chains[:2], BlockNumber: "0x10"})))
+
+	heavy := []string{signedTestTx(t, key, mainnet, 5, 20000000), signedTestTx(t, key, mainnet, 6, 31000000)}
+	violations = validator.ValidateSendBundle(SendBundleRequest{Txs: heavy, BlockNumber: "0x10"})
+	require.Equal(t, []BundleViolation{
+		{Rule: BundleRuleGasLimit, Tx: 1, Message: "gas limit 31000000 above the block gas limit 30000000"},
+		{Rule: BundleRuleGasLimit, Tx: -1, Message: "gas limit 51000000 above the block gas limit 30000000"},
+	}, violations)
+
+	// blob transactions are decoded by hand, the senders of other keys have their own nonces
+	other, _ := crypto.GenerateKey()
+	blobs := []string{signedBlobTestTx(t, key, mainnet, 5, 21000), signedTestTx(t, other, mainnet, 9, 21000), signedBlobTestTx(t, key, mainnet, 6, 21000)}
+	require.Empty(t, validator.ValidateSendBundle(SendBundleRequest{Txs: blobs, BlockNumber: "0x10"}))
+	blobs[2] = signedBlobTestTx(t, key, mainnet, 8, 21000)
+	violations = validator.ValidateSendBundle(SendBundleRequest{Txs: blobs, BlockNumber: "0x10"})
+	sender := addressHex(crypto.PubkeyToAddress(key.PublicKey))
+	require.Equal(t, []BundleViolation{{Rule: BundleRuleNonce, Tx: 2, Message: "nonce 8 of " + sender + " follows nonce 5"}}, violations)
+
+	require.Equal(t, map[string]int{BundleRuleEncoding: 0}, rules(validator.ValidateSendBundle(SendBundleRequest{Txs: []string{"0x02c0"}, BlockNumber: "0x10"})))
+}
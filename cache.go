@@ -0,0 +1,148 @@
+package flashxroute
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheHeadTTL - how long the head block number used to tell final transactions and receipts is reused
+const cacheHeadTTL = 12 * time.Second
+
+// lru - size bounded map evicting the least recently used entry
+type lru[K comparable, V any] struct {
+	size    int
+	order   *list.List // most recently used first
+	entries map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{size: size, order: list.New(), entries: map[K]*list.Element{}}
+}
+
+func (c *lru[K, V]) get(key K) (V, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *lru[K, V]) add(key K, value V) {
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// CacheStats - lookups of the object cache, see WithCache
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// objectCache - immutable objects fetched by hash, see WithCache
+type objectCache struct {
+	size  int
+	depth int // blocks a transaction or receipt must be behind the head to be cached
+
+	mu       sync.Mutex
+	blocks   *lru[string, Block] // by hash and whether transactions are included
+	txs      *lru[string, Transaction]
+	receipts *lru[string, TransactionReceipt]
+	stats    CacheStats
+	head     int
+	fetched  time.Time
+}
+
+func newObjectCache(size, depth int) *objectCache {
+	return &objectCache{
+		size:     size,
+		depth:    depth,
+		blocks:   newLRU[string, Block](size),
+		txs:      newLRU[string, Transaction](size),
+		receipts: newLRU[string, TransactionReceipt](size),
+	}
+}
+
+// cacheKey returns the key of hash, hex case doesn't matter
+func cacheKey(hash string) string {
+	return strings.ToLower(AddHexPrefix(hash))
+}
+
+// blockKey returns the key of the block of hash, blocks with and without transactions are cached apart
+func blockKey(hash string, withTransactions bool) string {
+	if withTransactions {
+		return cacheKey(hash) + "+txs"
+	}
+
+	return cacheKey(hash)
+}
+
+// cached returns the value of key in c, counting the lookup
+func cached[V any](cache *objectCache, c *lru[string, V], key string) (V, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	value, ok := c.get(key)
+	if ok {
+		cache.stats.Hits++
+	} else {
+		cache.stats.Misses++
+	}
+
+	return value, ok
+}
+
+func store[V any](cache *objectCache, c *lru[string, V], key string, value V) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	c.add(key, value)
+}
+
+// final reports whether blockNumber is deep enough behind the head for its transactions and receipts to be cached,
+// the head is fetched at most once per cacheHeadTTL
+func (rpc *FlashXRoute) final(blockNumber int) bool {
+	cache := rpc.cache
+	cache.mu.Lock()
+	head, fresh := cache.head, time.Since(cache.fetched) < cacheHeadTTL
+	cache.mu.Unlock()
+	if !fresh {
+		number, err := rpc.EthBlockNumber()
+		if err != nil {
+			return false
+		}
+		cache.mu.Lock()
+		cache.head, cache.fetched, head = number, time.Now(), number
+		cache.mu.Unlock()
+	}
+
+	return blockNumber <= head-cache.depth
+}
+
+// CacheStats returns the lookups of the object cache, zero without WithCache
+func (rpc *FlashXRoute) CacheStats() CacheStats {
+	if rpc.cache == nil {
+		return CacheStats{}
+	}
+	rpc.cache.mu.Lock()
+	defer rpc.cache.mu.Unlock()
+
+	return rpc.cache.stats
+}
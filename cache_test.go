@@ -0,0 +1,66 @@
+package flashxroute
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestCache() {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		method, param := gjson.GetBytes(body, "method").String(), gjson.GetBytes(body, "params.0").String()
+		mu.Lock()
+		calls[method+" "+param]++
+		mu.Unlock()
+
+		var result string
+		switch method {
+		case "eth_blockNumber":
+			result = `"0x64"`
+		case "eth_getBlockByHash":
+			result = `{"number": "0x10", "hash": "` + param + `", "transactions": []}`
+		case "eth_getTransactionByHash":
+			number := map[string]string{"0x01": "0x10", "0x02": "0x60"}[param]
+			result = `{"hash": "` + param + `", "blockHash": "0xb1", "blockNumber": "` + number + `", "transactionIndex": "0x0"}`
+		case "eth_getTransactionReceipt":
+			result = `{"transactionHash": "` + param + `", "blockHash": "0xb1", "blockNumber": "0x10", "status": "0x1"}`
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": ` + result + `}`))
+	})
+	defer server.Close()
+
+	rpc := New(server.URL, WithHttpClient(http.DefaultClient), WithCache(2, 10))
+	for i := 0; i < 3; i++ {
+		block, err := rpc.EthGetBlockByHash("0xAA", false)
+		s.Require().NoError(err)
+		s.Require().Equal(16, block.Number)
+		_, err = rpc.EthGetTransactionByHash("0x01")
+		s.Require().NoError(err)
+		_, err = rpc.EthGetTransactionByHash("0x02")
+		s.Require().NoError(err)
+		receipt, err := rpc.EthGetTransactionReceipt("0x01")
+		s.Require().NoError(err)
+		s.Require().True(receipt.Succeeded())
+	}
+	s.Require().Equal(1, calls["eth_getBlockByHash 0xAA"])
+	s.Require().Equal(1, calls["eth_getTransactionByHash 0x01"])
+	s.Require().Equal(3, calls["eth_getTransactionByHash 0x02"], "block 0x60 is less than 10 blocks behind the head")
+	s.Require().Equal(1, calls["eth_getTransactionReceipt 0x01"])
+	s.Require().Equal(1, calls["eth_blockNumber "])
+	s.Require().Equal(CacheStats{Hits: 6, Misses: 6}, rpc.CacheStats())
+
+	// blocks with transactions are cached apart and evict the least recently used block
+	_, err := rpc.EthGetBlockByHash("0xaa", true)
+	s.Require().NoError(err)
+	_, err = rpc.EthGetBlockByHash("0xbb", false)
+	s.Require().NoError(err)
+	_, err = rpc.EthGetBlockByHash("0xaa", false)
+	s.Require().NoError(err)
+	s.Require().Equal(2, calls["eth_getBlockByHash 0xaa"])
+
+	s.Require().Equal(CacheStats{}, rpc.With(WithURL(server.URL)).CacheStats())
+}
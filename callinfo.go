@@ -0,0 +1,91 @@
+package flashxroute
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Response headers read into CallInfo, the first one present wins
+var (
+	requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Correlation-Id", "Cf-Ray"}
+	rateLimitHeaders = []string{"X-Ratelimit-Remaining", "Ratelimit-Remaining", "X-Rate-Limit-Remaining"}
+	regionHeaders    = []string{"X-Region", "Fly-Region", "X-Served-By", "X-Amz-Cf-Pop"}
+)
+
+// CallInfo - operational details of the http response of a node or relay call
+type CallInfo struct {
+	Method             string
+	URL                string
+	Status             int
+	Duration           time.Duration
+	RequestID          string      // request id of the endpoint, from X-Request-Id and similar headers
+	RateLimitRemaining int         // requests left in the rate limit window, -1 when the endpoint doesn't tell
+	Region             string      // region or edge serving the call, from X-Region and similar headers
+	Header             http.Header // all response headers
+}
+
+// newCallInfo returns the details of response to req
+func newCallInfo(method string, req *http.Request, response *http.Response, duration time.Duration) CallInfo {
+	info := CallInfo{
+		Method:             method,
+		Status:             response.StatusCode,
+		Duration:           duration,
+		RequestID:          firstHeader(response.Header, requestIDHeaders),
+		RateLimitRemaining: -1,
+		Region:             firstHeader(response.Header, regionHeaders),
+		Header:             response.Header,
+	}
+	if req != nil {
+		info.URL = req.URL.String()
+	}
+	if remaining, err := strconv.Atoi(firstHeader(response.Header, rateLimitHeaders)); err == nil {
+		info.RateLimitRemaining = remaining
+	}
+
+	return info
+}
+
+// firstHeader returns the value of the first of names present in header
+func firstHeader(header http.Header, names []string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// callInfoKey - context key of the CallInfo capture of ContextWithCallInfo
+type callInfoKey struct{}
+
+// callInfoCapture - CallInfo filled by the call made with the context
+type callInfoCapture struct {
+	mu   sync.Mutex
+	info *CallInfo
+}
+
+// ContextWithCallInfo returns ctx capturing into info the details of the last http response of the calls made with
+// it by CallContext and the relay calls taking a ctx like BloxrouteSubmitBundleContext, e.g. to log the relay's
+// request id of a failed submission
+func ContextWithCallInfo(ctx context.Context, info *CallInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, &callInfoCapture{info: info})
+}
+
+// reportCallInfo passes info to the OnCallInfo hook and the capture of the request context
+func (rpc *FlashXRoute) reportCallInfo(req *http.Request, info CallInfo) {
+	if rpc.hooks.OnCallInfo != nil {
+		rpc.hooks.OnCallInfo(info)
+	}
+	if req == nil {
+		return
+	}
+	if capture, ok := req.Context().Value(callInfoKey{}).(*callInfoCapture); ok {
+		capture.mu.Lock()
+		*capture.info = info
+		capture.mu.Unlock()
+	}
+}
@@ -0,0 +1,56 @@
+package flashxroute
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestCallInfo() {
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "rate limited"}}`)
+		response.Header.Set("X-Request-Id", "r1")
+		response.Header.Set("X-RateLimit-Remaining", "42")
+		response.Header.Set("Fly-Region", "fra")
+		return response, nil
+	})
+
+	var hooked []CallInfo
+	rpc := s.rpc.With(WithHooks(Hooks{OnCallInfo: func(info CallInfo) { hooked = append(hooked, info) }}))
+	var info CallInfo
+	_, err := rpc.CallContext(ContextWithCallInfo(context.Background(), &info), "eth_sendBundle")
+	s.Require().Equal(RpcError{Code: -32000, Message: "rate limited"}, err)
+
+	s.Require().Equal("eth_sendBundle", info.Method)
+	s.Require().Equal(s.rpc.url, info.URL)
+	s.Require().Equal(200, info.Status)
+	s.Require().Equal("r1", info.RequestID)
+	s.Require().Equal(42, info.RateLimitRemaining)
+	s.Require().Equal("fra", info.Region)
+	s.Require().Len(hooked, 1)
+	s.Require().Equal(info.RequestID, hooked[0].RequestID)
+
+	httpmock.RegisterResponder("POST", s.rpc.url, httpmock.NewStringResponder(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	_, err = rpc.Call("eth_blockNumber")
+	s.Require().Nil(err)
+	s.Require().Len(hooked, 2)
+	s.Require().Equal(-1, hooked[1].RateLimitRemaining)
+	s.Require().Empty(hooked[1].RequestID)
+}
+
+func (s *FlashXRouteTestSuite) TestRelayCallInfo() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "relay-1")
+		w.Write([]byte(`{"error": "bundle rejected"}`))
+	})
+	defer server.Close()
+
+	var info CallInfo
+	ctx := ContextWithCallInfo(context.Background(), &info)
+	_, err := s.rpc.With(WithURL(server.URL)).BloxrouteSubmitBundleContext(ctx, "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().ErrorIs(err, ErrRelayErrorResponse)
+	s.Require().Equal("blxr_submit_bundle", info.Method)
+	s.Require().Equal(server.URL, info.URL)
+	s.Require().Equal("relay-1", info.RequestID)
+}
@@ -0,0 +1,63 @@
+package flashxroute
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrChainIDMismatch is returned when a chain id differs from the one of the endpoint
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// chainIDCache - chain id of the endpoint, fetched once
+type chainIDCache struct {
+	mu      sync.Mutex
+	chainID *big.Int
+}
+
+// EthChainId returns the chain id of the endpoint, EIP-155 transactions must be signed for it.
+func (rpc *FlashXRoute) EthChainId() (big.Int, error) {
+	var response string
+	if err := rpc.call("eth_chainId", &response); err != nil {
+		return big.Int{}, err
+	}
+
+	return ParseBigInt(response)
+}
+
+// ChainID returns the chain id of the endpoint, fetched with eth_chainId on first use and cached by the client.
+// Clients derived with With share the cache unless they change the url.
+func (rpc *FlashXRoute) ChainID() (*big.Int, error) {
+	cache := rpc.chainID
+	if cache == nil {
+		chainID, err := rpc.EthChainId()
+		return &chainID, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.chainID == nil {
+		chainID, err := rpc.EthChainId()
+		if err != nil {
+			return nil, err
+		}
+		cache.chainID = &chainID
+	}
+
+	return new(big.Int).Set(cache.chainID), nil
+}
+
+// CheckChainID returns ErrChainIDMismatch unless chainID is the chain id of the endpoint, e.g. to refuse submitting
+// transactions signed for another network
+func (rpc *FlashXRoute) CheckChainID(chainID *big.Int) error {
+	endpoint, err := rpc.ChainID()
+	if err != nil {
+		return err
+	}
+	if chainID == nil || chainID.Cmp(endpoint) != 0 {
+		return errors.Wrapf(ErrChainIDMismatch, "signing for chain %v, endpoint is on chain %v", chainID, endpoint)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package flashxroute
+
+import (
+	"math/big"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestChainID() {
+	var calls int32
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		s.methodEqual(s.getBody(request), "eth_chainId")
+		atomic.AddInt32(&calls, 1)
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+
+	key, _ := crypto.GenerateKey()
+	rpc := New(s.rpc.url, WithHttpClient(http.DefaultClient), WithSigner(NewPrivateKeySigner(key)), WithChainIDCheck(true))
+	for i := 0; i < 2; i++ {
+		chainID, err := rpc.ChainID()
+		s.Require().Nil(err)
+		s.Require().Equal(int64(1), chainID.Int64())
+	}
+	s.Require().Equal(int32(1), atomic.LoadInt32(&calls))
+
+	tx := types.NewTransaction(0, [20]byte{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	_, err := rpc.SignTransaction(tx, big.NewInt(1))
+	s.Require().Nil(err)
+	_, err = rpc.SignTransaction(tx, big.NewInt(56))
+	s.Require().ErrorIs(err, ErrChainIDMismatch)
+	s.Require().Equal(int32(1), atomic.LoadInt32(&calls))
+
+	_, err = rpc.With(WithURL(s.rpc.url)).ChainID()
+	s.Require().Nil(err)
+	s.Require().Equal(int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,173 @@
+package flashxroute
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrChaseExhausted is returned when SubmitAndChase targeted ChaseOptions.MaxBlocks blocks without inclusion
+var ErrChaseExhausted = errors.New("bundle not included within max blocks")
+
+// ChaseEventKind - kind of SubmitAndChase progress event
+type ChaseEventKind int
+
+// SubmitAndChase progress events
+const (
+	ChaseSubmitted ChaseEventKind = iota // bundle submitted for Block
+	ChaseSkipped                         // re-simulation for Block failed, Err tells why and the block is skipped
+	ChaseError                           // polling or submission failed, Err tells why and the chase goes on
+	ChaseIncluded                        // bundle landed in Block
+)
+
+// ChaseEvent - progress of SubmitAndChase
+type ChaseEvent struct {
+	Kind       ChaseEventKind
+	Block      int
+	BundleHash string
+	Err        error
+}
+
+// ChaseOptions - parameters of SubmitAndChase
+type ChaseOptions struct {
+	PollInterval time.Duration     // how often to poll for a new head (default: 1s)
+	MaxBlocks    int               // give up after targeting this many blocks (default: 0 - until ctx is done)
+	Resimulate   bool              // simulate on every new head and skip blocks where the bundle fails or a tx reverts
+	Progress     chan<- ChaseEvent // receives progress events, sends block until received or ctx is done
+}
+
+// SubmitAndChase submits the bundle for the block after the current head and re-targets it on every new head
+// until it is included, ctx is done or MaxBlocks blocks were targeted. The bundle counts as included when all its
+// transactions not listed in RevertingHashes have receipts in the same block, so a public transaction it backruns
+// landing alone doesn't end the chase. Returns the inclusion block number.
+func (rpc *FlashXRoute) SubmitAndChase(ctx context.Context, authHeader string, bundle BloxrouteSubmitBundleRequest, options ChaseOptions) (int, error) {
+	if len(bundle.Transaction) == 0 {
+		return 0, errors.New("bundle has no transactions")
+	}
+	hashes, err := chasedHashes(bundle)
+	if err != nil {
+		return 0, err
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Second
+	}
+	report := func(event ChaseEvent) {
+		if options.Progress == nil {
+			return
+		}
+		select {
+		case options.Progress <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	ticker := time.NewTicker(options.PollInterval)
+	defer ticker.Stop()
+
+	head, targeted := -1, 0
+	for {
+		number, err := rpc.EthBlockNumber()
+		if err != nil {
+			report(ChaseEvent{Kind: ChaseError, Err: err})
+		} else if number != head {
+			head = number
+			if targeted > 0 {
+				included, err := rpc.bundleInclusion(hashes)
+				if err != nil {
+					report(ChaseEvent{Kind: ChaseError, Block: head, Err: err})
+				} else if included > 0 {
+					report(ChaseEvent{Kind: ChaseIncluded, Block: included})
+					rpc.notify(BundleEvent{Kind: BundleIncluded, BlockNumber: head, Transactions: bundle.Transaction, IncludedIn: included})
+					return included, nil
+				}
+			}
+
+			if options.MaxBlocks > 0 && targeted == options.MaxBlocks {
+				rpc.notify(BundleEvent{Kind: BundleExpired, BlockNumber: head, Transactions: bundle.Transaction})
+				return 0, ErrChaseExhausted
+			}
+			targeted++
+			rpc.chase(authHeader, bundle, head+1, options.Resimulate, report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// chasedHashes returns the hashes of the transactions of bundle which must land for it to count as included, all
+// of them if every transaction may revert
+func chasedHashes(bundle BloxrouteSubmitBundleRequest) ([]string, error) {
+	reverting := map[string]bool{}
+	if bundle.RevertingHashes != nil {
+		for _, hash := range *bundle.RevertingHashes {
+			reverting[strings.ToLower(hash)] = true
+		}
+	}
+
+	var all, required []string
+	for i, raw := range bundle.Transaction {
+		hash, err := RawTxHash(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "transaction %d", i)
+		}
+		all = append(all, hash)
+		if !reverting[hash] {
+			required = append(required, hash)
+		}
+	}
+	if len(required) == 0 {
+		return all, nil
+	}
+
+	return required, nil
+}
+
+// bundleInclusion returns the block all transactions of hashes landed in, 0 while any of them is pending or they
+// landed in different blocks
+func (rpc *FlashXRoute) bundleInclusion(hashes []string) (int, error) {
+	receipts, err := rpc.GetTransactionReceipts(hashes, len(hashes))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, receipt := range receipts {
+		if receipt == nil || receipt.BlockHash == "" || receipt.BlockHash != receipts[0].BlockHash {
+			return 0, nil
+		}
+	}
+
+	return receipts[0].BlockNumber, nil
+}
+
+// chase submits the bundle for target, re-simulating it first if asked to
+func (rpc *FlashXRoute) chase(authHeader string, bundle BloxrouteSubmitBundleRequest, target int, resimulate bool, report func(ChaseEvent)) {
+	bundle.BlockNumber = IntToHex(target)
+
+	if resimulate {
+		res, err := rpc.BloxrouteSimulateBundle(authHeader, BloxrouteSimulateBundleRequest{Transaction: bundle.Transaction, BlockNumber: bundle.BlockNumber})
+		if err == nil {
+			if errs := res.Errors(); len(errs) > 0 {
+				err = errs[0]
+			}
+		}
+		if err != nil {
+			report(ChaseEvent{Kind: ChaseSkipped, Block: target, Err: err})
+			rpc.notify(BundleEvent{Kind: BundleSimulationFailed, BlockNumber: target, Transactions: bundle.Transaction, Err: err.Error()})
+			return
+		}
+	}
+
+	res, err := rpc.BloxrouteSubmitBundle(authHeader, bundle)
+	if err != nil {
+		report(ChaseEvent{Kind: ChaseError, Block: target, Err: err})
+		return
+	}
+	report(ChaseEvent{Kind: ChaseSubmitted, Block: target, BundleHash: res.BundleHash})
+}
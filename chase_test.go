@@ -0,0 +1,85 @@
+package flashxroute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestSubmitAndChase() {
+	var mu sync.Mutex
+	head, submitted := 16, []string{}
+	victim, backrun := Keccak256([]byte{1}), Keccak256([]byte{2})
+	landed := map[string]bool{} // transactions with a receipt regardless of head
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body := s.getBody(r)
+		result := "null"
+		switch gjson.GetBytes(body, "method").String() {
+		case "eth_blockNumber":
+			head++
+			result = fmt.Sprintf(`"0x%x"`, head)
+		case "blxr_submit_bundle":
+			submitted = append(submitted, gjson.GetBytes(body, "params.block_number").String())
+			result = `{"bundleHash": "0xb"}`
+		case "eth_getTransactionReceipt":
+			if hash := gjson.GetBytes(body, "params.0").String(); landed[hash] {
+				result = `{"blockHash": "0xbh0", "blockNumber": "0x12"}`
+			} else if head == 19 {
+				result = `{"blockHash": "0xbh", "blockNumber": "0x13"}`
+			}
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0", "id":1, "result": %s}`, result)
+	})
+	defer server.Close()
+
+	progress := make(chan ChaseEvent, 10)
+	rpc := s.rpc.With(WithURL(server.URL))
+	block, err := rpc.SubmitAndChase(context.Background(), "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01", "02"}}, ChaseOptions{PollInterval: time.Millisecond, Progress: progress})
+	s.Require().Nil(err)
+	s.Require().Equal(19, block)
+	s.Require().Equal([]string{"0x12", "0x13"}, submitted)
+
+	close(progress)
+	events := []ChaseEvent{}
+	for event := range progress {
+		events = append(events, event)
+	}
+	s.Require().Equal([]ChaseEvent{
+		{Kind: ChaseSubmitted, Block: 18, BundleHash: "0xb"},
+		{Kind: ChaseSubmitted, Block: 19, BundleHash: "0xb"},
+		{Kind: ChaseIncluded, Block: 19},
+	}, events)
+
+	// never included
+	head, submitted = 0, nil
+	_, err = rpc.SubmitAndChase(context.Background(), "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}}, ChaseOptions{PollInterval: time.Millisecond, MaxBlocks: 3})
+	s.Require().ErrorIs(err, ErrChaseExhausted)
+	s.Require().Len(submitted, 3)
+
+	// the backrun victim landing alone isn't inclusion of the bundle
+	head, submitted = 0, nil
+	landed[victim] = true
+	_, err = rpc.SubmitAndChase(context.Background(), "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01", "02"}}, ChaseOptions{PollInterval: time.Millisecond, MaxBlocks: 3})
+	s.Require().ErrorIs(err, ErrChaseExhausted)
+	s.Require().Len(submitted, 3)
+
+	// transactions allowed to revert needn't land
+	head, submitted = 0, nil
+	reverting := []string{backrun}
+	block, err = rpc.SubmitAndChase(context.Background(), "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01", "02"}, RevertingHashes: &reverting}, ChaseOptions{PollInterval: time.Millisecond, MaxBlocks: 3})
+	s.Require().Nil(err)
+	s.Require().Equal(18, block)
+	delete(landed, victim)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	head = 100
+	_, err = rpc.SubmitAndChase(ctx, "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}}, ChaseOptions{PollInterval: time.Millisecond})
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+}
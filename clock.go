@@ -0,0 +1,123 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoDateHeader is returned when the endpoint response has no usable Date header to measure clock drift against
+var ErrNoDateHeader = errors.New("response has no Date header")
+
+// clock keeps the last measured drift between the endpoint clock and the local clock
+type clock struct {
+	threshold time.Duration
+	adjust    bool
+
+	mu       sync.Mutex
+	drift    time.Duration
+	measured bool
+}
+
+// ClockDrift measures how far the endpoint clock is ahead of the local clock (negative when behind) using the Date
+// header of a plain request to the rpc url. The header has a resolution of one second so drift below that is noise.
+func (rpc *FlashXRoute) ClockDrift() (time.Duration, error) {
+	req, err := http.NewRequest("GET", rpc.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	for k, v := range rpc.Headers {
+		req.Header.Add(k, v)
+	}
+	httpClient := rpc.newHTTPClient("", nil)
+
+	sent := time.Now()
+	response, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	received := time.Now()
+
+	date, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrNoDateHeader, rpc.url)
+	}
+
+	// the server stamped the response somewhere during the round trip, assume the middle and round to its resolution
+	local := sent.Add(received.Sub(sent) / 2)
+	return date.Sub(local).Round(time.Second), nil
+}
+
+// CheckClock measures the clock drift and remembers it for timestamp adjustment, see WithClockCheck. Drift beyond
+// the threshold is logged as an error of SubsystemTransport.
+func (rpc *FlashXRoute) CheckClock() (time.Duration, error) {
+	drift, err := rpc.ClockDrift()
+	if err != nil {
+		return 0, err
+	}
+	if rpc.clock == nil {
+		return drift, nil
+	}
+
+	rpc.clock.mu.Lock()
+	rpc.clock.drift, rpc.clock.measured = drift, true
+	rpc.clock.mu.Unlock()
+
+	if rpc.clock.exceeded(drift) {
+		rpc.debugf(SubsystemTransport, LogErrors, "local clock is off by %s from %s, bundle timestamps may be rejected", -drift, rpc.url)
+	}
+
+	return drift, nil
+}
+
+// Now returns the local time corrected by the last measured clock drift, use it to compute bundle timestamps
+func (rpc *FlashXRoute) Now() time.Time {
+	if rpc.clock == nil {
+		return time.Now()
+	}
+
+	rpc.clock.mu.Lock()
+	defer rpc.clock.mu.Unlock()
+	return time.Now().Add(rpc.clock.drift)
+}
+
+func (c *clock) exceeded(drift time.Duration) bool {
+	return drift > c.threshold || drift < -c.threshold
+}
+
+// shift returns the seconds to add to local timestamps, zero unless adjustment is enabled and the drift exceeds the
+// threshold
+func (c *clock) shift() int64 {
+	if c == nil || !c.adjust {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.measured || !c.exceeded(c.drift) {
+		return 0
+	}
+	return int64(c.drift / time.Second)
+}
+
+// adjusted returns bundle min/max timestamps computed from the local clock shifted onto the endpoint clock, the
+// caller's values are left untouched
+func (c *clock) adjusted(min, max *uint64) (*uint64, *uint64) {
+	shift := c.shift()
+	if shift == 0 {
+		return min, max
+	}
+
+	move := func(timestamp *uint64) *uint64 {
+		if timestamp == nil {
+			return nil
+		}
+		moved := uint64(int64(*timestamp) + shift)
+		return &moved
+	}
+	return move(min), move(max)
+}
@@ -0,0 +1,68 @@
+package flashxroute
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) registerDate(date time.Time) {
+	httpmock.Reset()
+	httpmock.RegisterResponder("GET", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		response := httpmock.NewStringResponse(405, "")
+		response.Header.Set("Date", date.UTC().Format(http.TimeFormat))
+		return response, nil
+	})
+}
+
+func (s *FlashXRouteTestSuite) TestClockDrift() {
+	s.registerDate(time.Now().Add(-time.Minute))
+	drift, err := s.rpc.ClockDrift()
+	s.Require().Nil(err)
+	s.Require().InDelta(float64(-time.Minute), float64(drift), float64(2*time.Second))
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("GET", s.rpc.url, httpmock.NewStringResponder(200, ""))
+	_, err = s.rpc.ClockDrift()
+	s.Require().ErrorIs(err, ErrNoDateHeader)
+}
+
+func (s *FlashXRouteTestSuite) TestClockAdjustTimestamps() {
+	rpc := s.rpc.With(WithClockCheck(5*time.Second, true))
+	s.registerDate(time.Now().Add(time.Hour))
+	drift, err := rpc.CheckClock()
+	s.Require().Nil(err)
+	s.Require().InDelta(float64(time.Hour), float64(drift), float64(2*time.Second))
+	s.Require().WithinDuration(time.Now().Add(time.Hour), rpc.Now(), 2*time.Second)
+
+	log := new(bufferLogger)
+	_, err = rpc.With(WithLogger(log)).CheckClock()
+	s.Require().Nil(err)
+	s.Require().Empty(log.lines)
+	_, err = rpc.With(WithLogger(log), WithLogLevel(SubsystemTransport, LogErrors)).CheckClock()
+	s.Require().Nil(err)
+	s.Require().Len(log.lines, 1)
+	s.Require().Contains(log.lines[0], "local clock is off")
+
+	min, max := uint64(1000000), uint64(1000060)
+	adjustedMin, adjustedMax := rpc.clock.adjusted(&min, &max)
+	s.Require().InDelta(1003600, *adjustedMin, 2)
+	s.Require().Equal(*adjustedMin+60, *adjustedMax)
+	s.Require().EqualValues(1000000, min)
+
+	// within the threshold timestamps are left alone
+	s.registerDate(time.Now())
+	_, err = rpc.CheckClock()
+	s.Require().Nil(err)
+	adjustedMin, _ = rpc.clock.adjusted(&min, nil)
+	s.Require().Equal(&min, adjustedMin)
+
+	// without adjust the drift is only reported
+	rpc = s.rpc.With(WithClockCheck(5*time.Second, false))
+	s.registerDate(time.Now().Add(time.Hour))
+	_, err = rpc.CheckClock()
+	s.Require().Nil(err)
+	adjustedMin, _ = rpc.clock.adjusted(&min, nil)
+	s.Require().Equal(&min, adjustedMin)
+}
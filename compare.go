@@ -0,0 +1,113 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// RelaySimulation - outcome of a bundle simulation on one relay
+type RelaySimulation struct {
+	URL      string
+	Response BloxrouteSimulateBundleResponse
+	Err      error
+}
+
+// SimulationDiff - a simulation field on which relays disagree with its value by relay index, the index of the relay
+// in the SimulateAcrossRelays arguments and in Simulations, so relays sharing a url are told apart. Failed
+// simulations report their error under the "error" field.
+type SimulationDiff struct {
+	Field  string
+	Values map[int]string
+}
+
+// SimulationComparison - simulations of the same bundle on several relays and the fields they disagree on
+type SimulationComparison struct {
+	Simulations []RelaySimulation
+	Diffs       []SimulationDiff
+}
+
+// Consistent reports whether all relays returned the same simulation
+func (c SimulationComparison) Consistent() bool {
+	return len(c.Diffs) == 0
+}
+
+// SimulateAcrossRelays simulates the bundle on all relays concurrently and reports the fields their results
+// disagree on (gas used, coinbase diff, per-tx errors...), exposing differences in the builders' state before
+// the bundle is submitted
+func SimulateAcrossRelays(authHeader string, bundle BloxrouteSimulateBundleRequest, relays ...*FlashXRoute) SimulationComparison {
+	simulations := make([]RelaySimulation, len(relays))
+
+	var wg sync.WaitGroup
+	for i, relay := range relays {
+		wg.Add(1)
+		go func(i int, relay *FlashXRoute) {
+			defer wg.Done()
+			res, err := relay.BloxrouteSimulateBundle(authHeader, bundle)
+			simulations[i] = RelaySimulation{URL: relay.URL(), Response: res, Err: err}
+		}(i, relay)
+	}
+	wg.Wait()
+
+	return SimulationComparison{Simulations: simulations, Diffs: diffSimulations(simulations)}
+}
+
+type simulationField struct {
+	name  string
+	value string
+}
+
+// simulationFields returns the compared fields of a simulation in report order, only the error of failed ones
+func simulationFields(simulation RelaySimulation) []simulationField {
+	if simulation.Err != nil {
+		return []simulationField{{"error", simulation.Err.Error()}}
+	}
+
+	res := simulation.Response
+	fields := []simulationField{
+		{"error", ""},
+		{"stateBlockNumber", strconv.FormatInt(res.StateBlockNumber, 10)},
+		{"totalGasUsed", strconv.FormatInt(res.TotalGasUsed, 10)},
+		{"coinbaseDiff", res.CoinbaseDiff},
+		{"ethSentToCoinbase", res.EthSentToCoinbase},
+		{"gasFees", res.GasFees},
+		{"bundleGasPrice", res.BundleGasPrice},
+		{"results", strconv.Itoa(len(res.Results))},
+	}
+	for i, result := range res.Results {
+		fields = append(fields,
+			simulationField{fmt.Sprintf("results[%d].gasUsed", i), strconv.FormatInt(result.GasUsed, 10)},
+			simulationField{fmt.Sprintf("results[%d].error", i), result.Error},
+		)
+	}
+
+	return fields
+}
+
+func diffSimulations(simulations []RelaySimulation) []SimulationDiff {
+	names := []string{}
+	values := map[string]map[int]string{}
+	for i, simulation := range simulations {
+		for _, field := range simulationFields(simulation) {
+			if _, ok := values[field.name]; !ok {
+				names = append(names, field.name)
+				values[field.name] = map[int]string{}
+			}
+			values[field.name][i] = field.value
+		}
+	}
+
+	diffs := []SimulationDiff{}
+	for _, name := range names {
+		distinct := map[string]bool{}
+		for _, value := range values[name] {
+			distinct[value] = true
+		}
+		// fields missing on some relays are covered by the error and results count fields
+		if len(distinct) > 1 {
+			diffs = append(diffs, SimulationDiff{Field: name, Values: values[name]})
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,49 @@
+package flashxroute
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *FlashXRouteTestSuite) TestSimulateAcrossRelays() {
+	relay := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+	}
+	a := relay(`{"jsonrpc":"2.0", "id":1, "result": {"coinbaseDiff": "100", "totalGasUsed": 42000, "stateBlockNumber": 16,
+		"results": [{"gasUsed": 21000}, {"gasUsed": 21000}]}}`)
+	defer a.Close()
+	b := relay(`{"jsonrpc":"2.0", "id":1, "result": {"coinbaseDiff": "50", "totalGasUsed": 42000, "stateBlockNumber": 16,
+		"results": [{"gasUsed": 21000}, {"gasUsed": 21000, "error": "execution reverted"}]}}`)
+	defer b.Close()
+	c := relay(`{"error": "block param must be a hex int"}`)
+	defer c.Close()
+
+	bundle := BloxrouteSimulateBundleRequest{Transaction: []string{"01", "02"}, BlockNumber: "0x11"}
+	comparison := SimulateAcrossRelays("auth", bundle, s.rpc.With(WithURL(a.URL)), s.rpc.With(WithURL(a.URL)))
+	s.Require().True(comparison.Consistent())
+	s.Require().Len(comparison.Simulations, 2)
+
+	comparison = SimulateAcrossRelays("auth", bundle, s.rpc.With(WithURL(a.URL)), s.rpc.With(WithURL(b.URL)), s.rpc.With(WithURL(c.URL)))
+	s.Require().False(comparison.Consistent())
+	s.Require().ErrorIs(comparison.Simulations[2].Err, ErrRelayErrorResponse)
+	s.Require().Equal([]SimulationDiff{
+		{Field: "error", Values: map[int]string{0: "", 1: "", 2: "relay error response: block param must be a hex int"}},
+		{Field: "coinbaseDiff", Values: map[int]string{0: "100", 1: "50"}},
+		{Field: "results[1].error", Values: map[int]string{0: "", 1: "execution reverted"}},
+	}, comparison.Diffs)
+
+	// relays sharing a url, e.g. with different credentials, don't hide each other
+	shared := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		coinbaseDiff := "100"
+		if r.Header.Get("X-Account") == "b" {
+			coinbaseDiff = "50"
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"coinbaseDiff": "` + coinbaseDiff + `"}}`))
+	})
+	defer shared.Close()
+	comparison = SimulateAcrossRelays("auth", bundle, s.rpc.With(WithURL(shared.URL), WithHeader("X-Account", "a")),
+		s.rpc.With(WithURL(shared.URL), WithHeader("X-Account", "b")))
+	s.Require().Equal([]SimulationDiff{{Field: "coinbaseDiff", Values: map[int]string{0: "100", 1: "50"}}}, comparison.Diffs)
+}
@@ -0,0 +1,107 @@
+package flashxroute
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// compression - gzip settings shared by clones of a client
+type compression struct {
+	minSize     int
+	unsupported int32 // set once the endpoint rejected a compressed body, requests are sent uncompressed afterwards
+}
+
+// compressTransport - gzips request bodies of at least minSize bytes and transparently decompresses gzipped
+// responses
+type compressTransport struct {
+	base        http.RoundTripper
+	compression *compression
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *compressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	if req.Body != nil && atomic.LoadInt32(&t.compression.unsupported) == 0 {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body, req.ContentLength = ioutil.NopCloser(bytes.NewReader(body)), int64(len(body))
+
+		if len(body) >= t.compression.minSize {
+			response, err := t.roundTripCompressed(base, req, body)
+			if err != nil || response.StatusCode != http.StatusUnsupportedMediaType {
+				return response, err
+			}
+
+			// the endpoint does not accept compressed bodies, resend plain and stop compressing
+			response.Body.Close()
+			atomic.StoreInt32(&t.compression.unsupported, 1)
+			req.Header.Del("Content-Encoding")
+			req.Body, req.ContentLength = ioutil.NopCloser(bytes.NewReader(body)), int64(len(body))
+		}
+	}
+
+	return decompress(base.RoundTrip(req))
+}
+
+func (t *compressTransport) roundTripCompressed(base http.RoundTripper, req *http.Request, body []byte) (*http.Response, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	compressedReq := req.Clone(req.Context())
+	compressedReq.Header.Set("Content-Encoding", "gzip")
+	compressedReq.Body, compressedReq.ContentLength = ioutil.NopCloser(&compressed), int64(compressed.Len())
+
+	return decompress(base.RoundTrip(compressedReq))
+}
+
+// decompress replaces the body of a gzipped response with its decompressed content
+func decompress(response *http.Response, err error) (*http.Response, error) {
+	if err != nil || !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return response, err
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+	response.Body = &gzipBody{Reader: reader, body: response.Body}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+
+	return response, nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes both the gzip reader and the underlying body
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,60 @@
+package flashxroute
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestCompression() {
+	encodings := []string{}
+	reject := false
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		s.Require().Equal("gzip", r.Header.Get("Accept-Encoding"))
+		body := s.getBody(r)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			if reject {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			reader, err := gzip.NewReader(bytes.NewReader(body))
+			s.Require().Nil(err)
+			body, err = ioutil.ReadAll(reader)
+			s.Require().Nil(err)
+		}
+
+		var response bytes.Buffer
+		writer := gzip.NewWriter(&response)
+		writer.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "` + gjson.GetBytes(body, "method").String() + `"}`))
+		writer.Close()
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(response.Bytes())
+	})
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL), WithCompression(100))
+	var result string
+	s.Require().Nil(rpc.call("web3_clientVersion", &result))
+	s.Require().Equal("web3_clientVersion", result)
+
+	s.Require().Nil(rpc.call("web3_sha3", &result, strings.Repeat("ab", 100)))
+	s.Require().Equal("web3_sha3", result)
+
+	// bloXroute calls go through their own transport
+	res, err := rpc.CallWithBloxrouteAuthHeader("blxr_submit_bundle", "auth", BloxrouteSubmitBundleRequest{Transaction: []string{strings.Repeat("ab", 100)}})
+	s.Require().Nil(err)
+	s.Require().Equal(`"blxr_submit_bundle"`, string(res))
+	s.Require().Equal([]string{"", "gzip", "gzip"}, encodings)
+
+	// endpoints rejecting compressed bodies get plain ones from then on
+	reject, encodings = true, nil
+	s.Require().Nil(rpc.call("web3_sha3", &result, strings.Repeat("ab", 100)))
+	s.Require().Nil(rpc.call("web3_sha3", &result, strings.Repeat("ab", 100)))
+	s.Require().Equal("web3_sha3", result)
+	s.Require().Equal([]string{"gzip", "", ""}, encodings)
+}
@@ -0,0 +1,340 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Config errors
+var (
+	ErrMissingEnv    = errors.New("environment variable not set")
+	ErrUnknownClient = errors.New("unknown client")
+	ErrNoEndpoint    = errors.New("client has neither url nor ipc path")
+)
+
+// Duration - time.Duration read from strings like "1.5s" or numbers of seconds
+type Duration time.Duration
+
+// UnmarshalJSON decodes "1.5s" like strings and numbers of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return errors.Errorf("invalid duration %s", data)
+	}
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+
+	return nil
+}
+
+// RetryConfig - timeout and retries of a method pattern, see MethodConfig
+type RetryConfig struct {
+	Timeout    Duration `json:"timeout"`
+	Retries    int      `json:"retries"`
+	RetryDelay Duration `json:"retryDelay"`
+}
+
+// AuthConfig - credentials of a client, all the configured ones are applied
+type AuthConfig struct {
+	Bloxroute  string            `json:"bloxroute"` // bloXroute Authorization header
+	AccountID  string            `json:"accountId"` // with SecretHash builds the bloXroute header, see AuthorizationHeader
+	SecretHash string            `json:"secretHash"`
+	Bearer     string            `json:"bearer"`  // token sent as Authorization: Bearer
+	Headers    map[string]string `json:"headers"` // static headers, e.g. API keys
+}
+
+// ClientConfig - configuration of one client, see LoadConfig
+type ClientConfig struct {
+	URL              string                 `json:"url"`
+	IPC              string                 `json:"ipc"` // unix socket path used instead of url
+	Network          string                 `json:"network"`
+	Auth             AuthConfig             `json:"auth"`
+	Timeout          Duration               `json:"timeout"`
+	Methods          map[string]RetryConfig `json:"methods"`  // by method name or pattern, see WithMethodConfig
+	Builders         []string               `json:"builders"` // builders of bundles without mev_builders
+	ExcludeBuilders  []string               `json:"excludeBuilders"`
+	NonCensoringOnly bool                   `json:"nonCensoringOnly"`
+	Compression      int                    `json:"compression"` // gzip bodies from this size, see WithCompression
+	Debug            bool                   `json:"debug"`
+	LogLevels        map[string]LogLevel    `json:"logLevels"` // by subsystem, "" for all, see WithLogLevel
+}
+
+// Config - clients and relay sets of a deployment, see LoadConfig
+type Config struct {
+	Defaults  ClientConfig            `json:"defaults"` // inherited by every client for the fields it leaves empty
+	Clients   map[string]ClientConfig `json:"clients"`
+	RelaySets map[string][]string     `json:"relaySets"` // named lists of clients, e.g. for SimulateAcrossRelays
+}
+
+// envReference matches ${NAME} and ${NAME:-default}
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolateEnv replaces environment variable references in the string values of document, unset variables
+// without default are an error
+func interpolateEnv(document interface{}) (interface{}, error) {
+	var missing []string
+	var interpolate func(value interface{}) interface{}
+	interpolate = func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case string:
+			return envReference.ReplaceAllStringFunc(value, func(reference string) string {
+				match := envReference.FindStringSubmatch(reference)
+				if env, ok := os.LookupEnv(match[1]); ok {
+					return env
+				}
+				if match[2] != "" {
+					return match[3]
+				}
+				missing = append(missing, match[1])
+				return reference
+			})
+		case map[string]interface{}:
+			for k, v := range value {
+				value[k] = interpolate(v)
+			}
+		case []interface{}:
+			for i, v := range value {
+				value[i] = interpolate(v)
+			}
+		case []map[string]interface{}:
+			for _, v := range value {
+				interpolate(v)
+			}
+		}
+		return value
+	}
+
+	document = interpolate(document)
+	if len(missing) > 0 {
+		return nil, errors.Wrap(ErrMissingEnv, strings.Join(missing, ", "))
+	}
+
+	return document, nil
+}
+
+// LoadConfig reads YAML (.yaml, .yml), TOML (.toml) or JSON config at path. ${NAME} and ${NAME:-default}
+// references in string values are replaced with environment variables after parsing, so credentials stay out of the
+// file and their content can't alter its structure. Keys are
+// the json names of the Config fields, e.g.
+//
+//	defaults:
+//	  timeout: 10s
+//	clients:
+//	  bloxroute:
+//	    url: https://api.blxrbdn.com
+//	    network: Mainnet
+//	    auth: {bloxroute: "${BLOXROUTE_AUTH}"}
+//	    methods: {"blxr_*": {retries: 2, retryDelay: 100ms}}
+//	relaySets:
+//	  all: [bloxroute]
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		table := map[string]interface{}{}
+		err = toml.Unmarshal(data, &table)
+		document = table
+	case ".json":
+		err = json.Unmarshal(data, &document)
+	default:
+		return nil, errors.Errorf("unsupported config format %s", path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	if document, err = interpolateEnv(document); err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	data, err = json.Marshal(document)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	config := new(Config)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	return config, nil
+}
+
+// merge returns the config with empty fields taken from defaults, method patterns are merged
+func (c ClientConfig) merge(defaults ClientConfig) ClientConfig {
+	if c.URL == "" && c.IPC == "" {
+		c.URL, c.IPC = defaults.URL, defaults.IPC
+	}
+	if c.Network == "" {
+		c.Network = defaults.Network
+	}
+	if c.Auth.Bloxroute == "" && c.Auth.AccountID == "" {
+		c.Auth.Bloxroute, c.Auth.AccountID, c.Auth.SecretHash = defaults.Auth.Bloxroute, defaults.Auth.AccountID, defaults.Auth.SecretHash
+	}
+	if c.Auth.Bearer == "" {
+		c.Auth.Bearer = defaults.Auth.Bearer
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaults.Timeout
+	}
+	if c.Builders == nil {
+		c.Builders = defaults.Builders
+	}
+	if c.ExcludeBuilders == nil {
+		c.ExcludeBuilders = defaults.ExcludeBuilders
+	}
+	if c.Compression == 0 {
+		c.Compression = defaults.Compression
+	}
+	c.NonCensoringOnly = c.NonCensoringOnly || defaults.NonCensoringOnly
+	c.Debug = c.Debug || defaults.Debug
+
+	c.Auth.Headers = mergeMaps(defaults.Auth.Headers, c.Auth.Headers)
+	c.Methods = mergeMaps(defaults.Methods, c.Methods)
+	c.LogLevels = mergeMaps(defaults.LogLevels, c.LogLevels)
+
+	return c
+}
+
+func mergeMaps[V any](defaults, values map[string]V) map[string]V {
+	if len(defaults) == 0 {
+		return values
+	}
+
+	merged := make(map[string]V, len(defaults)+len(values))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// Options returns client options of the config
+func (c ClientConfig) Options() []func(rpc *FlashXRoute) {
+	var options []func(rpc *FlashXRoute)
+	if c.Network != "" {
+		options = append(options, WithNetwork(c.Network))
+	}
+	switch {
+	case c.Auth.Bloxroute != "":
+		options = append(options, WithBloxrouteAuthHeader(c.Auth.Bloxroute))
+	case c.Auth.AccountID != "":
+		options = append(options, WithBloxrouteAuthHeader(AuthorizationHeader(c.Auth.AccountID, c.Auth.SecretHash)))
+	}
+	if c.Auth.Bearer != "" {
+		options = append(options, WithAuth(BearerToken(c.Auth.Bearer)))
+	}
+	for name, value := range c.Auth.Headers {
+		options = append(options, WithHeader(name, value))
+	}
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(time.Duration(c.Timeout)))
+	}
+	if len(c.Methods) > 0 {
+		methods := make(map[string]MethodConfig, len(c.Methods))
+		for pattern, retry := range c.Methods {
+			methods[pattern] = MethodConfig{Timeout: time.Duration(retry.Timeout), Retries: retry.Retries, RetryDelay: time.Duration(retry.RetryDelay)}
+		}
+		options = append(options, WithMethodConfig(methods))
+	}
+	if len(c.Builders) > 0 || len(c.ExcludeBuilders) > 0 || c.NonCensoringOnly {
+		options = append(options, WithPolicy(Policy{Include: c.Builders, Exclude: c.ExcludeBuilders, NonCensoringOnly: c.NonCensoringOnly}))
+	}
+	if c.Compression > 0 {
+		options = append(options, WithCompression(c.Compression))
+	}
+	if c.Debug {
+		options = append(options, WithDebug(true))
+	}
+	for subsystem, level := range c.LogLevels {
+		options = append(options, WithLogLevel(subsystem, level))
+	}
+
+	return options
+}
+
+// NewClient create client from the config, options are applied after the configured ones
+func (c ClientConfig) NewClient(options ...func(rpc *FlashXRoute)) (*FlashXRoute, error) {
+	options = append(c.Options(), options...)
+	switch {
+	case c.IPC != "":
+		return NewIPC(c.IPC, options...), nil
+	case c.URL != "":
+		return New(c.URL, options...), nil
+	}
+
+	return nil, ErrNoEndpoint
+}
+
+// Client create the named client
+func (c *Config) Client(name string, options ...func(rpc *FlashXRoute)) (*FlashXRoute, error) {
+	client, ok := c.Clients[name]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownClient, name)
+	}
+
+	rpc, err := client.merge(c.Defaults).NewClient(options...)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	return rpc, nil
+}
+
+// NewClients create every configured client by name
+func (c *Config) NewClients(options ...func(rpc *FlashXRoute)) (map[string]*FlashXRoute, error) {
+	clients := make(map[string]*FlashXRoute, len(c.Clients))
+	for name := range c.Clients {
+		rpc, err := c.Client(name, options...)
+		if err != nil {
+			return nil, err
+		}
+		clients[name] = rpc
+	}
+
+	return clients, nil
+}
+
+// RelaySet create the clients of the named relay set in order
+func (c *Config) RelaySet(name string, options ...func(rpc *FlashXRoute)) ([]*FlashXRoute, error) {
+	names, ok := c.RelaySets[name]
+	if !ok {
+		return nil, errors.Errorf("unknown relay set %s", name)
+	}
+
+	relays := make([]*FlashXRoute, len(names))
+	for i, client := range names {
+		rpc, err := c.Client(client, options...)
+		if err != nil {
+			return nil, err
+		}
+		relays[i] = rpc
+	}
+
+	return relays, nil
+}
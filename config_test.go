@@ -0,0 +1,118 @@
+package flashxroute
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfig = `
+defaults:
+  timeout: 10s
+  methods:
+    "trace_*": {timeout: 1m}
+clients:
+  bloxroute:
+    url: https://api.blxrbdn.com
+    network: Mainnet
+    auth: {bloxroute: "${TEST_BLOXROUTE_AUTH}"}
+    methods:
+      "blxr_*": {retries: 2, retryDelay: 100ms}
+    builders: [flashbots, titan]
+  node:
+    url: ${TEST_NODE_URL:-http://127.0.0.1:8545}
+    timeout: 2.5
+relaySets:
+  all: [node, bloxroute]
+`
+
+const tomlConfig = `
+# same config as yamlConfig
+[defaults]
+timeout = "10s"
+methods = { "trace_*" = { timeout = "1m" } }
+
+[clients.bloxroute]
+url = "https://api.blxrbdn.com"
+network = "Mainnet"
+auth.bloxroute = "${TEST_BLOXROUTE_AUTH}"
+builders = [
+  "flashbots", # multi-line array
+  "titan",
+]
+
+[clients.bloxroute.methods."blxr_*"]
+retries = 2
+retryDelay = "100ms"
+
+[clients.node]
+url = "${TEST_NODE_URL:-http://127.0.0.1:8545}"
+timeout = 2.5
+
+[relaySets]
+all = ["node", "bloxroute"]
+`
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("TEST_BLOXROUTE_AUTH", "secret")
+
+	for name, content := range map[string]string{"config.yaml": yamlConfig, "config.toml": tomlConfig} {
+		config, err := LoadConfig(writeConfig(t, name, content))
+		require.Nil(t, err, name)
+
+		relays, err := config.RelaySet("all")
+		require.Nil(t, err, name)
+		require.Len(t, relays, 2)
+
+		node, bloxroute := relays[0], relays[1]
+		require.Equal(t, "http://127.0.0.1:8545", node.url, name)
+		require.Equal(t, 2500*time.Millisecond, node.Timeout, name)
+		require.Equal(t, time.Minute, node.timeout("trace_block"), name)
+
+		require.Equal(t, "https://api.blxrbdn.com", bloxroute.url, name)
+		require.Equal(t, NetworkMainnet, bloxroute.network, name)
+		require.Equal(t, "secret", bloxroute.authHeader, name)
+		require.Equal(t, 10*time.Second, bloxroute.Timeout, name)
+		require.Equal(t, MethodConfig{Retries: 2, RetryDelay: 100 * time.Millisecond}, bloxroute.methodConfig("blxr_tx"), name)
+		require.Equal(t, time.Minute, bloxroute.timeout("trace_block"), name)
+		require.Equal(t, []string{"flashbots", "titan"}, bloxroute.policy.Include, name)
+
+		_, err = config.Client("missing")
+		require.ErrorIs(t, err, ErrUnknownClient)
+	}
+}
+
+func TestLoadConfigMissingEnv(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, "config.yml", "clients: {a: {url: '${TEST_UNSET_CONFIG_VARIABLE}'}}"))
+	require.ErrorIs(t, err, ErrMissingEnv)
+
+	_, err = LoadConfig(writeConfig(t, "config.ini", "url = x"))
+	require.NotNil(t, err)
+}
+
+func TestLoadConfigEnvValues(t *testing.T) {
+	t.Setenv("TEST_BLOXROUTE_AUTH", "a\"b\nc: d")
+	for name, content := range map[string]string{
+		"config.yaml": "clients: {a: {url: http://a, auth: {bloxroute: '${TEST_BLOXROUTE_AUTH}'}}}",
+		"config.toml": "[clients.a]\nurl = \"http://a\"\nauth.bloxroute = \"${TEST_BLOXROUTE_AUTH}\"",
+		"config.json": `{"clients": {"a": {"url": "http://a", "auth": {"bloxroute": "${TEST_BLOXROUTE_AUTH}"}}}}`,
+	} {
+		config, err := LoadConfig(writeConfig(t, name, content))
+		require.Nil(t, err, name)
+		client, err := config.Client("a")
+		require.Nil(t, err, name)
+		require.Equal(t, "a\"b\nc: d", client.authHeader, name)
+	}
+
+	_, err := LoadConfig(writeConfig(t, "config.toml", "a = 1\na = 2"))
+	require.NotNil(t, err)
+}
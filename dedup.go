@@ -0,0 +1,157 @@
+package flashxroute
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateBundle - an identical bundle was submitted to the same relay for the same block within the window of
+// WithDeduplication
+var ErrDuplicateBundle = errors.New("duplicate bundle submission")
+
+// BundleIdempotencyKey returns the key bundle is deduplicated by when submitted to relay: sha256 of the relay and
+// the bundle with raw transactions lowercased without 0x prefix and the target block as decimal number, so the
+// bloXroute and eth_sendBundle forms of the same bundle get the same key. Bundles without transactions, i.e.
+// cancellations, get an empty key.
+func BundleIdempotencyKey(relay string, bundle interface{}) (string, error) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+	for _, name := range []string{"transaction", "txs"} {
+		txs, ok := fields[name].([]interface{})
+		if !ok {
+			continue
+		}
+		delete(fields, name)
+		canonical := make([]string, len(txs))
+		for i, tx := range txs {
+			raw, _ := tx.(string)
+			canonical[i] = strings.ToLower(StripHexPrefix(raw))
+		}
+		fields["txs"] = canonical
+	}
+	if txs, _ := fields["txs"].([]string); len(txs) == 0 {
+		return "", nil
+	}
+	for _, name := range []string{"block_number", "blockNumber"} {
+		block, ok := fields[name].(string)
+		if !ok {
+			continue
+		}
+		delete(fields, name)
+		number, err := ParseInt(block)
+		if err != nil {
+			return "", errors.Wrapf(err, "block number %q", block)
+		}
+		fields["blockNumber"] = number
+	}
+
+	canonical, err := json.Marshal(fields) // keys are sorted
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(relay+"\n"), canonical...))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// submission - deduplicated bundle submission, result is nil while it is in flight
+type submission struct {
+	at     time.Time
+	result json.RawMessage
+}
+
+// dedup - bundle submissions by idempotency key within window
+type dedup struct {
+	window time.Duration
+	refuse bool // fail duplicates with ErrDuplicateBundle instead of returning the earlier result
+
+	mu          sync.Mutex
+	submissions map[string]submission
+}
+
+// duplicateKey - context key of the flag set by deduplicated when it suppresses a duplicate
+type duplicateKey struct{}
+
+// contextWithDuplicateFlag returns ctx with a flag telling whether the submission made with it was suppressed as a
+// duplicate, so it isn't journaled and notified as submitted
+func contextWithDuplicateFlag(ctx context.Context) (context.Context, *bool) {
+	duplicate := new(bool)
+	return context.WithValue(ctx, duplicateKey{}, duplicate), duplicate
+}
+
+// deduplicated sends the request of method with body through send, unless it submits a bundle already submitted to
+// the relay within the window. Failed submissions are forgotten, so they can be retried.
+func (rpc *FlashXRoute) deduplicated(ctx context.Context, method string, body []byte, send func() (json.RawMessage, error)) (json.RawMessage, error) {
+	d := rpc.dedup
+	if d == nil || !submissionMethods[method] {
+		return send()
+	}
+
+	request := struct {
+		Params json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	var bundle interface{} = request.Params
+	if params := []json.RawMessage{}; json.Unmarshal(request.Params, &params) == nil {
+		if len(params) == 0 {
+			return send()
+		}
+		bundle = params[0]
+	}
+	key, err := BundleIdempotencyKey(rpc.url, bundle)
+	if err != nil || key == "" {
+		return send()
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	for k, earlier := range d.submissions {
+		if now.Sub(earlier.at) >= d.window {
+			delete(d.submissions, k)
+		}
+	}
+	if earlier, ok := d.submissions[key]; ok {
+		d.mu.Unlock()
+		if duplicate, ok := ctx.Value(duplicateKey{}).(*bool); ok {
+			*duplicate = true
+		}
+		rpc.debugf(SubsystemBundles, LogRequests, "%s: duplicate of bundle submitted %s ago", method, now.Sub(earlier.at))
+		if d.refuse || earlier.result == nil {
+			return nil, errors.Wrapf(ErrDuplicateBundle, "submitted %s ago", now.Sub(earlier.at).Round(time.Millisecond))
+		}
+		return earlier.result, nil
+	}
+	d.submissions[key] = submission{at: now}
+	d.mu.Unlock()
+
+	result, err := send()
+
+	d.mu.Lock()
+	if err != nil {
+		delete(d.submissions, key)
+	} else {
+		if result == nil {
+			result = json.RawMessage("null")
+		}
+		d.submissions[key] = submission{at: now, result: result}
+	}
+	d.mu.Unlock()
+
+	return result, err
+}
@@ -0,0 +1,135 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestDeduplication() {
+	var submitted, failures int32 = 0, 1
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		n := atomic.AddInt32(&submitted, 1)
+		if gjson.GetBytes(body, "params.block_number").String() == "0x13" && atomic.AddInt32(&failures, -1) >= 0 {
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "busy"}}`))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0x%x"}}`, n)))
+	})
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL), WithDeduplication(time.Minute, false))
+	first, err := rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"0xAB"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	again, err := rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Equal(first, again)
+	s.Require().Equal(int32(1), atomic.LoadInt32(&submitted))
+
+	// another block, relay or transaction list is another bundle
+	_, err = rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x11"})
+	s.Require().Nil(err)
+	_, err = rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab", "cd"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Equal(int32(3), atomic.LoadInt32(&submitted))
+
+	// failed submissions and cancellations are sent again
+	_, err = rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x13"})
+	s.Require().NotNil(err)
+	_, err = rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x13"})
+	s.Require().Nil(err)
+	s.Require().Nil(rpc.BloxrouteCancelBundle("", "uuid", "0x10"))
+	s.Require().Nil(rpc.BloxrouteCancelBundle("", "uuid", "0x10"))
+	s.Require().Equal(int32(7), atomic.LoadInt32(&submitted))
+
+	refusing := s.rpc.With(WithURL(server.URL), WithDeduplication(time.Minute, true))
+	_, err = refusing.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	_, err = refusing.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x10"})
+	s.Require().True(errors.Is(err, ErrDuplicateBundle))
+	s.Require().Equal(int32(8), atomic.LoadInt32(&submitted))
+
+	expiring := s.rpc.With(WithURL(server.URL), WithDeduplication(time.Nanosecond, true))
+	for i := 0; i < 2; i++ {
+		_, err = expiring.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x10"})
+		s.Require().Nil(err)
+	}
+	s.Require().Equal(int32(10), atomic.LoadInt32(&submitted))
+}
+
+func TestBundleIdempotencyKey(t *testing.T) {
+	relay := "https://relay.flashbots.net"
+	bloxroute, err := BundleIdempotencyKey(relay, BloxrouteSubmitBundleRequest{Transaction: []string{"ABCD", "ef"}, BlockNumber: "0x10"})
+	require.Nil(t, err)
+	require.Len(t, bloxroute, 64)
+
+	flashbots, err := BundleIdempotencyKey(relay, SendBundleRequest{Txs: []string{"0xabcd", "0xEF"}, BlockNumber: "0x10"})
+	require.Nil(t, err)
+	require.Equal(t, bloxroute, flashbots)
+
+	for _, other := range []interface{}{
+		SendBundleRequest{Txs: []string{"0xef", "0xabcd"}, BlockNumber: "0x10"},
+		SendBundleRequest{Txs: []string{"0xabcd", "0xef"}, BlockNumber: "0x11"},
+		SendBundleRequest{Txs: []string{"0xabcd", "0xef"}, BlockNumber: "0x10", ReplacementUUID: "uuid"},
+	} {
+		key, err := BundleIdempotencyKey(relay, other)
+		require.Nil(t, err)
+		require.NotEqual(t, bloxroute, key)
+	}
+	key, err := BundleIdempotencyKey("https://rpc.beaverbuild.org", SendBundleRequest{Txs: []string{"0xabcd", "0xef"}, BlockNumber: "0x10"})
+	require.Nil(t, err)
+	require.NotEqual(t, bloxroute, key)
+
+	key, err = BundleIdempotencyKey(relay, SendBundleRequest{Txs: []string{}, BlockNumber: "0x10", ReplacementUUID: "uuid"})
+	require.Nil(t, err)
+	require.Empty(t, key)
+
+	_, err = BundleIdempotencyKey(relay, SendBundleRequest{Txs: []string{"0xab"}, BlockNumber: "latest"})
+	require.NotNil(t, err)
+}
+
+func (s *FlashXRouteTestSuite) TestDeduplicationJournal() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	journal, err := OpenFileJournal(filepath.Join(s.T().TempDir(), "journal"))
+	s.Require().Nil(err)
+	var events []BundleEvent
+	notifier := WithNotifier(func(event BundleEvent) { events = append(events, event) })
+	for _, refuse := range []bool{false, true} {
+		events = nil
+		rpc := s.rpc.With(WithURL(server.URL), WithDeduplication(time.Minute, refuse), WithJournal(journal), notifier)
+		for i := 0; i < 2; i++ {
+			_, _ = rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"ab"}, BlockNumber: "0x10"})
+		}
+		s.Require().Len(events, 2)
+		s.Require().Equal(BundleSubmitted, events[0].Kind)
+		s.Require().Equal(BundleDuplicate, events[1].Kind)
+		s.Require().Equal(refuse, events[1].Err != "")
+	}
+
+	entries, err := journal.Entries()
+	s.Require().Nil(err)
+	s.Require().Len(entries, 2)
+
+	builder, err := NewBuilderClient(Builder{Name: "builder", URL: server.URL}, WithDeduplication(time.Minute, false), notifier)
+	s.Require().Nil(err)
+	events = nil
+	for i := 0; i < 2; i++ {
+		res, err := builder.SendBundle(SendBundleRequest{Txs: []string{"0xab"}, BlockNumber: "0x10"})
+		s.Require().Nil(err)
+		s.Require().Equal("0xb", res.BundleHash)
+	}
+	s.Require().Equal([]BundleEventKind{BundleSubmitted, BundleDuplicate}, []BundleEventKind{events[0].Kind, events[1].Kind})
+	s.Require().Equal("0xb", events[1].BundleHash)
+}
@@ -0,0 +1,179 @@
+package flashxroute
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// How a RaceConflict was found
+const (
+	ConflictNonce = "nonce" // same sender and nonce as a transaction of the bundle
+	ConflictTrace = "trace" // a call frame of the transaction reached a contract of the bundle
+	ConflictTo    = "to"    // the transaction is sent to a contract of the bundle, used when the block can't be traced
+)
+
+// RaceConflict - transaction of the landed block competing with a missed bundle
+type RaceConflict struct {
+	Transaction Transaction
+	Reason      string   // ConflictNonce, ConflictTrace or ConflictTo
+	Contracts   []string // contracts of the bundle touched by the transaction, sorted
+}
+
+// RaceDiagnosis - why a bundle missed its block, see DiagnoseMissedBundle
+type RaceDiagnosis struct {
+	Block     *Block
+	Traced    bool     // false if trace_block failed and conflicts were found by heuristics
+	Contracts []string // contracts the bundle touches, sorted
+	Conflicts []RaceConflict
+
+	// the winning bundle is estimated as the consecutive conflicting transactions starting at the first conflict
+	Winner               []Transaction
+	WinnerGasUsed        int
+	WinnerPayment        big.Int // priority fees and direct coinbase transfers in wei
+	WinnerEffectivePrice big.Int // WinnerPayment per gas in wei, compare it with the effective price of the bundle
+}
+
+// DiagnoseMissedBundle fetches blockNumber, the block a bundle of txs targeted but didn't land in, and finds the
+// transactions which competed with it. The contracts of the bundle are read from its access list at the parent
+// block and the to addresses of txs. With trace_block every call frame of the block is compared to them, without
+// it only the to address of block transactions. Direct coinbase transfers of the winner are only counted when the
+// block could be traced.
+func (rpc *FlashXRoute) DiagnoseMissedBundle(txs []T, blockNumber int) (*RaceDiagnosis, error) {
+	block, err := rpc.EthGetBlockByNumber(blockNumber, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "block %d", blockNumber)
+	}
+	if block == nil {
+		return nil, errors.Errorf("block %d not found", blockNumber)
+	}
+
+	contracts := map[string]bool{}
+	for _, tx := range txs {
+		if tx.To != "" {
+			contracts[strings.ToLower(tx.To)] = true
+		}
+	}
+	if access, err := rpc.BundleAccessList(txs, IntToHex(blockNumber-1)); err == nil {
+		for _, tuple := range access.Union {
+			contracts[tuple.Address] = true
+		}
+	} else {
+		rpc.debugf(SubsystemBundles, LogErrors, "access list of missed bundle: %v", err)
+	}
+
+	diagnosis := &RaceDiagnosis{Block: block, Contracts: make([]string, 0, len(contracts))}
+	for contract := range contracts {
+		diagnosis.Contracts = append(diagnosis.Contracts, contract)
+	}
+	sort.Strings(diagnosis.Contracts)
+
+	touched := map[string]map[string]bool{} // contracts of the bundle by transaction hash
+	touch := func(hash, address string) {
+		address = strings.ToLower(address)
+		if !contracts[address] {
+			return
+		}
+		if touched[hash] == nil {
+			touched[hash] = map[string]bool{}
+		}
+		touched[hash][address] = true
+	}
+
+	traces, err := rpc.TraceBlock(blockNumber)
+	diagnosis.Traced = err == nil
+	if diagnosis.Traced {
+		for _, trace := range traces {
+			if trace.TransactionHash == "" {
+				continue // block reward
+			}
+			touch(trace.TransactionHash, trace.Action.To)
+			touch(trace.TransactionHash, trace.Action.Address)
+			if trace.Result != nil {
+				touch(trace.TransactionHash, trace.Result.Address)
+			}
+		}
+	} else {
+		rpc.debugf(SubsystemBundles, LogErrors, "trace of block %d: %v", blockNumber, err)
+		for _, tx := range block.Transactions {
+			touch(tx.Hash, tx.To)
+		}
+	}
+
+	first := -1
+	for i, tx := range block.Transactions {
+		conflict := RaceConflict{Transaction: tx}
+		for _, leg := range txs {
+			if leg.From != "" && leg.Nonce > 0 && strings.EqualFold(leg.From, tx.From) && leg.Nonce == tx.Nonce {
+				conflict.Reason = ConflictNonce
+			}
+		}
+		for contract := range touched[tx.Hash] {
+			conflict.Contracts = append(conflict.Contracts, contract)
+		}
+		sort.Strings(conflict.Contracts)
+		switch {
+		case conflict.Reason != "":
+		case len(conflict.Contracts) == 0:
+			continue
+		case diagnosis.Traced:
+			conflict.Reason = ConflictTrace
+		default:
+			conflict.Reason = ConflictTo
+		}
+
+		if first < 0 {
+			first = i
+		}
+		if first+len(diagnosis.Winner) == i {
+			diagnosis.Winner = append(diagnosis.Winner, tx)
+		}
+		diagnosis.Conflicts = append(diagnosis.Conflicts, conflict)
+	}
+	if len(diagnosis.Winner) == 0 {
+		return diagnosis, nil
+	}
+
+	return diagnosis, rpc.priceWinner(diagnosis, traces)
+}
+
+// priceWinner sums the priority fees and coinbase transfers of the winning transactions of diagnosis
+func (rpc *FlashXRoute) priceWinner(diagnosis *RaceDiagnosis, traces []Trace) error {
+	hashes := make([]string, len(diagnosis.Winner))
+	winner := map[string]bool{}
+	for i, tx := range diagnosis.Winner {
+		hashes[i] = tx.Hash
+		winner[tx.Hash] = true
+	}
+	receipts, err := rpc.GetTransactionReceipts(hashes, len(hashes))
+	if err != nil {
+		return errors.Wrap(err, "receipts of winning transactions")
+	}
+
+	payment := new(big.Int)
+	for i, tx := range diagnosis.Winner {
+		if receipts[i] == nil {
+			return errors.Errorf("no receipt of winning transaction %s", tx.Hash)
+		}
+		// gasPrice of mined transactions is their effective price, for EIP-1559 ones too
+		tip := new(big.Int).Sub(&tx.GasPrice, &diagnosis.Block.BaseFeePerGas)
+		if tip.Sign() > 0 {
+			payment.Add(payment, tip.Mul(tip, big.NewInt(int64(receipts[i].GasUsed))))
+		}
+		diagnosis.WinnerGasUsed += receipts[i].GasUsed
+	}
+	for _, transfer := range CoinbaseTransfers(traces, diagnosis.Block.Miner) {
+		if winner[transfer.TransactionHash] {
+			payment.Add(payment, &transfer.Value)
+		}
+	}
+
+	diagnosis.WinnerPayment.Set(payment)
+	if diagnosis.WinnerGasUsed > 0 {
+		diagnosis.WinnerEffectivePrice.Div(payment, big.NewInt(int64(diagnosis.WinnerGasUsed)))
+	}
+
+	return nil
+}
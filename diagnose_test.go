@@ -0,0 +1,88 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (s *FlashXRouteTestSuite) TestDiagnoseMissedBundle() {
+	me := "0x00000000000000000000000000000000000000aa"
+	router := "0x7a250d5630b4cf539739df2c5dacb4c659f2488d"
+	pool := "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc"
+	other := "0x00000000000000000000000000000000000000bb"
+	coinbase := "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"
+	hash := func(i int) string { return fmt.Sprintf("0x%064x", i) }
+	tx := func(i, nonce int, from, to, gasPrice string) string {
+		return fmt.Sprintf(`{"hash": "%s", "nonce": "0x%x", "blockHash": "%s", "blockNumber": "0x64", "transactionIndex": "0x%x",
+			"from": "%s", "to": "%s", "value": "0x0", "gas": "0x30d40", "gasPrice": "%s", "input": "0x"}`,
+			hash(i), nonce, hash(100), i, from, to, gasPrice)
+	}
+	receipt := func(i int, gasUsed string) string {
+		return fmt.Sprintf(`{"transactionHash": "%s", "blockHash": "%s", "blockNumber": "0x64", "transactionIndex": "0x%x",
+			"gasUsed": "%s", "status": "0x1", "logs": []}`, hash(i), hash(100), i, gasUsed)
+	}
+	call := func(i int, from, to, value string, traceAddress string) string {
+		return fmt.Sprintf(`{"type": "call", "action": {"callType": "call", "from": "%s", "to": "%s", "gas": "0x0", "input": "0x",
+			"value": "%s"}, "result": {"gasUsed": "0x0", "output": "0x"}, "subtraces": 0, "traceAddress": %s,
+			"blockHash": "%s", "blockNumber": 100, "transactionHash": "%s", "transactionPosition": %d}`,
+			from, to, value, traceAddress, hash(100), hash(i), i)
+	}
+	methods := map[string]string{
+		`eth_getBlockByNumber ["0x64",true]`: `{"number": "0x64", "hash": "` + hash(100) + `", "miner": "` + coinbase + `",
+			"baseFeePerGas": "0x2540be400", "gasLimit": "0x1c9c380", "gasUsed": "0x0", "timestamp": "0x0", "transactions": [` +
+			tx(0, 1, other, other, "0x2540be400") + `,` + // unrelated
+			tx(1, 7, other, router, "0x2cb417800") + `,` + // front leg swapping through the router, 12 gwei
+			tx(2, 8, other, other, "0x2540be400") + `,` + // back leg paying coinbase through a contract
+			tx(3, 2, other, other, "0x2540be400") + `,` + // unrelated
+			tx(4, 5, me, other, "0x2540be400") + `]}`, // replaced leg of the bundle
+		`eth_createAccessList [{"data":"0x01","from":"` + me + `","nonce":"0x5","to":"` + router + `"},"0x63"]`: `{"accessList": [
+			{"address": "` + pool + `", "storageKeys": ["` + hash(8) + `"]}], "gasUsed": "0x1d4c0"}`,
+		`trace_block ["0x64"]`: `[` +
+			call(0, other, other, "0x0", "[]") + `,` +
+			call(1, other, router, "0x0", "[]") + `,` + call(1, router, pool, "0x0", "[0]") + `,` +
+			call(2, other, other, "0x0", "[]") + `,` + call(2, other, pool, "0x0", "[0]") + `,` +
+			call(2, other, coinbase, "0x2386f26fc10000", "[1]") + `,` + // 0.01 ETH
+			call(3, other, other, "0x0", "[]") + `,` +
+			call(4, me, other, "0x0", "[]") + `]`,
+		`eth_getTransactionReceipt ["` + hash(1) + `"]`: receipt(1, "0x186a0"),
+		`eth_getTransactionReceipt ["` + hash(2) + `"]`: receipt(2, "0xc350"),
+	}
+	s.registerMethods(methods)
+
+	bundle := []T{{From: me, To: router, Data: "0x01", Nonce: 5}}
+	diagnosis, err := s.rpc.DiagnoseMissedBundle(bundle, 100)
+	s.Require().Nil(err)
+	s.Require().True(diagnosis.Traced)
+	s.Require().Equal([]string{router, pool}, diagnosis.Contracts)
+	s.Require().Len(diagnosis.Conflicts, 3)
+	s.Require().Equal(RaceConflict{Transaction: diagnosis.Block.Transactions[1], Reason: ConflictTrace, Contracts: []string{router, pool}}, diagnosis.Conflicts[0])
+	s.Require().Equal([]string{pool}, diagnosis.Conflicts[1].Contracts)
+	s.Require().Equal(ConflictNonce, diagnosis.Conflicts[2].Reason)
+	s.Require().Equal(diagnosis.Block.Transactions[1:3], diagnosis.Winner)
+	s.Require().Equal(150000, diagnosis.WinnerGasUsed)
+	// 2 gwei tip of 100000 gas and 0.01 ETH to coinbase over 150000 gas
+	s.Require().Equal("10200000000000000", diagnosis.WinnerPayment.String())
+	s.Require().Equal("68000000000", diagnosis.WinnerEffectivePrice.String())
+
+	// without traces only transactions sent to the contracts of the bundle conflict and coinbase transfers are missed
+	methods[`trace_block ["0x64"]`] = `error:{"code": -32601, "message": "the method trace_block does not exist"}`
+	s.registerMethods(methods)
+	diagnosis, err = s.rpc.DiagnoseMissedBundle(bundle, 100)
+	s.Require().Nil(err)
+	s.Require().False(diagnosis.Traced)
+	s.Require().Len(diagnosis.Conflicts, 2)
+	s.Require().Equal(ConflictTo, diagnosis.Conflicts[0].Reason)
+	s.Require().Equal([]Transaction{diagnosis.Block.Transactions[1]}, diagnosis.Winner)
+	s.Require().Equal("200000000000000", diagnosis.WinnerPayment.String())
+	s.Require().Equal("2000000000", diagnosis.WinnerEffectivePrice.String())
+
+	// legs without a nonce don't conflict with transactions of their sender by nonce
+	block := `eth_getBlockByNumber ["0x64",true]`
+	methods[block] = strings.Replace(methods[block], tx(4, 5, me, other, "0x2540be400"), tx(4, 0, me, other, "0x2540be400"), 1)
+	methods["eth_createAccessList"] = `error:{"code": -32000, "message": "nonce too low"}`
+	s.registerMethods(methods)
+	diagnosis, err = s.rpc.DiagnoseMissedBundle([]T{{From: me, To: router, Data: "0x01"}}, 100)
+	s.Require().Nil(err)
+	s.Require().Len(diagnosis.Conflicts, 1)
+	s.Require().Equal(ConflictTo, diagnosis.Conflicts[0].Reason)
+}
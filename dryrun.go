@@ -0,0 +1,166 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DryRunRequest - state-changing request recorded instead of sent in dry-run mode
+type DryRunRequest struct {
+	Method string
+	Body   []byte          // json-rpc request body which would have been sent
+	Result json.RawMessage // synthetic result returned to the caller
+	Time   time.Time
+}
+
+type dryRunRecorder struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// dryRunResults build synthetic results of submissionMethods from the raw params, the others result in null
+var dryRunResults = map[string]func(params json.RawMessage) (interface{}, error){
+	"eth_sendRawTransaction":        rawTxHashResult(firstParam),
+	"eth_sendPrivateRawTransaction": rawTxHashResult(firstParam),
+	"eth_sendTransaction":           bodyHashResult,
+	"personal_sendTransaction":      bodyHashResult,
+	"blxr_tx":                       rawTxHashResult(transactionField),
+	"blxr_private_tx":               rawTxHashResult(transactionField),
+	"blxr_submit_bundle":            bundleHashResult,
+	"submit_arb_only_bundle":        bundleHashResult,
+	"eth_sendBundle":                sendBundleHashResult,
+	"mev_sendBundle":                sendBundleHashResult,
+}
+
+func firstParam(params json.RawMessage) (string, error) {
+	values := []string{}
+	if err := json.Unmarshal(params, &values); err != nil || len(values) == 0 {
+		return "", err
+	}
+
+	return values[0], nil
+}
+
+func transactionField(params json.RawMessage) (string, error) {
+	value := struct {
+		Transaction string `json:"transaction"`
+	}{}
+	err := json.Unmarshal(params, &value)
+	return value.Transaction, err
+}
+
+// rawTxHashResult returns the hash the transaction would get, keccak256 of its binary encoding
+func rawTxHashResult(rawTx func(params json.RawMessage) (string, error)) func(params json.RawMessage) (interface{}, error) {
+	return func(params json.RawMessage) (interface{}, error) {
+		raw, err := rawTx(params)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ParseBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		return Keccak256(data), nil
+	}
+}
+
+func nullResult(json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
+
+func bodyHashResult(params json.RawMessage) (interface{}, error) {
+	return Keccak256(params), nil
+}
+
+// bundleHashResult returns keccak256 of the concatenated transaction hashes, the way relays hash bundles
+func bundleHashResult(params json.RawMessage) (interface{}, error) {
+	value := struct {
+		Transaction []string `json:"transaction"`
+	}{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		return nil, err
+	}
+	hash, err := bundleHash(value.Transaction)
+
+	return BloxrouteSubmitBundleResponse{BundleHash: hash}, err
+}
+
+// bundleHash returns keccak256 of the concatenated hashes of raw transactions
+func bundleHash(txs []string) (string, error) {
+	hashes := []byte{}
+	for _, raw := range txs {
+		data, err := ParseBytes(raw)
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, crypto.Keccak256(data)...)
+	}
+
+	return Keccak256(hashes), nil
+}
+
+// sendBundleHashResult returns the bundle hash of eth_sendBundle params, keccak256 of the concatenated transaction
+// hashes, or of the params for bundles of another shape like mev_sendBundle
+func sendBundleHashResult(params json.RawMessage) (interface{}, error) {
+	values := []struct {
+		Txs []string `json:"txs"`
+	}{}
+	if err := json.Unmarshal(params, &values); err != nil || len(values) == 0 || len(values[0].Txs) == 0 {
+		return SendBundleResponse{BundleHash: Keccak256(params)}, nil
+	}
+	hash, err := bundleHash(values[0].Txs)
+
+	return SendBundleResponse{BundleHash: hash}, err
+}
+
+// dryRun records state-changing requests and returns their synthetic result, ok is false for requests which
+// should be sent
+func (rpc *FlashXRoute) dryRun(method string, body []byte) (result json.RawMessage, ok bool, err error) {
+	if rpc.recorder == nil {
+		return nil, false, nil
+	}
+	if !submissionMethods[method] {
+		return nil, false, nil
+	}
+	build, ok := dryRunResults[method]
+	if !ok {
+		build = nullResult
+	}
+
+	request := struct {
+		Params json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, true, err
+	}
+	value, err := build(request.Params)
+	if err != nil {
+		return nil, true, err
+	}
+	if result, err = json.Marshal(value); err != nil {
+		return nil, true, err
+	}
+
+	rpc.recorder.mu.Lock()
+	rpc.recorder.requests = append(rpc.recorder.requests, DryRunRequest{Method: method, Body: body, Result: result, Time: time.Now()})
+	rpc.recorder.mu.Unlock()
+
+	rpc.debugf(SubsystemBundles, LogBodies, "dry run %s %s", method, body)
+
+	return result, true, nil
+}
+
+// DryRunRequests returns state-changing requests recorded in dry-run mode, clients derived with Clone or With share them
+func (rpc *FlashXRoute) DryRunRequests() []DryRunRequest {
+	if rpc.recorder == nil {
+		return nil
+	}
+
+	rpc.recorder.mu.Lock()
+	defer rpc.recorder.mu.Unlock()
+	return append([]DryRunRequest(nil), rpc.recorder.requests...)
+}
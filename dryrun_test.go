@@ -0,0 +1,107 @@
+package flashxroute
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestDryRun() {
+	rpc := s.rpc.With(WithDryRun(true))
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.methodEqual(body, "eth_blockNumber")
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x10"}`), nil
+	})
+
+	// reads still reach the node
+	number, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(16, number)
+
+	raw := "0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675"
+	data, _ := ParseBytes(raw)
+	txHash, err := rpc.EthSendRawTransaction(raw)
+	s.Require().Nil(err)
+	s.Require().Equal(Keccak256(data), txHash)
+
+	bundle, err := rpc.BloxrouteSubmitBundle("auth", BloxrouteSubmitBundleRequest{Transaction: []string{raw[2:]}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Equal(Keccak256(mustParseBytes(Keccak256(data))), bundle.BundleHash)
+
+	requests := rpc.DryRunRequests()
+	s.Require().Len(requests, 2)
+	s.Require().Equal("eth_sendRawTransaction", requests[0].Method)
+	s.Require().Equal("blxr_submit_bundle", requests[1].Method)
+	s.Require().Nil(s.rpc.DryRunRequests())
+
+	// signed bodies are only logged at LogBodies
+	log := new(bufferLogger)
+	_, err = rpc.With(WithLogger(log)).EthSendRawTransaction(raw)
+	s.Require().Nil(err)
+	s.Require().Empty(log.lines)
+	_, err = rpc.With(WithLogger(log), WithLogLevel(SubsystemBundles, LogBodies)).EthSendRawTransaction(raw)
+	s.Require().Nil(err)
+	s.Require().Len(log.lines, 1)
+	s.Require().Contains(log.lines[0], "dry run eth_sendRawTransaction")
+}
+
+func mustParseBytes(value string) []byte {
+	data, err := ParseBytes(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+func (s *FlashXRouteTestSuite) TestDryRunBuilderSubmissions() {
+	hedgeURL := "http://127.0.0.1:8546"
+	builderURL := "http://127.0.0.1:8547"
+	httpmock.Reset()
+	calls := 0
+	for _, url := range []string{hedgeURL, builderURL} {
+		httpmock.RegisterResponder("POST", url, func(request *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`), nil
+		})
+	}
+
+	options := []func(rpc *FlashXRoute){WithDryRun(true), WithHedging(hedgeURL, 0), WithHttpClient(http.DefaultClient)}
+	builder, err := NewBuilderClient(Builder{Name: "titan", URL: builderURL}, options...)
+	s.Require().Nil(err)
+	raw := "0x02f8"
+	res, err := builder.SendBundle(SendBundleRequest{Txs: []string{raw}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Equal(Keccak256(mustParseBytes(Keccak256(mustParseBytes(raw)))), res.BundleHash)
+	s.Require().Nil(builder.CancelBundle("u1"))
+	txHash, err := builder.SendPrivateRawTransaction(raw)
+	s.Require().Nil(err)
+	s.Require().Equal(Keccak256(mustParseBytes(raw)), txHash)
+
+	key, _ := crypto.GenerateKey()
+	signed, err := NewBuilderClient(Builder{Name: "flashbots", URL: builderURL}, append(options, WithSigner(NewPrivateKeySigner(key)))...)
+	s.Require().Nil(err)
+	_, err = signed.SendBundle(SendBundleRequest{Txs: []string{raw}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Nil(signed.CancelBundle("u1"))
+	_, err = signed.SendPrivateRawTransaction(raw)
+	s.Require().Nil(err)
+
+	s.Require().Equal(0, calls)
+	s.Require().Len(builder.DryRunRequests(), 3)
+	s.Require().Equal("eth_sendBundle", builder.DryRunRequests()[0].Method)
+	s.Require().Equal("eth_cancelBundle", builder.DryRunRequests()[1].Method)
+	s.Require().Equal("eth_sendPrivateRawTransaction", builder.DryRunRequests()[2].Method)
+	s.Require().Len(signed.DryRunRequests(), 3)
+
+	// without dry run the submission goes to the builder only, never hedged
+	live, err := NewBuilderClient(Builder{Name: "titan", URL: builderURL}, options[1:]...)
+	s.Require().Nil(err)
+	_, err = live.SendBundle(SendBundleRequest{Txs: []string{raw}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	live.SendPrivateRawTransaction(raw)
+	s.Require().Equal(2, calls)
+	s.Require().Equal(2, httpmock.GetCallCountInfo()["POST "+builderURL])
+}
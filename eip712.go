@@ -0,0 +1,393 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTypedData is returned for typed data not matching its types
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// TypedDataField - member of an EIP-712 struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData - EIP-712 typed data as accepted by eth_signTypedData_v4. Values of Domain and Message are strings,
+// numbers (json.Number, float64, big.Int, sized integers), bools, []byte, nested maps and slices. EIP712Domain may be
+// left out of Types, it is derived from the Domain keys then.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// domainFields - EIP712Domain members in the order of the standard
+var domainFields = []TypedDataField{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// withDomainType returns the types with EIP712Domain derived from the domain keys when missing
+func (data TypedData) withDomainType() map[string][]TypedDataField {
+	if _, ok := data.Types["EIP712Domain"]; ok {
+		return data.Types
+	}
+
+	types := make(map[string][]TypedDataField, len(data.Types)+1)
+	for name, fields := range data.Types {
+		types[name] = fields
+	}
+	var fields []TypedDataField
+	for _, field := range domainFields {
+		if _, ok := data.Domain[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+	types["EIP712Domain"] = fields
+
+	return types
+}
+
+// MarshalJSON encodes typed data with EIP712Domain in types, as nodes and wallets expect it
+func (data TypedData) MarshalJSON() ([]byte, error) {
+	type plain TypedData
+	data.Types = data.withDomainType()
+	return json.Marshal(plain(data))
+}
+
+// DomainSeparator returns hashStruct of the domain
+func (data TypedData) DomainSeparator() ([]byte, error) {
+	return typedDataEncoder(data.withDomainType()).hashStruct("EIP712Domain", data.Domain)
+}
+
+// HashStruct returns hashStruct of the message
+func (data TypedData) HashStruct() ([]byte, error) {
+	if _, ok := data.Types[data.PrimaryType]; !ok {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "unknown primary type %q", data.PrimaryType)
+	}
+
+	return typedDataEncoder(data.Types).hashStruct(data.PrimaryType, data.Message)
+}
+
+// SigningPayload returns "\x19\x01" ‖ domainSeparator ‖ hashStruct(message), the data hashed for signing
+func (data TypedData) SigningPayload() ([]byte, error) {
+	domain, err := data.DomainSeparator()
+	if err != nil {
+		return nil, errors.Wrap(err, "domain")
+	}
+	message, err := data.HashStruct()
+	if err != nil {
+		return nil, errors.Wrap(err, "message")
+	}
+
+	return append(append([]byte{0x19, 0x01}, domain...), message...), nil
+}
+
+// HashTypedData returns the EIP-712 hash of data signed by eth_signTypedData_v4
+func HashTypedData(data TypedData) ([]byte, error) {
+	payload, err := data.SigningPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(payload), nil
+}
+
+// typedDataEncoder - EIP-712 encoding of values of the struct types
+type typedDataEncoder map[string][]TypedDataField
+
+// dependencies collects the struct types referenced by typeName, including itself
+func (types typedDataEncoder) dependencies(typeName string, found map[string]bool) {
+	typeName = strings.SplitN(typeName, "[", 2)[0]
+	if _, ok := types[typeName]; !ok || found[typeName] {
+		return
+	}
+	found[typeName] = true
+	for _, field := range types[typeName] {
+		types.dependencies(field.Type, found)
+	}
+}
+
+// encodeType returns the type string like "Mail(Person from,Person to,string contents)Person(string name,address wallet)"
+func (types typedDataEncoder) encodeType(primaryType string) string {
+	found := map[string]bool{}
+	types.dependencies(primaryType, found)
+	delete(found, primaryType)
+	deps := make([]string, 0, len(found))
+	for dep := range found {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+
+	var result strings.Builder
+	for _, typeName := range append([]string{primaryType}, deps...) {
+		members := make([]string, len(types[typeName]))
+		for i, field := range types[typeName] {
+			members[i] = field.Type + " " + field.Name
+		}
+		result.WriteString(typeName + "(" + strings.Join(members, ",") + ")")
+	}
+
+	return result.String()
+}
+
+// hashStruct returns keccak256(typeHash ‖ encodeData(value))
+func (types typedDataEncoder) hashStruct(typeName string, value interface{}) ([]byte, error) {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidTypedData, "%s value is %T, expected object", typeName, value)
+	}
+
+	encoded := crypto.Keccak256([]byte(types.encodeType(typeName)))
+	for _, field := range types[typeName] {
+		fieldValue, ok := values[field.Name]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "%s.%s missing", typeName, field.Name)
+		}
+		word, err := types.encodeValue(field.Type, fieldValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.%s", typeName, field.Name)
+		}
+		encoded = append(encoded, word...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeValue returns the 32 bytes encoding of value of the type
+func (types typedDataEncoder) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typeName, "]") {
+		elementType := typeName[:strings.LastIndexByte(typeName, '[')]
+		elements := reflect.ValueOf(value)
+		if elements.Kind() != reflect.Slice && elements.Kind() != reflect.Array {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "%s value is %T", typeName, value)
+		}
+		if size := typeName[len(elementType)+1 : len(typeName)-1]; size != "" && size != strconv.Itoa(elements.Len()) {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "%s value has %d elements", typeName, elements.Len())
+		}
+		var encoded []byte
+		for i := 0; i < elements.Len(); i++ {
+			word, err := types.encodeValue(elementType, elements.Index(i).Interface())
+			if err != nil {
+				return nil, errors.Wrapf(err, "element %d", i)
+			}
+			encoded = append(encoded, word...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+	if _, ok := types[typeName]; ok {
+		return types.hashStruct(typeName, value)
+	}
+
+	word := make([]byte, 32)
+	switch {
+	case typeName == "string":
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "string value is %T", value)
+		}
+		return crypto.Keccak256([]byte(text)), nil
+
+	case typeName == "bytes":
+		data, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(data), nil
+
+	case typeName == "bool":
+		switch v := value.(type) {
+		case bool:
+			if v {
+				word[31] = 1
+			}
+		case string:
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.Wrapf(ErrInvalidTypedData, "bool value %q", v)
+			}
+			if enabled {
+				word[31] = 1
+			}
+		default:
+			return nil, errors.Wrapf(ErrInvalidTypedData, "bool value is %T", value)
+		}
+		return word, nil
+
+	case typeName == "address":
+		if address := reflect.ValueOf(value); address.Kind() == reflect.Array && address.Len() == 20 && address.Type().Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(reflect.ValueOf(word[12:]), address)
+			return word, nil
+		}
+		text, ok := value.(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "address value is %T", value)
+		}
+		if err := ValidateAddress(text); err != nil {
+			return nil, err
+		}
+		data, _ := ParseBytes(text)
+		copy(word[12:], data)
+		return word, nil
+
+	case strings.HasPrefix(typeName, "bytes"):
+		size, err := strconv.Atoi(typeName[5:])
+		if err != nil || size < 1 || size > 32 {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "unknown type %s", typeName)
+		}
+		data, err := typedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > size {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "%d bytes don't fit %s", len(data), typeName)
+		}
+		copy(word, data)
+		return word, nil
+
+	case strings.HasPrefix(typeName, "uint") || strings.HasPrefix(typeName, "int"):
+		number, err := typedDataNumber(value)
+		if err != nil {
+			return nil, err
+		}
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typeName, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "unknown type %s", typeName)
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		min, max := new(big.Int), new(big.Int).Sub(limit, big.NewInt(1))
+		if strings.HasPrefix(typeName, "int") {
+			min.Neg(limit).Rsh(min, 1)
+			max.Rsh(limit, 1).Sub(max, big.NewInt(1))
+		}
+		if number.Cmp(min) < 0 || number.Cmp(max) > 0 {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "%s overflows %s", number, typeName)
+		}
+		if number.Sign() < 0 {
+			number.Add(number, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return number.FillBytes(word), nil
+	}
+
+	return nil, errors.Wrapf(ErrInvalidTypedData, "unknown type %s", typeName)
+}
+
+// typedDataBytes reads hex string or []byte
+func typedDataBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return ParseBytes(v)
+	}
+
+	return nil, errors.Wrapf(ErrInvalidTypedData, "bytes value is %T", value)
+}
+
+// typedDataNumber reads decimal or 0x prefixed hex string, json.Number, float64 without fraction, big.Int and
+// sized integers
+func typedDataNumber(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case string:
+		if hex := strings.TrimPrefix(v, "-"); strings.HasPrefix(hex, "0x") {
+			number, err := ParseBigInt(hex)
+			if len(hex) < len(v) {
+				number.Neg(&number)
+			}
+			return &number, err
+		}
+		number, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "number value %q", v)
+		}
+		return number, nil
+	case json.Number:
+		return typedDataNumber(v.String())
+	case float64:
+		number, accuracy := new(big.Float).SetFloat64(v).Int(nil)
+		if accuracy != big.Exact {
+			return nil, errors.Wrapf(ErrInvalidTypedData, "number value %v is not an integer", v)
+		}
+		return number, nil
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return &v, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+
+	return nil, errors.Wrapf(ErrInvalidTypedData, "number value is %T", value)
+}
+
+// TypedDataSigner - signer of EIP-712 typed data, implemented by PrivateKeySigner and WalletSigner
+type TypedDataSigner interface {
+	SignTypedData(data TypedData) ([]byte, error)
+}
+
+// SignTypedData signs the EIP-712 hash of data, V is 27 or 28 like eth_signTypedData_v4 returns it
+func (s *PrivateKeySigner) SignTypedData(data TypedData) ([]byte, error) {
+	hash, err := HashTypedData(data)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.SignatureLength-1] += 27
+
+	return signature, nil
+}
+
+// SignTypedData asks the wallet to sign data, not every hardware wallet driver supports it
+func (s *WalletSigner) SignTypedData(data TypedData) ([]byte, error) {
+	payload, err := data.SigningPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wallet.SignData(s.account, accounts.MimetypeTypedData, payload)
+}
+
+// SignTypedData signs data locally with the configured signer
+func (rpc *FlashXRoute) SignTypedData(data TypedData) (string, error) {
+	signer, ok := rpc.signer.(TypedDataSigner)
+	if !ok {
+		return "", fmt.Errorf("%w: signer can't sign typed data", ErrNoSigner)
+	}
+	signature, err := signer.SignTypedData(data)
+	if err != nil {
+		return "", err
+	}
+
+	return BytesToHex(signature), nil
+}
+
+// EthSignTypedDataV4 asks the node to sign data with the key of address, returns 0x prefixed signature
+func (rpc *FlashXRoute) EthSignTypedDataV4(address string, data TypedData) (string, error) {
+	var signature string
+	err := rpc.call("eth_signTypedData_v4", &signature, address, data)
+	return signature, err
+}
@@ -0,0 +1,107 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+		"Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+	},
+	"primaryType": "Mail",
+	"domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestHashTypedData(t *testing.T) {
+	var data TypedData
+	require.Nil(t, json.Unmarshal([]byte(mailTypedData), &data))
+
+	require.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", typedDataEncoder(data.Types).encodeType("Mail"))
+
+	domain, err := data.DomainSeparator()
+	require.Nil(t, err)
+	require.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", BytesToHex(domain))
+
+	message, err := data.HashStruct()
+	require.Nil(t, err)
+	require.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", BytesToHex(message))
+
+	hash, err := HashTypedData(data)
+	require.Nil(t, err)
+	require.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", BytesToHex(hash))
+
+	// EIP712Domain derived from the domain keys
+	delete(data.Types, "EIP712Domain")
+	derived, err := HashTypedData(data)
+	require.Nil(t, err)
+	require.Equal(t, hash, derived)
+
+	data.Message["contents"] = 5
+	_, err = HashTypedData(data)
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+}
+
+func TestTypedDataNumbers(t *testing.T) {
+	encoder := typedDataEncoder{}
+	for _, value := range []interface{}{"255", "0xff", json.Number("255"), float64(255), uint8(255)} {
+		word, err := encoder.encodeValue("uint8", value)
+		require.Nil(t, err)
+		require.Equal(t, byte(255), word[31])
+	}
+
+	word, err := encoder.encodeValue("int8", -128)
+	require.Nil(t, err)
+	require.Equal(t, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", BytesToHex(word))
+	word, err = encoder.encodeValue("int8", "-0x80")
+	require.Nil(t, err)
+	require.Equal(t, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", BytesToHex(word))
+
+	_, err = encoder.encodeValue("int8", 128)
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+	_, err = encoder.encodeValue("uint256", -1)
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+	_, err = encoder.encodeValue("uint256", 1.5)
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+}
+
+func (s *FlashXRouteTestSuite) TestSignTypedData() {
+	var data TypedData
+	s.Require().Nil(json.Unmarshal([]byte(mailTypedData), &data))
+	delete(data.Types, "EIP712Domain")
+
+	s.registerResponse(`"0x4355c47d"`, func(body []byte) {
+		s.methodEqual(body, "eth_signTypedData_v4")
+		s.Require().Equal("0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826", gjson.GetBytes(body, "params.0").String())
+		s.Require().Equal("verifyingContract", gjson.GetBytes(body, "params.1.types.EIP712Domain.3.name").String())
+		s.Require().Equal("Hello, Bob!", gjson.GetBytes(body, "params.1.message.contents").String())
+	})
+	signature, err := s.rpc.EthSignTypedDataV4("0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826", data)
+	s.Require().Nil(err)
+	s.Require().Equal("0x4355c47d", signature)
+
+	_, err = s.rpc.SignTypedData(data)
+	s.Require().ErrorIs(err, ErrNoSigner)
+
+	key, _ := crypto.GenerateKey()
+	rpc := New(s.rpc.url, WithSigner(NewPrivateKeySigner(key)))
+	signature, err = rpc.SignTypedData(data)
+	s.Require().Nil(err)
+	s.Require().Len(signature, 132)
+}
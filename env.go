@@ -0,0 +1,107 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvPrefix - prefix of the environment variables read by NewFromEnv
+const EnvPrefix = "FLASHXROUTE_"
+
+// ConfigFromEnv reads client config from environment variables named prefix followed by
+//
+//	URL, IPC                       endpoint, IPC wins when both are set
+//	NETWORK                        bloXroute network name
+//	AUTH                           bloXroute Authorization header, or ACCOUNT_ID with SECRET_HASH
+//	BEARER                         bearer token
+//	HEADERS                        additional headers as Name=value pairs separated by commas
+//	TIMEOUT                        duration like 5s or number of seconds
+//	BUILDERS, EXCLUDE_BUILDERS     comma separated builder names
+//	NON_CENSORING, DEBUG           booleans
+//	COMPRESSION                    gzip request bodies from this size in bytes
+func ConfigFromEnv(prefix string) (ClientConfig, error) {
+	env := func(name string) string {
+		return strings.TrimSpace(os.Getenv(prefix + name))
+	}
+	list := func(name string) []string {
+		var values []string
+		for _, value := range strings.Split(env(name), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	config := ClientConfig{
+		URL:             env("URL"),
+		IPC:             env("IPC"),
+		Network:         env("NETWORK"),
+		Builders:        list("BUILDERS"),
+		ExcludeBuilders: list("EXCLUDE_BUILDERS"),
+		Auth: AuthConfig{
+			Bloxroute:  env("AUTH"),
+			AccountID:  env("ACCOUNT_ID"),
+			SecretHash: env("SECRET_HASH"),
+			Bearer:     env("BEARER"),
+		},
+	}
+
+	for _, header := range list("HEADERS") {
+		name, value, ok := strings.Cut(header, "=")
+		if !ok {
+			return config, errors.Errorf("%sHEADERS: expected Name=value, got %q", prefix, header)
+		}
+		if config.Auth.Headers == nil {
+			config.Auth.Headers = map[string]string{}
+		}
+		config.Auth.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	if timeout := env("TIMEOUT"); timeout != "" {
+		if _, err := strconv.ParseFloat(timeout, 64); err != nil {
+			timeout = strconv.Quote(timeout)
+		}
+		if err := json.Unmarshal([]byte(timeout), &config.Timeout); err != nil {
+			return config, errors.Wrapf(err, "%sTIMEOUT", prefix)
+		}
+	}
+	for name, target := range map[string]*bool{"NON_CENSORING": &config.NonCensoringOnly, "DEBUG": &config.Debug} {
+		if value := env(name); value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return config, errors.Wrapf(err, "%s%s", prefix, name)
+			}
+			*target = enabled
+		}
+	}
+	if value := env("COMPRESSION"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return config, errors.Wrapf(err, "%sCOMPRESSION", prefix)
+		}
+		config.Compression = size
+	}
+
+	return config, nil
+}
+
+// NewFromEnv create client configured by the FLASHXROUTE_ environment variables, see ConfigFromEnv. Options are
+// applied after the configured ones.
+func NewFromEnv(options ...func(rpc *FlashXRoute)) (*FlashXRoute, error) {
+	config, err := ConfigFromEnv(EnvPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc, err := config.NewClient(options...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "set %sURL or %sIPC", EnvPrefix, EnvPrefix)
+	}
+
+	return rpc, nil
+}
@@ -0,0 +1,43 @@
+package flashxroute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("FLASHXROUTE_URL", "")
+	_, err := NewFromEnv()
+	require.ErrorIs(t, err, ErrNoEndpoint)
+
+	t.Setenv("FLASHXROUTE_URL", "https://api.blxrbdn.com")
+	t.Setenv("FLASHXROUTE_NETWORK", NetworkBSCMainnet)
+	t.Setenv("FLASHXROUTE_ACCOUNT_ID", "account")
+	t.Setenv("FLASHXROUTE_SECRET_HASH", "hash")
+	t.Setenv("FLASHXROUTE_TIMEOUT", "5s")
+	t.Setenv("FLASHXROUTE_BUILDERS", "bloxroute, titan")
+	t.Setenv("FLASHXROUTE_HEADERS", "X-Api-Key=key, X-Team=mev")
+	t.Setenv("FLASHXROUTE_DEBUG", "true")
+
+	rpc, err := NewFromEnv(WithTimeout(7 * time.Second))
+	require.Nil(t, err)
+	require.Equal(t, "https://api.blxrbdn.com", rpc.url)
+	require.Equal(t, NetworkBSCMainnet, rpc.network)
+	require.Equal(t, AuthorizationHeader("account", "hash"), rpc.authHeader)
+	require.Equal(t, 7*time.Second, rpc.Timeout)
+	require.Equal(t, []string{"bloxroute", "titan"}, rpc.policy.Include)
+	require.Equal(t, "key", rpc.Headers["X-Api-Key"])
+	require.Equal(t, "mev", rpc.Headers["X-Team"])
+	require.True(t, rpc.Debug)
+
+	t.Setenv("FLASHXROUTE_TIMEOUT", "1.5")
+	config, err := ConfigFromEnv(EnvPrefix)
+	require.Nil(t, err)
+	require.Equal(t, Duration(1500*time.Millisecond), config.Timeout)
+
+	t.Setenv("FLASHXROUTE_DEBUG", "maybe")
+	_, err = NewFromEnv()
+	require.ErrorContains(t, err, "FLASHXROUTE_DEBUG")
+}
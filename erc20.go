@@ -0,0 +1,165 @@
+package flashxroute
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ERC-20 function selectors
+const (
+	erc20BalanceOf    = "0x70a08231" // balanceOf(address)
+	erc20Allowance    = "0xdd62ed3e" // allowance(address,address)
+	erc20Decimals     = "0x313ce567" // decimals()
+	erc20Symbol       = "0x95d89b41" // symbol()
+	erc20Transfer     = "0xa9059cbb" // transfer(address,uint256)
+	erc20Approve      = "0x095ea7b3" // approve(address,uint256)
+	erc20TransferFrom = "0x23b872dd" // transferFrom(address,address,uint256)
+)
+
+// ErrInvalidABIResult is returned when a call result can't be decoded as the expected return type
+var ErrInvalidABIResult = errors.New("invalid abi result")
+
+// abiAddress returns the 32 bytes word of address
+func abiAddress(address string) ([]byte, error) {
+	a, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(make([]byte, 12), a.Bytes()...), nil
+}
+
+// abiUint returns the 32 bytes word of unsigned value
+func abiUint(value *big.Int) ([]byte, error) {
+	if value == nil || value.Sign() < 0 || value.BitLen() > 256 {
+		return nil, errors.Errorf("%v is not a uint256", value)
+	}
+
+	return value.FillBytes(make([]byte, 32)), nil
+}
+
+// erc20Calldata returns the 0x prefixed calldata of selector with addresses followed by amount when not nil
+func erc20Calldata(selector string, amount *big.Int, addresses ...string) (string, error) {
+	data, _ := ParseBytes(selector)
+	for _, address := range addresses {
+		word, err := abiAddress(address)
+		if err != nil {
+			return "", err
+		}
+		data = append(data, word...)
+	}
+	if amount != nil {
+		word, err := abiUint(amount)
+		if err != nil {
+			return "", err
+		}
+		data = append(data, word...)
+	}
+
+	return BytesToHex(data), nil
+}
+
+// ERC20Transfer returns the call of token.transfer(to, amount), to be completed with gas, fees and nonce before
+// signing or passed to EthCall and EthEstimateGas
+func ERC20Transfer(token, to string, amount *big.Int) (T, error) {
+	data, err := erc20Calldata(erc20Transfer, amount, to)
+	return T{To: token, Data: data}, err
+}
+
+// ERC20Approve returns the call of token.approve(spender, amount)
+func ERC20Approve(token, spender string, amount *big.Int) (T, error) {
+	data, err := erc20Calldata(erc20Approve, amount, spender)
+	return T{To: token, Data: data}, err
+}
+
+// ERC20TransferFrom returns the call of token.transferFrom(from, to, amount)
+func ERC20TransferFrom(token, from, to string, amount *big.Int) (T, error) {
+	data, err := erc20Calldata(erc20TransferFrom, amount, from, to)
+	return T{To: token, Data: data}, err
+}
+
+// callWord calls token with data at block and returns the first 32 bytes word of the result
+func (rpc *FlashXRoute) callWord(token, data, block string) ([]byte, error) {
+	result, err := rpc.EthCall(T{To: token, Data: data}, block)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ParseBytes(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) < 32 {
+		return nil, errors.Wrapf(ErrInvalidABIResult, "%d bytes returned by %s", len(value), token)
+	}
+
+	return value[:32], nil
+}
+
+// ERC20BalanceOf returns the token balance of owner at block in the token's smallest unit
+func (rpc *FlashXRoute) ERC20BalanceOf(token, owner, block string) (*big.Int, error) {
+	data, err := erc20Calldata(erc20BalanceOf, nil, owner)
+	if err != nil {
+		return nil, err
+	}
+	word, err := rpc.callWord(token, data, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(word), nil
+}
+
+// ERC20Allowance returns the amount of token spender may transfer from owner at block
+func (rpc *FlashXRoute) ERC20Allowance(token, owner, spender, block string) (*big.Int, error) {
+	data, err := erc20Calldata(erc20Allowance, nil, owner, spender)
+	if err != nil {
+		return nil, err
+	}
+	word, err := rpc.callWord(token, data, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(word), nil
+}
+
+// ERC20Decimals returns the decimals of token
+func (rpc *FlashXRoute) ERC20Decimals(token string) (int, error) {
+	word, err := rpc.callWord(token, erc20Decimals, "latest")
+	if err != nil {
+		return 0, err
+	}
+	decimals := new(big.Int).SetBytes(word)
+	if decimals.BitLen() > 8 {
+		return 0, errors.Wrapf(ErrInvalidABIResult, "decimals %s of %s", decimals, token)
+	}
+
+	return int(decimals.Int64()), nil
+}
+
+// ERC20Symbol returns the symbol of token, returned as string or, by older tokens like MKR, as bytes32
+func (rpc *FlashXRoute) ERC20Symbol(token string) (string, error) {
+	result, err := rpc.EthCall(T{To: token, Data: erc20Symbol}, "latest")
+	if err != nil {
+		return "", err
+	}
+	data, err := ParseBytes(result)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(data) == 32:
+		return string(bytes.TrimRight(data, "\x00")), nil
+	case len(data) >= 64:
+		symbol, err := dynamicABIValue(data, data[:32])
+		if err != nil {
+			return "", errors.Wrapf(ErrInvalidABIResult, "symbol of %s: %v", token, err)
+		}
+		return string(symbol), nil
+	}
+
+	return "", errors.Wrapf(ErrInvalidABIResult, "%d bytes symbol of %s", len(data), token)
+}
@@ -0,0 +1,69 @@
+package flashxroute
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestERC20Selectors(t *testing.T) {
+	for selector, signature := range map[string]string{
+		erc20BalanceOf: "balanceOf(address)", erc20Allowance: "allowance(address,address)", erc20Decimals: "decimals()",
+		erc20Symbol: "symbol()", erc20Transfer: "transfer(address,uint256)", erc20Approve: "approve(address,uint256)",
+		erc20TransferFrom: "transferFrom(address,address,uint256)",
+	} {
+		expected, err := MethodSelector(signature)
+		require.Nil(t, err)
+		require.Equal(t, expected, selector, signature)
+	}
+}
+
+func TestERC20Transfer(t *testing.T) {
+	tx, err := ERC20Transfer("0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb", big.NewInt(256))
+	require.Nil(t, err)
+	require.Equal(t, "0x00000000000000000000000000000000000000aa", tx.To)
+	require.Equal(t, "0xa9059cbb"+strings.Repeat("0", 62)+"bb"+strings.Repeat("0", 61)+"100", tx.Data)
+
+	_, err = ERC20Approve("0xaa", "0x00000000000000000000000000000000000000bb", big.NewInt(-1))
+	require.NotNil(t, err)
+	_, err = ERC20TransferFrom("0xaa", "0xbb", "0x00000000000000000000000000000000000000cc", big.NewInt(1))
+	require.NotNil(t, err)
+}
+
+func (s *FlashXRouteTestSuite) TestERC20Reads() {
+	token, owner, spender := "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb", "0x00000000000000000000000000000000000000cc"
+	word := func(value string) string { return strings.Repeat("0", 64-len(value)) + value }
+	s.registerMethods(map[string]string{
+		`eth_call [{"data":"0x70a08231` + word("bb") + `","from":"","to":"` + token + `"},"0x10"]`:                `"0x` + word("3e8") + `"`,
+		`eth_call [{"data":"0xdd62ed3e` + word("bb") + word("cc") + `","from":"","to":"` + token + `"},"latest"]`: `"0x` + word("ff") + `"`,
+		`eth_call [{"data":"0x313ce567","from":"","to":"` + token + `"},"latest"]`:                                `"0x` + word("12") + `"`,
+		`eth_call [{"data":"0x95d89b41","from":"","to":"` + token + `"},"latest"]`:                                `"0x` + word("20") + word("4") + "57455448" + strings.Repeat("0", 56) + `"`,
+	})
+
+	balance, err := s.rpc.ERC20BalanceOf(token, owner, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(big.NewInt(1000), balance)
+
+	allowance, err := s.rpc.ERC20Allowance(token, owner, spender, "latest")
+	s.Require().Nil(err)
+	s.Require().Equal(big.NewInt(255), allowance)
+
+	decimals, err := s.rpc.ERC20Decimals(token)
+	s.Require().Nil(err)
+	s.Require().Equal(18, decimals)
+
+	symbol, err := s.rpc.ERC20Symbol(token)
+	s.Require().Nil(err)
+	s.Require().Equal("WETH", symbol)
+
+	s.registerMethods(map[string]string{"eth_call": `"0x4d4b520000000000000000000000000000000000000000000000000000000000"`})
+	symbol, err = s.rpc.ERC20Symbol(token)
+	s.Require().Nil(err)
+	s.Require().Equal("MKR", symbol)
+
+	s.registerMethods(map[string]string{"eth_call": `"0x"`})
+	_, err = s.rpc.ERC20Decimals(token)
+	s.Require().ErrorIs(err, ErrInvalidABIResult)
+}
@@ -0,0 +1,45 @@
+// Package eth narrows flashxroute to its generic ethereum json-rpc methods. Clients are *flashxroute.FlashXRoute
+// values seen through the EthereumAPI interface, so code depending on this package keeps working with fakes and with
+// clients created by the root package.
+//
+// This package only holds aliases and forwarders, the implementation is in the root package. It doesn't split the
+// client: relay calls share the transport, dry-run, deduplication, hedging and retries of the root package.
+package eth
+
+import (
+	"github.com/saman-pasha/flashxroute"
+)
+
+// Client - ethereum json-rpc methods
+type Client = flashxroute.EthereumAPI
+
+// Reader - read-only ethereum json-rpc methods
+type Reader = flashxroute.EthereumReader
+
+// Sender - ethereum json-rpc methods which sign or send transactions
+type Sender = flashxroute.EthereumSender
+
+// Option - client option, any flashxroute.With* option
+type Option = func(rpc *flashxroute.FlashXRoute)
+
+// Ethereum json-rpc types
+type (
+	Block              = flashxroute.Block
+	Transaction        = flashxroute.Transaction
+	TransactionReceipt = flashxroute.TransactionReceipt
+	Log                = flashxroute.Log
+	FilterParams       = flashxroute.FilterParams
+	Syncing            = flashxroute.Syncing
+	T                  = flashxroute.T
+	RpcError           = flashxroute.RpcError
+)
+
+// New create ethereum json-rpc client for url
+func New(url string, options ...Option) Client {
+	return flashxroute.New(url, options...)
+}
+
+// NewIPC create ethereum json-rpc client talking to a local node over its unix socket
+func NewIPC(path string, options ...Option) Client {
+	return flashxroute.NewIPC(path, options...)
+}
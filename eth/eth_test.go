@@ -0,0 +1,35 @@
+package eth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saman-pasha/flashxroute"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, "value", r.Header.Get("X-Test"))
+		switch gjson.GetBytes(body, "method").String() {
+		case "eth_blockNumber":
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x10"}`))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "error": {"code": -32601, "message": "method not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	var client Client = New(server.URL, flashxroute.WithHeader("X-Test", "value"))
+	number, err := client.EthBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 16, number)
+
+	var reader Reader = client
+	_, err = reader.EthCoinbase()
+	require.Equal(t, RpcError{Code: -32601, Message: "method not found"}, err)
+}
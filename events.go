@@ -0,0 +1,126 @@
+package flashxroute
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrEventMismatch is returned when decoding a log of another event
+var ErrEventMismatch = errors.New("log is not of the event")
+
+// DecodeEvent decodes log of the event declared by signature, like
+// "Transfer(address indexed from, address indexed to, uint256 value)", into the struct out points to. Exported
+// fields receive the event arguments in order: addresses as string or common.Address, integers as big.Int,
+// *big.Int or sized integers, bytesN as byte arrays, []byte or hex strings, indexed strings, bytes and arrays as
+// their topic hash.
+func DecodeEvent(log Log, signature string, out interface{}) error {
+	sig, err := parseEventSignature(signature)
+	if err != nil {
+		return err
+	}
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("decode target must be pointer to struct, got %T", out)
+	}
+
+	return decodeLog(sig, log, target.Elem())
+}
+
+func decodeLog(sig eventSignature, log Log, out reflect.Value) error {
+	if len(log.Topics) == 0 || !strings.EqualFold(log.Topics[0], sig.topic()) {
+		return errors.Wrap(ErrEventMismatch, sig.canonical())
+	}
+	data, err := ParseBytes(log.Data)
+	if err != nil {
+		return err
+	}
+
+	return decodeEvent(sig, log.Topics, data, out)
+}
+
+// SubscribeOptions - parameters of SubscribeEvent
+type SubscribeOptions struct {
+	PollInterval time.Duration // how often to poll the filter (default: 1s)
+	Topics       [][]string    // filters of the indexed arguments following the event topic, see FilterParams
+	OnError      func(error)   // receives polling errors, polling goes on after them
+}
+
+// SubscribeEvent installs an eth_newFilter filter for the logs of the event emitted by contract and calls handler
+// with every new log decoded, until ctx is done. handler is func(E) or func(E, Log) where E is a struct decoded as
+// by DecodeEvent, logs removed by reorgs are delivered too with Log.Removed set. An expired filter is installed
+// again, the filter is uninstalled on return. Returns ctx.Err() or the first decoding error.
+func (rpc *FlashXRoute) SubscribeEvent(ctx context.Context, contract, signature string, handler interface{}, options SubscribeOptions) error {
+	sig, err := parseEventSignature(signature)
+	if err != nil {
+		return err
+	}
+	callback := reflect.ValueOf(handler)
+	callbackType := callback.Type()
+	if callbackType.Kind() != reflect.Func || callbackType.NumIn() < 1 || callbackType.NumIn() > 2 || callbackType.In(0).Kind() != reflect.Struct ||
+		callbackType.NumIn() == 2 && callbackType.In(1) != reflect.TypeOf(Log{}) {
+		return errors.Errorf("handler must be func(E) or func(E, Log) with E struct, got %T", handler)
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Second
+	}
+	report := func(err error) {
+		if options.OnError != nil {
+			options.OnError(err)
+		}
+	}
+
+	params := FilterParams{Address: []string{contract}, Topics: append([][]string{{sig.topic()}}, options.Topics...)}
+	filterID, err := rpc.EthNewFilter(params)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = rpc.EthUninstallFilter(filterID)
+	}()
+
+	ticker := time.NewTicker(options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		logs, err := rpc.EthGetFilterChanges(filterID)
+		if err != nil {
+			report(err)
+			if isFilterNotFound(err) {
+				if id, err := rpc.EthNewFilter(params); err != nil {
+					report(err)
+				} else {
+					filterID = id
+				}
+			}
+			continue
+		}
+
+		for _, log := range logs {
+			event := reflect.New(callbackType.In(0)).Elem()
+			if err := decodeLog(sig, log, event); err != nil {
+				return errors.Wrapf(err, "log %d of %s", log.LogIndex, log.TransactionHash)
+			}
+			args := []reflect.Value{event}
+			if callbackType.NumIn() == 2 {
+				args = append(args, reflect.ValueOf(log))
+			}
+			callback.Call(args)
+		}
+	}
+}
+
+// isFilterNotFound reports whether the node dropped the filter, e.g. after it wasn't polled for a while
+func isFilterNotFound(err error) bool {
+	var rpcErr RpcError
+	return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Message), "filter not found")
+}
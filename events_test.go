@@ -0,0 +1,96 @@
+package flashxroute
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+type transferEvent struct {
+	From  string
+	To    common.Address
+	Value *big.Int
+}
+
+var transferLog = Log{
+	Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+	Topics: []string{
+		transferTopic,
+		"0x000000000000000000000000d10e3be2bc8f959bc8c41cf65f60de721cf89adf",
+		"0x0000000000000000000000000000000000000000000000000000000000000001",
+	},
+	Data: "0x00000000000000000000000000000000000000000000000000000000000f4240",
+}
+
+func (s *FlashXRouteTestSuite) TestDecodeEvent() {
+	var transfer transferEvent
+	err := DecodeEvent(transferLog, "event Transfer(address indexed from, address indexed to, uint256 value)", &transfer)
+	s.Require().Nil(err)
+	s.Require().Equal("0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf", transfer.From)
+	s.Require().Equal(common.HexToAddress("0x1"), transfer.To)
+	s.Require().Equal(big.NewInt(1000000), transfer.Value)
+
+	var approval transferEvent
+	err = DecodeEvent(transferLog, "Approval(address indexed owner, address indexed spender, uint256 value)", &approval)
+	s.Require().ErrorIs(err, ErrEventMismatch)
+
+	var message struct {
+		Sender string
+		Delta  int64
+		Text   string
+		Tag    [4]byte
+	}
+	log := Log{
+		Topics: []string{Keccak256([]byte("Message(address,int256,string,bytes4)")), transferLog.Topics[1]},
+		Data: "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe" +
+			"0000000000000000000000000000000000000000000000000000000000000060" +
+			"cafebabe00000000000000000000000000000000000000000000000000000000" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"6869000000000000000000000000000000000000000000000000000000000000",
+	}
+	err = DecodeEvent(log, "Message(address indexed sender, int delta, string text, bytes4 tag)", &message)
+	s.Require().Nil(err)
+	s.Require().Equal(int64(-2), message.Delta)
+	s.Require().Equal("hi", message.Text)
+	s.Require().Equal([4]byte{0xca, 0xfe, 0xba, 0xbe}, message.Tag)
+
+	var wrong struct {
+		Sender, Delta, Text string
+		Tag                 bool
+	}
+	err = DecodeEvent(log, "Message(address indexed sender, int delta, string text, bytes4 tag)", &wrong)
+	s.Require().ErrorContains(err, "field Tag: cannot store bytes4 in bool")
+
+	err = DecodeEvent(log, "Batch(uint256[] ids)", &approval)
+	s.Require().ErrorIs(err, ErrUnsupportedABIType)
+}
+
+func (s *FlashXRouteTestSuite) TestSubscribeEvent() {
+	s.registerMethods(map[string]string{
+		`eth_newFilter [{"address":["0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"],"topics":[["` + transferTopic + `"],null]}]`: `"0x1"`,
+		"eth_getFilterChanges": `[{"address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","topics":["` + transferTopic + `","` +
+			transferLog.Topics[1] + `","` + transferLog.Topics[2] + `"],"data":"` + transferLog.Data + `","blockNumber":"0x10"}]`,
+		"eth_uninstallFilter": `true`,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var events []transferEvent
+	err := s.rpc.SubscribeEvent(ctx, transferLog.Address, "Transfer(address indexed from, address indexed to, uint256 value)",
+		func(event transferEvent, log Log) {
+			s.Require().Equal(16, log.BlockNumber)
+			events = append(events, event)
+			cancel()
+		}, SubscribeOptions{PollInterval: time.Millisecond, Topics: [][]string{nil}})
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Require().Len(events, 1)
+	s.Require().Equal(big.NewInt(1000000), events[0].Value)
+
+	err = s.rpc.SubscribeEvent(ctx, transferLog.Address, "Transfer(address,address,uint256)", func(*transferEvent) {}, SubscribeOptions{})
+	s.Require().ErrorContains(err, "handler must be")
+}
@@ -0,0 +1,188 @@
+package flashxroute
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExportFormat - file format of the exporters
+type ExportFormat int
+
+// Export formats
+const (
+	ExportJSON ExportFormat = iota // one json object per line, e.g. for pandas.read_json(path, lines=True)
+	ExportCSV                      // header row followed by one row per record
+)
+
+// ExportFormatFromPath returns ExportCSV for .csv files and ExportJSON for the others
+func ExportFormatFromPath(path string) ExportFormat {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ExportCSV
+	}
+
+	return ExportJSON
+}
+
+// SimulationReport - flattened simulation response with derived profit metrics, amounts are decimal wei
+type SimulationReport struct {
+	BundleHash        string  `json:"bundleHash"`
+	StateBlockNumber  int64   `json:"stateBlockNumber"`
+	Transactions      int     `json:"transactions"`
+	Reverted          int     `json:"reverted"`
+	TotalGasUsed      int64   `json:"totalGasUsed"`
+	BundleGasPrice    string  `json:"bundleGasPrice"`
+	CoinbaseDiff      string  `json:"coinbaseDiff"`
+	GasFees           string  `json:"gasFees"`
+	EthSentToCoinbase string  `json:"ethSentToCoinbase"`
+	EffectiveGasPrice string  `json:"effectiveGasPrice"` // coinbaseDiff / totalGasUsed
+	TransferShare     float64 `json:"transferShare"`     // share of coinbaseDiff paid by direct transfer instead of gas fees
+}
+
+// NewSimulationReport derives the report of a simulation response
+func NewSimulationReport(res BloxrouteSimulateBundleResponse) SimulationReport {
+	report := SimulationReport{
+		BundleHash:        res.BundleHash,
+		StateBlockNumber:  res.StateBlockNumber,
+		Transactions:      len(res.Results),
+		TotalGasUsed:      res.TotalGasUsed,
+		BundleGasPrice:    res.BundleGasPrice,
+		CoinbaseDiff:      res.CoinbaseDiff,
+		GasFees:           res.GasFees,
+		EthSentToCoinbase: res.EthSentToCoinbase,
+	}
+	for _, result := range res.Results {
+		if result.Reverted() {
+			report.Reverted++
+		}
+	}
+
+	coinbaseDiff, ok := new(big.Int).SetString(res.CoinbaseDiff, 10)
+	if ok && res.TotalGasUsed > 0 {
+		report.EffectiveGasPrice = new(big.Int).Quo(coinbaseDiff, big.NewInt(res.TotalGasUsed)).String()
+	}
+	transfer, transferOk := new(big.Float).SetString(res.EthSentToCoinbase)
+	if ok && transferOk && coinbaseDiff.Sign() > 0 {
+		report.TransferShare, _ = new(big.Float).Quo(transfer, new(big.Float).SetInt(coinbaseDiff)).Float64()
+	}
+
+	return report
+}
+
+func (r SimulationReport) header() []string {
+	return []string{"bundleHash", "stateBlockNumber", "transactions", "reverted", "totalGasUsed", "bundleGasPrice",
+		"coinbaseDiff", "gasFees", "ethSentToCoinbase", "effectiveGasPrice", "transferShare"}
+}
+
+func (r SimulationReport) record() []string {
+	return []string{r.BundleHash, strconv.FormatInt(r.StateBlockNumber, 10), strconv.Itoa(r.Transactions),
+		strconv.Itoa(r.Reverted), strconv.FormatInt(r.TotalGasUsed, 10), r.BundleGasPrice, r.CoinbaseDiff, r.GasFees,
+		r.EthSentToCoinbase, r.EffectiveGasPrice, strconv.FormatFloat(r.TransferShare, 'f', -1, 64)}
+}
+
+// SubmissionReport - journal entry with derived inclusion metrics
+type SubmissionReport struct {
+	JournalEntry
+	InclusionDelay int    `json:"inclusionDelay"` // blocks between target and inclusion, -1 unless included
+	RealizedProfit string `json:"realizedProfit"` // declared coinbase profit of included bundles, otherwise 0
+}
+
+// NewSubmissionReport derives the report of a journal entry
+func NewSubmissionReport(entry JournalEntry) SubmissionReport {
+	report := SubmissionReport{JournalEntry: entry, InclusionDelay: -1, RealizedProfit: "0"}
+	if entry.Status == InclusionIncluded {
+		report.InclusionDelay = entry.IncludedIn - entry.BlockNumber
+		if entry.CoinbaseProfit != "" {
+			report.RealizedProfit = entry.CoinbaseProfit
+		}
+	}
+
+	return report
+}
+
+func (r SubmissionReport) header() []string {
+	return []string{"id", "submittedAt", "relay", "method", "blockNumber", "transactions", "bundleHash", "status",
+		"includedIn", "inclusionDelay", "coinbaseProfit", "realizedProfit", "error"}
+}
+
+func (r SubmissionReport) record() []string {
+	return []string{strconv.FormatInt(r.ID, 10), r.SubmittedAt.UTC().Format("2006-01-02T15:04:05.000Z07:00"), r.Relay,
+		r.Method, strconv.Itoa(r.BlockNumber), strings.Join(r.Transactions, " "), r.BundleHash, r.Status.String(),
+		strconv.Itoa(r.IncludedIn), strconv.Itoa(r.InclusionDelay), r.CoinbaseProfit, r.RealizedProfit, r.Error}
+}
+
+// report - row of an exporter
+type report interface {
+	header() []string
+	record() []string
+}
+
+// ExportSimulations writes the reports of simulation responses to w
+func ExportSimulations(w io.Writer, format ExportFormat, simulations []BloxrouteSimulateBundleResponse) error {
+	reports := make([]report, len(simulations))
+	for i, res := range simulations {
+		reports[i] = NewSimulationReport(res)
+	}
+
+	return export(w, format, SimulationReport{}, reports)
+}
+
+// ExportSubmissions writes the reports of journal entries to w
+func ExportSubmissions(w io.Writer, format ExportFormat, entries []JournalEntry) error {
+	reports := make([]report, len(entries))
+	for i, entry := range entries {
+		reports[i] = NewSubmissionReport(entry)
+	}
+
+	return export(w, format, SubmissionReport{}, reports)
+}
+
+// ExportToFile creates file at path and writes it with the exporter in the format picked by the file extension,
+// e.g. ExportToFile("bundles.csv", func(w io.Writer, f ExportFormat) error { return ExportSubmissions(w, f, entries) })
+func ExportToFile(path string, exporter func(w io.Writer, format ExportFormat) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := exporter(file, ExportFormatFromPath(path)); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func export(w io.Writer, format ExportFormat, empty report, reports []report) error {
+	switch format {
+	case ExportJSON:
+		encoder := json.NewEncoder(w)
+		for _, r := range reports {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(empty.header()); err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if err := writer.Write(r.record()); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return errors.Errorf("unknown export format %d", format)
+}
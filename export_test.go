@@ -0,0 +1,57 @@
+package flashxroute
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSimulations(t *testing.T) {
+	simulations := []BloxrouteSimulateBundleResponse{{
+		BundleHash:        "0xb",
+		CoinbaseDiff:      "4000",
+		GasFees:           "3000",
+		EthSentToCoinbase: "1000",
+		TotalGasUsed:      20,
+		StateBlockNumber:  100,
+		Results:           []BloxrouteSimulateBundleResult{{TxHash: "0x1"}, {TxHash: "0x2", Error: "execution reverted"}},
+	}}
+
+	var buffer bytes.Buffer
+	require.Nil(t, ExportSimulations(&buffer, ExportCSV, simulations))
+	require.Equal(t, "bundleHash,stateBlockNumber,transactions,reverted,totalGasUsed,bundleGasPrice,coinbaseDiff,gasFees,ethSentToCoinbase,effectiveGasPrice,transferShare\n"+
+		"0xb,100,2,1,20,,4000,3000,1000,200,0.25\n", buffer.String())
+
+	buffer.Reset()
+	require.Nil(t, ExportSimulations(&buffer, ExportJSON, simulations))
+	require.Contains(t, buffer.String(), `"effectiveGasPrice":"200","transferShare":0.25}`)
+}
+
+func TestExportSubmissions(t *testing.T) {
+	entries := []JournalEntry{
+		{ID: 1, SubmittedAt: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), Relay: "r", Method: "blxr_submit_bundle", BlockNumber: 10, Transactions: []string{"01", "02"}, CoinbaseProfit: "7", Status: InclusionIncluded, IncludedIn: 11},
+		{ID: 2, SubmittedAt: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), BlockNumber: 12, CoinbaseProfit: "7", Status: InclusionMissed},
+	}
+
+	path := filepath.Join(t.TempDir(), "submissions.csv")
+	require.Nil(t, ExportToFile(path, func(w io.Writer, format ExportFormat) error {
+		return ExportSubmissions(w, format, entries)
+	}))
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "1,2022-09-01T00:00:00.000Z,r,blxr_submit_bundle,10,01 02,,included,11,1,7,7,", lines[1])
+	require.Equal(t, "2,2022-09-01T00:00:00.000Z,,,12,,,missed,0,-1,7,0,", lines[2])
+
+	var buffer bytes.Buffer
+	require.Nil(t, ExportSubmissions(&buffer, ExportJSON, entries[:1]))
+	require.Contains(t, buffer.String(), `"inclusionDelay":1,"realizedProfit":"7"}`)
+	require.Equal(t, ExportJSON, ExportFormatFromPath("out.jsonl"))
+}
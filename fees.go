@@ -0,0 +1,120 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoFeeHistory is returned when the fee history has no non-empty blocks to suggest a priority fee from
+var ErrNoFeeHistory = errors.New("no fee history")
+
+// FeeHistory - eth_feeHistory result
+type FeeHistory struct {
+	OldestBlock   int
+	BaseFeePerGas []big.Int   // base fees of the returned blocks followed by the base fee of the next block
+	GasUsedRatio  []float64   // gas used divided by gas limit of the returned blocks
+	Reward        [][]big.Int // requested priority fee percentiles of the returned blocks
+}
+
+type proxyFeeHistory struct {
+	OldestBlock   hexInt     `json:"oldestBlock"`
+	BaseFeePerGas []hexBig   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	Reward        [][]hexBig `json:"reward"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *FeeHistory) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyFeeHistory)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	h.OldestBlock = int(proxy.OldestBlock)
+	h.GasUsedRatio = proxy.GasUsedRatio
+	h.BaseFeePerGas = make([]big.Int, len(proxy.BaseFeePerGas))
+	for i := range proxy.BaseFeePerGas {
+		h.BaseFeePerGas[i] = big.Int(proxy.BaseFeePerGas[i])
+	}
+	h.Reward = make([][]big.Int, len(proxy.Reward))
+	for i := range proxy.Reward {
+		h.Reward[i] = make([]big.Int, len(proxy.Reward[i]))
+		for j := range proxy.Reward[i] {
+			h.Reward[i][j] = big.Int(proxy.Reward[i][j])
+		}
+	}
+
+	return nil
+}
+
+// EthFeeHistory returns base fees, gas used ratios and the given priority fee percentiles of blockCount blocks up
+// to newestBlock
+func (rpc *FlashXRoute) EthFeeHistory(blockCount int, newestBlock string, rewardPercentiles []float64) (*FeeHistory, error) {
+	history := new(FeeHistory)
+
+	err := rpc.call("eth_feeHistory", history, IntToHex(blockCount), newestBlock, rewardPercentiles)
+	return history, err
+}
+
+// SuggestPriorityFee returns a priority fee (tip) in wei at the given percentile of the last lookbackBlocks blocks,
+// smoothed with an exponential moving average favouring recent blocks. Empty blocks are skipped.
+func (rpc *FlashXRoute) SuggestPriorityFee(percentile float64, lookbackBlocks int) (*big.Int, error) {
+	history, err := rpc.EthFeeHistory(lookbackBlocks, "latest", []float64{percentile})
+	if err != nil {
+		return nil, err
+	}
+
+	return history.PriorityFee(0)
+}
+
+// SuggestFees returns a priority fee from SuggestPriorityFee and a max fee per gas covering it on top of twice the
+// next block base fee, enough to stay includable through six full blocks
+func (rpc *FlashXRoute) SuggestFees(percentile float64, lookbackBlocks int) (maxFee, priorityFee *big.Int, err error) {
+	history, err := rpc.EthFeeHistory(lookbackBlocks, "latest", []float64{percentile})
+	if err != nil {
+		return nil, nil, err
+	}
+	if priorityFee, err = history.PriorityFee(0); err != nil {
+		return nil, nil, err
+	}
+
+	maxFee = new(big.Int).Mul(history.NextBaseFee(), big.NewInt(2))
+	return maxFee.Add(maxFee, priorityFee), priorityFee, nil
+}
+
+// NextBaseFee returns the base fee of the block following the newest returned block
+func (h *FeeHistory) NextBaseFee() *big.Int {
+	if len(h.BaseFeePerGas) == 0 {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(&h.BaseFeePerGas[len(h.BaseFeePerGas)-1])
+}
+
+// PriorityFee returns the exponential moving average of the reward at the given percentile index over non-empty
+// blocks, oldest first
+func (h *FeeHistory) PriorityFee(index int) (*big.Int, error) {
+	var rewards []*big.Int
+	for i, reward := range h.Reward {
+		if index >= len(reward) || i < len(h.GasUsedRatio) && h.GasUsedRatio[i] == 0 {
+			continue
+		}
+		rewards = append(rewards, &reward[index])
+	}
+	if len(rewards) == 0 {
+		return nil, ErrNoFeeHistory
+	}
+
+	// alpha = 2 / (n + 1), kept in integers as average += (reward - average) * 2 / (n + 1)
+	average := new(big.Int).Set(rewards[0])
+	for _, reward := range rewards[1:] {
+		delta := new(big.Int).Sub(reward, average)
+		delta.Mul(delta, big.NewInt(2))
+		delta.Quo(delta, big.NewInt(int64(len(rewards)+1)))
+		average.Add(average, delta)
+	}
+
+	return average, nil
+}
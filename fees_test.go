@@ -0,0 +1,41 @@
+package flashxroute
+
+import (
+	"math/big"
+)
+
+func (s *FlashXRouteTestSuite) TestEthFeeHistory() {
+	s.registerResponse(`{
+		"oldestBlock": "0x10",
+		"baseFeePerGas": ["0x3b9aca00", "0x3b9aca00", "0x3b9aca00", "0x4190ab00"],
+		"gasUsedRatio": [0.5, 0, 0.9],
+		"reward": [["0x64"], ["0x0"], ["0x190"]]
+	}`, func(body []byte) {
+		s.methodEqual(body, "eth_feeHistory")
+		s.paramsEqual(body, `["0x3", "latest", [50]]`)
+	})
+
+	history, err := s.rpc.EthFeeHistory(3, "latest", []float64{50})
+	s.Require().Nil(err)
+	s.Require().Equal(16, history.OldestBlock)
+	s.Require().Equal([]float64{0.5, 0, 0.9}, history.GasUsedRatio)
+	s.Require().Equal(*big.NewInt(400), history.Reward[2][0])
+	s.Require().Equal(big.NewInt(1100000000), history.NextBaseFee())
+
+	// the empty block is skipped: 100 + (400 - 100) * 2 / 3
+	tip, err := s.rpc.SuggestPriorityFee(50, 3)
+	s.Require().Nil(err)
+	s.Require().Equal(big.NewInt(300), tip)
+
+	maxFee, tip, err := s.rpc.SuggestFees(50, 3)
+	s.Require().Nil(err)
+	s.Require().Equal(big.NewInt(300), tip)
+	s.Require().Equal(big.NewInt(2200000300), maxFee)
+}
+
+func (s *FlashXRouteTestSuite) TestSuggestPriorityFeeEmptyBlocks() {
+	s.registerResponse(`{"oldestBlock": "0x10", "baseFeePerGas": ["0x1", "0x1"], "gasUsedRatio": [0], "reward": [["0x0"]]}`, func(body []byte) {})
+
+	_, err := s.rpc.SuggestPriorityFee(50, 1)
+	s.Require().ErrorIs(err, ErrNoFeeHistory)
+}
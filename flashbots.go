@@ -0,0 +1,289 @@
+package flashxroute
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+)
+
+// CallWithFlashbotsSignature is like Call but also signs the request with X-Flashbots-Signature
+func (rpc *FlashXRoute) CallWithFlashbotsSignature(method string, privKey *ecdsa.PrivateKey, params ...interface{}) (json.RawMessage, error) {
+	return rpc.CallWithFlashbotsSigner(method, NewPrivateKeySigner(privKey), params...)
+}
+
+// CallWithFlashbotsSigner is like CallWithFlashbotsSignature but signs with signer, e.g. a hardware wallet
+func (rpc *FlashXRoute) CallWithFlashbotsSigner(method string, signer Signer, params ...interface{}) (json.RawMessage, error) {
+	return rpc.CallWithFlashbotsSignerContext(context.Background(), method, signer, params...)
+}
+
+// CallWithFlashbotsSignerContext is like CallWithFlashbotsSigner, the http request is cancelled when ctx is done and
+// carries the headers of ContextWithHeaders, which take precedence over the computed X-Flashbots-Signature
+func (rpc *FlashXRoute) CallWithFlashbotsSignerContext(ctx context.Context, method string, signer Signer, params ...interface{}) (json.RawMessage, error) {
+	if err := checkTextSigner(signer); err != nil {
+		return nil, err
+	}
+
+	request := rpcRequest{
+		ID:      1,
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if result, ok, err := rpc.dryRun(method, body); ok {
+		return result, err
+	}
+
+	return rpc.deduplicated(ctx, method, body, func() (json.RawMessage, error) {
+		return rpc.postFlashbots(ctx, method, signer, body)
+	})
+}
+
+// postFlashbots sends json-rpc request body signed by signer and returns its result
+func (rpc *FlashXRoute) postFlashbots(ctx context.Context, method string, signer Signer, body []byte) (json.RawMessage, error) {
+	signature, err := FlashbotsSignature(signer, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", rpc.url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("X-Flashbots-Signature", signature)
+	for k, v := range rpc.Headers {
+		req.Header.Add(k, v)
+	}
+	for k, v := range callHeaders(ctx) {
+		req.Header.Set(k, v)
+	}
+	httpClient := rpc.newHTTPClient(method, nil)
+
+	finish := rpc.observe(method, req, body)
+	response, err := httpClient.Do(req)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		finish(nil, nil, err)
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		finish(response, nil, err)
+		return nil, err
+	}
+	finish(response, data, nil)
+
+	// On error, response looks like this instead of JSON-RPC: {"error":"block param must be a hex int"}
+	errorResp := new(RelayErrorResponse)
+	if err := json.Unmarshal(data, errorResp); err == nil && errorResp.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrRelayErrorResponse, errorResp.Error)
+	}
+
+	resp := new(rpcResponse)
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRelayErrorResponse, (*resp).Error.Message)
+	}
+
+	return resp.Result, nil
+}
+
+// FlashbotsFeeRefundTotals - gas fee refunds of a recipient in wei
+type FlashbotsFeeRefundTotals struct {
+	Pending  big.Int // refunds of landed bundles not paid out yet
+	Received big.Int // refunds paid out
+}
+
+type proxyFlashbotsFeeRefundTotals struct {
+	Pending  hexBig `json:"pending"`
+	Received hexBig `json:"received"`
+}
+
+// FlashbotsFeeRefund - a gas fee refund of a landed bundle
+type FlashbotsFeeRefund struct {
+	Hash        string
+	Amount      big.Int
+	BlockNumber int
+	Status      string // pending or received
+	Recipient   string
+}
+
+type proxyFlashbotsFeeRefund struct {
+	Hash        string `json:"hash"`
+	Amount      hexBig `json:"amount"`
+	BlockNumber hexInt `json:"blockNumber"`
+	Status      string `json:"status"`
+	Recipient   string `json:"recipient"`
+}
+
+// FlashbotsFeeRefunds - a page of fee refunds, pass Cursor to fetch the next one, it is empty on the last page
+type FlashbotsFeeRefunds struct {
+	Refunds []FlashbotsFeeRefund
+	Cursor  string
+}
+
+type proxyFlashbotsFeeRefunds struct {
+	Refunds []proxyFlashbotsFeeRefund `json:"refunds"`
+	Cursor  string                    `json:"cursor"`
+}
+
+func (proxy *proxyFlashbotsFeeRefunds) toFeeRefunds() FlashbotsFeeRefunds {
+	refunds := FlashbotsFeeRefunds{Refunds: make([]FlashbotsFeeRefund, len(proxy.Refunds)), Cursor: proxy.Cursor}
+	for i, refund := range proxy.Refunds {
+		refunds.Refunds[i] = FlashbotsFeeRefund{
+			Hash:        refund.Hash,
+			Amount:      big.Int(refund.Amount),
+			BlockNumber: int(refund.BlockNumber),
+			Status:      refund.Status,
+			Recipient:   refund.Recipient,
+		}
+	}
+
+	return refunds
+}
+
+// flashbotsSigner returns signer of privKey, the client's signer of WithSigner when privKey is nil
+func (rpc *FlashXRoute) flashbotsSigner(privKey *ecdsa.PrivateKey) Signer {
+	if privKey == nil {
+		return rpc.signer
+	}
+
+	return NewPrivateKeySigner(privKey)
+}
+
+// callFlashbotsSigned calls method signed by signer, the client's signer of WithSigner when signer is nil
+func (rpc *FlashXRoute) callFlashbotsSigned(method string, signer Signer, params ...interface{}) (json.RawMessage, error) {
+	if signer == nil {
+		signer = rpc.signer
+	}
+	if signer == nil {
+		return nil, ErrNoSigner
+	}
+
+	return rpc.CallWithFlashbotsSigner(method, signer, params...)
+}
+
+func (rpc *FlashXRoute) flashbotsFeeRefunds(method string, signer Signer, params interface{}) (FlashbotsFeeRefunds, error) {
+	rawMsg, err := rpc.callFlashbotsSigned(method, signer, params)
+	if err != nil {
+		return FlashbotsFeeRefunds{}, err
+	}
+
+	proxy := new(proxyFlashbotsFeeRefunds)
+	if err := rpc.decode(rawMsg, proxy); err != nil {
+		return FlashbotsFeeRefunds{}, err
+	}
+
+	return proxy.toFeeRefunds(), nil
+}
+
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#flashbots_getfeerefundtotalsbyrecipient
+// A nil privKey signs with the signer of WithSigner, like every Flashbots method taking a key.
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundTotalsByRecipient(privKey *ecdsa.PrivateKey, recipient string) (FlashbotsFeeRefundTotals, error) {
+	return rpc.FlashbotsGetFeeRefundTotalsByRecipientWithSigner(rpc.flashbotsSigner(privKey), recipient)
+}
+
+// FlashbotsGetFeeRefundTotalsByRecipientWithSigner is like FlashbotsGetFeeRefundTotalsByRecipient but signs with
+// signer, the signer of WithSigner when nil
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundTotalsByRecipientWithSigner(signer Signer, recipient string) (res FlashbotsFeeRefundTotals, err error) {
+	rawMsg, err := rpc.callFlashbotsSigned("flashbots_getFeeRefundTotalsByRecipient", signer, recipient)
+	if err != nil {
+		return res, err
+	}
+
+	proxy := new(proxyFlashbotsFeeRefundTotals)
+	if err := rpc.decode(rawMsg, proxy); err != nil {
+		return res, err
+	}
+
+	return FlashbotsFeeRefundTotals{Pending: big.Int(proxy.Pending), Received: big.Int(proxy.Received)}, nil
+}
+
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#flashbots_getfeerefundsbyrecipient
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByRecipient(privKey *ecdsa.PrivateKey, recipient, cursor string) (FlashbotsFeeRefunds, error) {
+	return rpc.FlashbotsGetFeeRefundsByRecipientWithSigner(rpc.flashbotsSigner(privKey), recipient, cursor)
+}
+
+// FlashbotsGetFeeRefundsByRecipientWithSigner is like FlashbotsGetFeeRefundsByRecipient but signs with signer, the
+// signer of WithSigner when nil
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByRecipientWithSigner(signer Signer, recipient, cursor string) (FlashbotsFeeRefunds, error) {
+	params := map[string]string{"recipient": recipient}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
+
+	return rpc.flashbotsFeeRefunds("flashbots_getFeeRefundsByRecipient", signer, params)
+}
+
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#flashbots_getfeerefundsbybundle
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByBundle(privKey *ecdsa.PrivateKey, bundleHash string) (FlashbotsFeeRefunds, error) {
+	return rpc.FlashbotsGetFeeRefundsByBundleWithSigner(rpc.flashbotsSigner(privKey), bundleHash)
+}
+
+// FlashbotsGetFeeRefundsByBundleWithSigner is like FlashbotsGetFeeRefundsByBundle but signs with signer, the signer
+// of WithSigner when nil
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByBundleWithSigner(signer Signer, bundleHash string) (FlashbotsFeeRefunds, error) {
+	return rpc.flashbotsFeeRefunds("flashbots_getFeeRefundsByBundle", signer, bundleHash)
+}
+
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#flashbots_getfeerefundsbyblock
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByBlock(privKey *ecdsa.PrivateKey, blockNumber int) (FlashbotsFeeRefunds, error) {
+	return rpc.FlashbotsGetFeeRefundsByBlockWithSigner(rpc.flashbotsSigner(privKey), blockNumber)
+}
+
+// FlashbotsGetFeeRefundsByBlockWithSigner is like FlashbotsGetFeeRefundsByBlock but signs with signer, the signer of
+// WithSigner when nil
+func (rpc *FlashXRoute) FlashbotsGetFeeRefundsByBlockWithSigner(signer Signer, blockNumber int) (FlashbotsFeeRefunds, error) {
+	return rpc.flashbotsFeeRefunds("flashbots_getFeeRefundsByBlock", signer, IntToHex(blockNumber))
+}
+
+// FlashbotsSetFeeRefundRecipient redirects the fee refunds of the signing address to recipient,
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#flashbots_setfeerefundrecipient
+func (rpc *FlashXRoute) FlashbotsSetFeeRefundRecipient(privKey *ecdsa.PrivateKey, recipient string) error {
+	return rpc.FlashbotsSetFeeRefundRecipientWithSigner(rpc.flashbotsSigner(privKey), recipient)
+}
+
+// FlashbotsSetFeeRefundRecipientWithSigner is like FlashbotsSetFeeRefundRecipient for the address of signer, the
+// signer of WithSigner when nil
+func (rpc *FlashXRoute) FlashbotsSetFeeRefundRecipientWithSigner(signer Signer, recipient string) error {
+	if signer == nil {
+		signer = rpc.signer
+	}
+	if signer == nil {
+		return ErrNoSigner
+	}
+	_, err := rpc.CallWithFlashbotsSigner("flashbots_setFeeRefundRecipient", signer, signer.Address().Hex(), recipient)
+	return err
+}
+
+// FlashbotsCancelBundle cancels the bundles submitted with replacementUuid uuid by the signing address,
+// https://docs.flashbots.net/flashbots-auction/advanced/rpc-endpoint#eth_cancelbundle
+func (rpc *FlashXRoute) FlashbotsCancelBundle(privKey *ecdsa.PrivateKey, uuid string) error {
+	return rpc.FlashbotsCancelBundleWithSigner(rpc.flashbotsSigner(privKey), uuid)
+}
+
+// FlashbotsCancelBundleWithSigner is like FlashbotsCancelBundle but signs with signer, the signer of WithSigner when
+// nil
+func (rpc *FlashXRoute) FlashbotsCancelBundleWithSigner(signer Signer, uuid string) error {
+	_, err := rpc.callFlashbotsSigned("eth_cancelBundle", signer, CancelBundleRequest{ReplacementUUID: uuid})
+	return err
+}
@@ -0,0 +1,88 @@
+// Package flashbots narrows flashxroute to the Flashbots relay methods, forwarding to the root package. Requests
+// are signed with the key the client was created with.
+package flashbots
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+
+	"github.com/saman-pasha/flashxroute"
+)
+
+// Option - client option, any flashxroute.With* option
+type Option = func(rpc *flashxroute.FlashXRoute)
+
+// Flashbots request and response types
+type (
+	SendBundleRequest  = flashxroute.SendBundleRequest
+	SendBundleResponse = flashxroute.SendBundleResponse
+	FeeRefundTotals    = flashxroute.FlashbotsFeeRefundTotals
+	FeeRefund          = flashxroute.FlashbotsFeeRefund
+	FeeRefunds         = flashxroute.FlashbotsFeeRefunds
+)
+
+// Client - Flashbots relay client
+type Client struct {
+	relay  *flashxroute.BuilderClient
+	signer flashxroute.Signer
+}
+
+// New create client for the Flashbots mainnet relay signing with key
+func New(key *ecdsa.PrivateKey, options ...Option) *Client {
+	return NewWithURL(flashxroute.FlashbotsRelayURL, key, options...)
+}
+
+// NewWithURL create client for the Flashbots relay at url, e.g. flashxroute.FlashbotsSepoliaRelayURL
+func NewWithURL(url string, key *ecdsa.PrivateKey, options ...Option) *Client {
+	return NewWithSigner(url, flashxroute.NewPrivateKeySigner(key), options...)
+}
+
+// NewWithSigner create client for the Flashbots relay at url signing with signer, e.g. a hardware wallet
+func NewWithSigner(url string, signer flashxroute.Signer, options ...Option) *Client {
+	builder, _ := flashxroute.KnownBuilder("flashbots")
+	builder.URL = url
+	options = append([]Option{flashxroute.WithSigner(signer)}, options...)
+	relay, _ := flashxroute.NewBuilderClient(builder, options...)
+
+	return &Client{relay: relay, signer: signer}
+}
+
+// RPC returns the underlying client
+func (c *Client) RPC() *flashxroute.FlashXRoute {
+	return c.relay.FlashXRoute
+}
+
+// Call calls method with X-Flashbots-Signature
+func (c *Client) Call(method string, params ...interface{}) (json.RawMessage, error) {
+	return c.relay.CallWithFlashbotsSigner(method, c.signer, params...)
+}
+
+// SendBundle submits bundle with eth_sendBundle
+func (c *Client) SendBundle(params SendBundleRequest) (SendBundleResponse, error) {
+	return c.relay.SendBundle(params)
+}
+
+// FeeRefundTotalsByRecipient returns pending and received fee refunds of recipient
+func (c *Client) FeeRefundTotalsByRecipient(recipient string) (FeeRefundTotals, error) {
+	return c.relay.FlashbotsGetFeeRefundTotalsByRecipientWithSigner(c.signer, recipient)
+}
+
+// FeeRefundsByRecipient returns a page of fee refunds of recipient, pass the Cursor of a page to fetch the next one
+func (c *Client) FeeRefundsByRecipient(recipient, cursor string) (FeeRefunds, error) {
+	return c.relay.FlashbotsGetFeeRefundsByRecipientWithSigner(c.signer, recipient, cursor)
+}
+
+// FeeRefundsByBundle returns fee refunds of a bundle
+func (c *Client) FeeRefundsByBundle(bundleHash string) (FeeRefunds, error) {
+	return c.relay.FlashbotsGetFeeRefundsByBundleWithSigner(c.signer, bundleHash)
+}
+
+// FeeRefundsByBlock returns fee refunds of the bundles landed in block
+func (c *Client) FeeRefundsByBlock(blockNumber int) (FeeRefunds, error) {
+	return c.relay.FlashbotsGetFeeRefundsByBlockWithSigner(c.signer, blockNumber)
+}
+
+// SetFeeRefundRecipient delegates fee refunds of the signing address to recipient
+func (c *Client) SetFeeRefundRecipient(recipient string) error {
+	return c.relay.FlashbotsSetFeeRefundRecipientWithSigner(c.signer, recipient)
+}
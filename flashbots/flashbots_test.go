@@ -0,0 +1,40 @@
+package flashbots
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.True(t, strings.Contains(r.Header.Get("X-Flashbots-Signature"), ":"))
+		switch gjson.GetBytes(body, "method").String() {
+		case "eth_sendBundle":
+			require.False(t, gjson.GetBytes(body, "params.0.refundPercent").Exists())
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+		case "flashbots_getFeeRefundTotalsByRecipient":
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"pending": "0x1", "received": "0x2"}}`))
+		}
+	}))
+	defer server.Close()
+
+	key, _ := crypto.GenerateKey()
+	client := NewWithURL(server.URL, key)
+
+	refund := 50
+	res, err := client.SendBundle(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10", RefundPercent: &refund})
+	require.Nil(t, err)
+	require.Equal(t, "0xb", res.BundleHash)
+
+	totals, err := client.FeeRefundTotalsByRecipient("0x01")
+	require.Nil(t, err)
+	require.Equal(t, int64(2), totals.Received.Int64())
+}
@@ -0,0 +1,109 @@
+package flashxroute
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestCallWithFlashbotsSignature() {
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		signature := request.Header.Get("X-Flashbots-Signature")
+		address := crypto.PubkeyToAddress(s.privKey.PublicKey).Hex()
+		s.Require().True(strings.HasPrefix(signature, address+":0x"), signature)
+		return httpmock.NewStringResponse(200, `{"error": "block param must be a hex int"}`), nil
+	})
+
+	_, err := s.rpc.CallWithFlashbotsSignature("flashbots_getFeeRefundsByBlock", s.privKey, "16")
+	s.Require().ErrorIs(err, ErrRelayErrorResponse)
+
+	s.registerResponseError(errors.New("Error"))
+	_, err = s.rpc.CallWithFlashbotsSignature("flashbots_getFeeRefundsByBlock", s.privKey, "0x10")
+	s.Require().NotNil(err)
+}
+
+func (s *FlashXRouteTestSuite) TestFlashbotsFeeRefunds() {
+	s.registerResponse(`{"pending": "0x17812d3d3b8b8c1", "received": "0x0"}`, func(body []byte) {
+		s.methodEqual(body, "flashbots_getFeeRefundTotalsByRecipient")
+		s.paramsEqual(body, `["0xrecipient"]`)
+	})
+	totals, err := s.rpc.FlashbotsGetFeeRefundTotalsByRecipient(s.privKey, "0xrecipient")
+	s.Require().Nil(err)
+	s.Require().Equal(*big.NewInt(0x17812d3d3b8b8c1), totals.Pending)
+	s.Require().Equal(big.Int{}, totals.Received)
+
+	refunds := `{"refunds": [{"hash": "0xbundle", "amount": "0x17812d3d3b8b8c1", "blockNumber": "0x10", "status": "pending", "recipient": "0xrecipient"}], "cursor": "0x1"}`
+	expected := FlashbotsFeeRefunds{
+		Refunds: []FlashbotsFeeRefund{{Hash: "0xbundle", Amount: *big.NewInt(0x17812d3d3b8b8c1), BlockNumber: 16, Status: "pending", Recipient: "0xrecipient"}},
+		Cursor:  "0x1",
+	}
+
+	s.registerResponse(refunds, func(body []byte) {
+		s.methodEqual(body, "flashbots_getFeeRefundsByRecipient")
+		s.paramsEqual(body, `[{"recipient": "0xrecipient", "cursor": "0x0"}]`)
+	})
+	page, err := s.rpc.FlashbotsGetFeeRefundsByRecipient(s.privKey, "0xrecipient", "0x0")
+	s.Require().Nil(err)
+	s.Require().Equal(expected, page)
+
+	s.registerResponse(refunds, func(body []byte) {
+		s.methodEqual(body, "flashbots_getFeeRefundsByBundle")
+		s.paramsEqual(body, `["0xbundle"]`)
+	})
+	page, err = s.rpc.FlashbotsGetFeeRefundsByBundle(s.privKey, "0xbundle")
+	s.Require().Nil(err)
+	s.Require().Equal(expected, page)
+
+	s.registerResponse(refunds, func(body []byte) {
+		s.methodEqual(body, "flashbots_getFeeRefundsByBlock")
+		s.paramsEqual(body, `["0x10"]`)
+	})
+	page, err = s.rpc.FlashbotsGetFeeRefundsByBlock(s.privKey, 16)
+	s.Require().Nil(err)
+	s.Require().Equal(expected, page)
+
+	from := crypto.PubkeyToAddress(s.privKey.PublicKey).Hex()
+	s.registerResponse(`{"from": "`+from+`", "to": "0xrecipient"}`, func(body []byte) {
+		s.methodEqual(body, "flashbots_setFeeRefundRecipient")
+		s.paramsEqual(body, `["`+from+`", "0xrecipient"]`)
+	})
+	s.Require().Nil(s.rpc.FlashbotsSetFeeRefundRecipient(s.privKey, "0xrecipient"))
+}
+
+func (s *FlashXRouteTestSuite) TestFlashbotsMethodsWithSigner() {
+	_, err := s.rpc.FlashbotsGetFeeRefundsByBlock(nil, 16)
+	s.Require().ErrorIs(err, ErrNoSigner)
+	s.Require().ErrorIs(s.rpc.FlashbotsCancelBundleWithSigner(nil, "uuid"), ErrNoSigner)
+
+	key, _ := crypto.GenerateKey()
+	wallet := &testWallet{key: key}
+	signer := NewWalletSigner(wallet, accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey)})
+	rpc := New(s.rpc.url, WithSigner(signer))
+	from := signer.Address().Hex()
+
+	var methods []string
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		s.Require().True(strings.HasPrefix(request.Header.Get("X-Flashbots-Signature"), from+":0x"))
+		body := s.getBody(request)
+		methods = append(methods, gjson.GetBytes(body, "method").String())
+		if gjson.GetBytes(body, "method").String() == "flashbots_setFeeRefundRecipient" {
+			s.paramsEqual(body, `["`+from+`", "0xrecipient"]`)
+		}
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": {"pending": "0x1", "received": "0x0"}}`), nil
+	})
+
+	s.Require().Nil(rpc.FlashbotsSetFeeRefundRecipient(nil, "0xrecipient"))
+	s.Require().Nil(rpc.FlashbotsCancelBundleWithSigner(nil, "uuid"))
+	totals, err := rpc.FlashbotsGetFeeRefundTotalsByRecipientWithSigner(signer, "0xrecipient")
+	s.Require().Nil(err)
+	s.Require().Equal(*big.NewInt(1), totals.Pending)
+	s.Require().Equal([]string{"flashbots_setFeeRefundRecipient", "eth_cancelBundle", "flashbots_getFeeRefundTotalsByRecipient"}, methods)
+}
@@ -2,6 +2,7 @@ package flashxroute
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,7 +30,28 @@ type rpcResponse struct {
 	ID      int             `json:"id"`
 	JSONRPC string          `json:"jsonrpc"`
 	Result  json.RawMessage `json:"result"`
-	Error   *RpcError       `json:"error"`
+	Error   *rpcError       `json:"error"`
+}
+
+// rpcError - error object of a json-rpc response, its data is only kept as the revert data of RevertError
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// toError returns the error as *RevertError when it carries revert data, else as RpcError
+func (e rpcError) toError() error {
+	rpcErr := RpcError{Code: e.Code, Message: e.Message}
+	var hexData string
+	if len(e.Data) == 0 || json.Unmarshal(e.Data, &hexData) != nil {
+		return rpcErr
+	}
+	if data, err := ParseBytes(hexData); err == nil && len(data) > 0 {
+		return newRevertError(rpcErr, data, nil)
+	}
+
+	return rpcErr
 }
 
 type rpcRequest struct {
@@ -48,12 +70,39 @@ type BoxrouteRequest struct {
 
 // FlashXRoute - Ethereum rpc client
 type FlashXRoute struct {
-	url     string
-	client  httpClient
-	log     logger
-	Debug   bool
-	Headers map[string]string // Additional headers to send with the request
-	Timeout time.Duration
+	url        string
+	client     httpClient
+	log        logger
+	authHeader string // Default bloXroute Authorization header, used when a call passes an empty one
+	network    string // bloXroute blockchain network name, e.g. BSC-Mainnet
+	personal   bool   // personal_* methods are enabled, see WithPersonalAPI
+	signer     Signer
+	policy     *Policy                 // builder selection for bundles submitted without mev_builders
+	guard      *ProfitGuard            // simulate and refuse unprofitable bundles before submission
+	recorder   *dryRunRecorder         // records state-changing requests instead of sending them, see WithDryRun
+	hedge      *hedge                  // second endpoint raced against url for reads, see WithHedging
+	clock      *clock                  // measured drift used to adjust bundle timestamps, see WithClockCheck
+	auth       AuthProvider            // authenticates every request in the transport, see WithAuth
+	compress   *compression            // gzip request bodies, see WithCompression
+	ipc        *ipcClient              // unix socket of a local node used instead of http, see WithIPC
+	transport  http.RoundTripper       // replaces the http transport of every request, see WithTransport
+	methods    map[string]MethodConfig // timeouts and retries by method, see WithMethodConfig
+	hooks      Hooks                   // request and response callbacks, see WithHooks
+	journal    Journal                 // records bundle submissions, see WithJournal
+	notifiers  []BundleNotifier        // receive bundle events, see WithNotifier
+	quota      *quotaThrottle          // delays bloXroute requests when the daily quota is nearly used, see WithQuotaThrottle
+	validate   bool                    // check address and hash parameters before sending, see WithValidation
+	strict     bool                    // reject null, empty and decimal quantities in results, see WithStrictDecoding
+	archive    *archive                // archive node serving calls at old blocks, see WithArchive
+	chainID    *chainIDCache           // chain id of the endpoint, see ChainID
+	checkChain bool                    // verify the chain id of transactions signed by the client, see WithChainIDCheck
+	logLevels  map[string]LogLevel     // debug log verbosity by subsystem, see WithLogLevel
+	stats      *latencyStats           // latency and errors by endpoint, see WithStats
+	cache      *objectCache            // blocks, transactions and receipts by hash, see WithCache
+	dedup      *dedup                  // bundle submissions by idempotency key, see WithDeduplication
+	Debug      bool              // log every subsystem at LogBodies, unless configured with WithLogLevel
+	Headers    map[string]string // Additional headers to send with the request
+	Timeout    time.Duration
 }
 
 // New create new rpc client with given url
@@ -64,6 +113,7 @@ func New(url string, options ...func(rpc *FlashXRoute)) *FlashXRoute {
 		log:     log.New(os.Stderr, "", log.LstdFlags),
 		Headers: make(map[string]string),
 		Timeout: 30 * time.Second,
+		chainID: &chainIDCache{},
 	}
 	for _, option := range options {
 		option(rpc)
@@ -77,6 +127,27 @@ func NewFlashXRoute(url string, options ...func(rpc *FlashXRoute)) *FlashXRoute
 	return New(url, options...)
 }
 
+// Clone returns a copy of the client sharing the http client and logger but owning its own Headers map
+func (rpc *FlashXRoute) Clone() *FlashXRoute {
+	clone := *rpc
+	clone.Headers = make(map[string]string, len(rpc.Headers))
+	for k, v := range rpc.Headers {
+		clone.Headers[k] = v
+	}
+
+	return &clone
+}
+
+// With returns a clone of the client with the given options applied, e.g. to derive per-relay instances from a base client
+func (rpc *FlashXRoute) With(options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	clone := rpc.Clone()
+	for _, option := range options {
+		option(clone)
+	}
+
+	return clone
+}
+
 func (rpc *FlashXRoute) call(method string, target interface{}, params ...interface{}) error {
 	result, err := rpc.Call(method, params...)
 	if err != nil {
@@ -87,7 +158,7 @@ func (rpc *FlashXRoute) call(method string, target interface{}, params ...interf
 		return nil
 	}
 
-	return json.Unmarshal(result, target)
+	return rpc.decode(result, target)
 }
 
 // URL returns client url
@@ -97,6 +168,12 @@ func (rpc *FlashXRoute) URL() string {
 
 // Call returns raw response of method call
 func (rpc *FlashXRoute) Call(method string, params ...interface{}) (json.RawMessage, error) {
+	return rpc.CallContext(context.Background(), method, params...)
+}
+
+// CallContext is like Call, the http request is cancelled when ctx is done and carries the headers of
+// ContextWithHeaders
+func (rpc *FlashXRoute) CallContext(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
 	request := rpcRequest{
 		ID:      1,
 		JSONRPC: "2.0",
@@ -109,7 +186,50 @@ func (rpc *FlashXRoute) Call(method string, params ...interface{}) (json.RawMess
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", rpc.url, bytes.NewBuffer(body))
+	if result, ok, err := rpc.dryRun(method, body); ok {
+		return result, err
+	}
+
+	return rpc.deduplicated(ctx, method, body, func() (json.RawMessage, error) {
+		return rpc.retry(method, submissionMethods[method], func() (json.RawMessage, error) {
+			if url, ok := rpc.archiveURL(method, body); ok {
+				return rpc.post(ctx, url, method, body)
+			}
+
+			result, err := rpc.send(ctx, method, body)
+			return rpc.postArchive(ctx, method, body, result, err)
+		})
+	})
+}
+
+// send sends json-rpc request body over ipc, hedged or to url and returns its result
+func (rpc *FlashXRoute) send(ctx context.Context, method string, body []byte) (json.RawMessage, error) {
+	if rpc.ipc != nil {
+		return rpc.callIPC(method, body)
+	}
+
+	if rpc.hedge != nil {
+		if !submissionMethods[method] {
+			return rpc.hedged(ctx, method, body)
+		}
+	}
+
+	return rpc.post(ctx, rpc.url, method, body)
+}
+
+// post sends json-rpc request body to url and returns its result
+func (rpc *FlashXRoute) post(ctx context.Context, url, method string, body []byte) (json.RawMessage, error) {
+	data, err := rpc.postData(ctx, url, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResponse(data)
+}
+
+// postData sends json-rpc request body to url and returns the response body
+func (rpc *FlashXRoute) postData(ctx context.Context, url, method string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -119,34 +239,40 @@ func (rpc *FlashXRoute) Call(method string, params ...interface{}) (json.RawMess
 	for k, v := range rpc.Headers {
 		req.Header.Add(k, v)
 	}
-	httpClient := &http.Client{
-		Timeout: rpc.Timeout,
+	for k, v := range callHeaders(ctx) {
+		req.Header.Set(k, v)
 	}
+	httpClient := rpc.newHTTPClient(method, nil)
 
+	finish := rpc.observe(method, req, body)
 	response, err := httpClient.Do(req)
 	if response != nil {
 		defer response.Body.Close()
 	}
 	if err != nil {
+		finish(nil, nil, err)
 		return nil, err
 	}
 
 	data, err := ioutil.ReadAll(response.Body)
 	if err != nil {
+		finish(response, nil, err)
 		return nil, err
 	}
+	finish(response, data, nil)
 
-	if rpc.Debug {
-		rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nResponse: %s\n", method, body, data))
-	}
+	return data, nil
+}
 
+// decodeResponse returns the result of json-rpc response data, or its error as RpcError
+func decodeResponse(data []byte) (json.RawMessage, error) {
 	resp := new(rpcResponse)
 	if err := json.Unmarshal(data, resp); err != nil {
 		return nil, err
 	}
 
 	if resp.Error != nil {
-		return nil, *resp.Error
+		return nil, resp.Error.toError()
 	}
 
 	return resp.Result, nil
@@ -154,6 +280,16 @@ func (rpc *FlashXRoute) Call(method string, params ...interface{}) (json.RawMess
 
 // CallWithBloxrouteAuthHeader is like Call but also signs the request
 func (rpc *FlashXRoute) CallWithBloxrouteAuthHeader(method string, authHeader string, params interface{}) (json.RawMessage, error) {
+	return rpc.CallWithBloxrouteAuthHeaderContext(context.Background(), method, authHeader, params)
+}
+
+// CallWithBloxrouteAuthHeaderContext is like CallWithBloxrouteAuthHeader, the http request is cancelled when ctx is
+// done and carries the headers of ContextWithHeaders
+func (rpc *FlashXRoute) CallWithBloxrouteAuthHeaderContext(ctx context.Context, method string, authHeader string, params interface{}) (json.RawMessage, error) {
+	if authHeader == "" {
+		authHeader = rpc.authHeader
+	}
+
 	request := BoxrouteRequest{
 		ID:      1,
 		JSONRPC: "2.0",
@@ -166,7 +302,24 @@ func (rpc *FlashXRoute) CallWithBloxrouteAuthHeader(method string, authHeader st
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", rpc.url, bytes.NewBuffer(body))
+	if result, ok, err := rpc.dryRun(method, body); ok {
+		return result, err
+	}
+
+	return rpc.deduplicated(ctx, method, body, func() (json.RawMessage, error) {
+		if rpc.quota != nil {
+			rpc.throttle(authHeader)
+		}
+
+		return rpc.retry(method, submissionMethods[method], func() (json.RawMessage, error) {
+			return rpc.postBloxroute(ctx, method, authHeader, body)
+		})
+	})
+}
+
+// postBloxroute sends bloXroute request body with the Authorization header and returns its result
+func (rpc *FlashXRoute) postBloxroute(ctx context.Context, method, authHeader string, body []byte) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", rpc.url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -177,30 +330,30 @@ func (rpc *FlashXRoute) CallWithBloxrouteAuthHeader(method string, authHeader st
 	for k, v := range rpc.Headers {
 		req.Header.Add(k, v)
 	}
+	for k, v := range callHeaders(ctx) {
+		req.Header.Set(k, v)
+	}
 
 	tlsConfig := &tls.Config{InsecureSkipVerify: true}
 	transport := &http.Transport{TLSClientConfig: tlsConfig}
-	httpClient := &http.Client{
-		Transport: transport,
-		Timeout: rpc.Timeout,
-	}
+	httpClient := rpc.newHTTPClient(method, transport)
 
+	finish := rpc.observe(method, req, body)
 	response, err := httpClient.Do(req)
 	if response != nil {
 		defer response.Body.Close()
 	}
 	if err != nil {
+		finish(nil, nil, err)
 		return nil, err
 	}
 
 	data, err := ioutil.ReadAll(response.Body)
 	if err != nil {
+		finish(response, nil, err)
 		return nil, err
 	}
-
-	if rpc.Debug {
-		rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nAuthHeader: %s\nResponse: %s\n", method, body, authHeader, data))
-	}
+	finish(response, data, nil)
 
 	// On error, response looks like this instead of JSON-RPC: {"error":"block param must be a hex int"}
 	errorResp := new(RelayErrorResponse)
@@ -235,6 +388,7 @@ func (rpc *FlashXRoute) Web3ClientVersion() (string, error) {
 }
 
 // Web3Sha3 returns Keccak-256 (not the standardized SHA3-256) of the given data.
+// Keccak256 computes the same hash locally.
 func (rpc *FlashXRoute) Web3Sha3(data []byte) (string, error) {
 	var hash string
 
@@ -286,7 +440,7 @@ func (rpc *FlashXRoute) EthSyncing() (*Syncing, error) {
 	if bytes.Equal(result, []byte("false")) {
 		return syncing, nil
 	}
-	err = json.Unmarshal(result, syncing)
+	err = rpc.decode(result, syncing)
 	return syncing, err
 }
 
@@ -347,6 +501,10 @@ func (rpc *FlashXRoute) EthBlockNumber() (int, error) {
 
 // EthGetBalance returns the balance of the account of given address in wei.
 func (rpc *FlashXRoute) EthGetBalance(address, block string) (big.Int, error) {
+	if err := rpc.checkAddress(address); err != nil {
+		return big.Int{}, err
+	}
+
 	var response string
 	if err := rpc.call("eth_getBalance", &response, address, block); err != nil {
 		return big.Int{}, err
@@ -357,14 +515,39 @@ func (rpc *FlashXRoute) EthGetBalance(address, block string) (big.Int, error) {
 
 // EthGetStorageAt returns the value from a storage position at a given address.
 func (rpc *FlashXRoute) EthGetStorageAt(data string, position int, tag string) (string, error) {
+	if err := rpc.checkAddress(data); err != nil {
+		return "", err
+	}
+
 	var result string
 
 	err := rpc.call("eth_getStorageAt", &result, data, IntToHex(position), tag)
 	return result, err
 }
 
+// EthGetStorageAtSlot returns the value from a storage slot at a given address, slot is a hex key of up to 32 bytes
+// like the mapping and array slots of MappingSlot and ArraySlot or common.Hash.Hex().
+func (rpc *FlashXRoute) EthGetStorageAtSlot(address, slot, tag string) (string, error) {
+	if err := rpc.checkAddress(address); err != nil {
+		return "", err
+	}
+	key, err := padSlot(slot)
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+
+	err = rpc.call("eth_getStorageAt", &result, address, BytesToHex(key), tag)
+	return result, err
+}
+
 // EthGetTransactionCount returns the number of transactions sent from an address.
 func (rpc *FlashXRoute) EthGetTransactionCount(address, block string) (int, error) {
+	if err := rpc.checkAddress(address); err != nil {
+		return 0, err
+	}
+
 	var response string
 
 	if err := rpc.call("eth_getTransactionCount", &response, address, block); err != nil {
@@ -374,8 +557,28 @@ func (rpc *FlashXRoute) EthGetTransactionCount(address, block string) (int, erro
 	return ParseInt(response)
 }
 
+// PendingNonceAt returns the nonce of the next transaction of address, counting its transactions pending in the
+// node's mempool. Use it to send a new transaction.
+func (rpc *FlashXRoute) PendingNonceAt(address string) (int, error) {
+	return rpc.EthGetTransactionCount(address, "pending")
+}
+
+// NonceAt returns the number of transactions of address mined up to block, "latest" when empty, ignoring pending
+// ones. Use it to replace a stuck transaction or check whether a nonce landed.
+func (rpc *FlashXRoute) NonceAt(address, block string) (int, error) {
+	if block == "" {
+		block = "latest"
+	}
+
+	return rpc.EthGetTransactionCount(address, block)
+}
+
 // EthGetBlockTransactionCountByHash returns the number of transactions in a block from a block matching the given block hash.
 func (rpc *FlashXRoute) EthGetBlockTransactionCountByHash(hash string) (int, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return 0, err
+	}
+
 	var response string
 
 	if err := rpc.call("eth_getBlockTransactionCountByHash", &response, hash); err != nil {
@@ -398,6 +601,10 @@ func (rpc *FlashXRoute) EthGetBlockTransactionCountByNumber(number int) (int, er
 
 // EthGetUncleCountByBlockHash returns the number of uncles in a block from a block matching the given block hash.
 func (rpc *FlashXRoute) EthGetUncleCountByBlockHash(hash string) (int, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return 0, err
+	}
+
 	var response string
 
 	if err := rpc.call("eth_getUncleCountByBlockHash", &response, hash); err != nil {
@@ -420,6 +627,10 @@ func (rpc *FlashXRoute) EthGetUncleCountByBlockNumber(number int) (int, error) {
 
 // EthGetCode returns code at a given address.
 func (rpc *FlashXRoute) EthGetCode(address, block string) (string, error) {
+	if err := rpc.checkAddress(address); err != nil {
+		return "", err
+	}
+
 	var code string
 
 	err := rpc.call("eth_getCode", &code, address, block)
@@ -451,12 +662,20 @@ func (rpc *FlashXRoute) EthSendRawTransaction(data string) (string, error) {
 	return hash, err
 }
 
+// EthSendSignedTransaction submits a signed transaction, encoding it for eth_sendRawTransaction.
+func (rpc *FlashXRoute) EthSendSignedTransaction(tx *types.Transaction) (string, error) {
+	data, err := TxToHex(tx)
+	if err != nil {
+		return "", err
+	}
+
+	return rpc.EthSendRawTransaction(data)
+}
+
 // EthCall executes a new message call immediately without creating a transaction on the block chain.
+// A reverted call fails with *RevertError carrying the decoded revert reason, see EthCallWithErrors for custom errors.
 func (rpc *FlashXRoute) EthCall(transaction T, tag string) (string, error) {
-	var data string
-
-	err := rpc.call("eth_call", &data, transaction, tag)
-	return data, err
+	return rpc.EthCallWithErrors(transaction, tag)
 }
 
 // EthEstimateGas makes a call or transaction, which won't be added to the blockchain and returns the used gas, which can be used for estimating the used gas.
@@ -487,7 +706,7 @@ func (rpc *FlashXRoute) getBlock(method string, withTransactions bool, params ..
 		response = new(proxyBlockWithoutTransactions)
 	}
 
-	err = json.Unmarshal(result, response)
+	err = rpc.decode(result, response)
 	if err != nil {
 		return nil, err
 	}
@@ -498,7 +717,24 @@ func (rpc *FlashXRoute) getBlock(method string, withTransactions bool, params ..
 
 // EthGetBlockByHash returns information about a block by hash.
 func (rpc *FlashXRoute) EthGetBlockByHash(hash string, withTransactions bool) (*Block, error) {
-	return rpc.getBlock("eth_getBlockByHash", withTransactions, hash, withTransactions)
+	if err := rpc.checkHash(hash); err != nil {
+		return nil, err
+	}
+
+	if rpc.cache == nil {
+		return rpc.getBlock("eth_getBlockByHash", withTransactions, hash, withTransactions)
+	}
+
+	key := blockKey(hash, withTransactions)
+	if block, ok := cached(rpc.cache, rpc.cache.blocks, key); ok {
+		return &block, nil
+	}
+	block, err := rpc.getBlock("eth_getBlockByHash", withTransactions, hash, withTransactions)
+	if err == nil && block != nil {
+		store(rpc.cache, rpc.cache.blocks, key, *block)
+	}
+
+	return block, err
 }
 
 // EthGetBlockByNumber returns information about a block by block number.
@@ -506,6 +742,57 @@ func (rpc *FlashXRoute) EthGetBlockByNumber(number int, withTransactions bool) (
 	return rpc.getBlock("eth_getBlockByNumber", withTransactions, IntToHex(number), withTransactions)
 }
 
+// EthGetBlockByTag returns information about a block by tag, e.g. the latest, pending or finalized block.
+func (rpc *FlashXRoute) EthGetBlockByTag(tag BlockTag, withTransactions bool) (*Block, error) {
+	return rpc.getBlock("eth_getBlockByNumber", withTransactions, string(tag), withTransactions)
+}
+
+func (rpc *FlashXRoute) getBlockHeader(method string, params ...interface{}) (*BlockHeader, error) {
+	result, err := rpc.RawCall(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(result, []byte("null")) {
+		return nil, nil
+	}
+
+	response := new(proxyBlockHeader)
+	if err := rpc.decode(result, response); err != nil {
+		return nil, err
+	}
+
+	header := response.toHeader()
+	return &header, nil
+}
+
+// EthGetBlockHeaderByHash returns the header of a block by hash, transactions and uncles are not decoded.
+func (rpc *FlashXRoute) EthGetBlockHeaderByHash(hash string) (*BlockHeader, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return nil, err
+	}
+
+	return rpc.getBlockHeader("eth_getBlockByHash", hash, false)
+}
+
+// EthGetBlockHeaderByNumber returns the header of a block by block number, transactions and uncles are not decoded.
+func (rpc *FlashXRoute) EthGetBlockHeaderByNumber(number int) (*BlockHeader, error) {
+	return rpc.getBlockHeader("eth_getBlockByNumber", IntToHex(number), false)
+}
+
+// EthGetUncleByBlockHashAndIndex returns information about an uncle of a block by hash and uncle index position.
+func (rpc *FlashXRoute) EthGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return nil, err
+	}
+
+	return rpc.getBlock("eth_getUncleByBlockHashAndIndex", false, hash, IntToHex(index))
+}
+
+// EthGetUncleByBlockNumberAndIndex returns information about an uncle of a block by number and uncle index position.
+func (rpc *FlashXRoute) EthGetUncleByBlockNumberAndIndex(number, index int) (*Block, error) {
+	return rpc.getBlock("eth_getUncleByBlockNumberAndIndex", false, IntToHex(number), IntToHex(index))
+}
+
 func (rpc *FlashXRoute) getTransaction(method string, params ...interface{}) (*Transaction, error) {
 	transaction := new(Transaction)
 
@@ -515,11 +802,46 @@ func (rpc *FlashXRoute) getTransaction(method string, params ...interface{}) (*T
 
 // EthGetTransactionByHash returns the information about a transaction requested by transaction hash.
 func (rpc *FlashXRoute) EthGetTransactionByHash(hash string) (*Transaction, error) {
-	return rpc.getTransaction("eth_getTransactionByHash", hash)
+	if err := rpc.checkHash(hash); err != nil {
+		return nil, err
+	}
+
+	if rpc.cache == nil {
+		return rpc.getTransaction("eth_getTransactionByHash", hash)
+	}
+
+	if tx, ok := cached(rpc.cache, rpc.cache.txs, cacheKey(hash)); ok {
+		return &tx, nil
+	}
+	tx, err := rpc.getTransaction("eth_getTransactionByHash", hash)
+	if err == nil && !tx.IsPending() && rpc.final(*tx.BlockNumber) {
+		store(rpc.cache, rpc.cache.txs, cacheKey(hash), *tx)
+	}
+
+	return tx, err
+}
+
+// EthGetRawTransactionByHash returns the signed raw bytes of a transaction by transaction hash as 0x prefixed hex,
+// empty when the node doesn't know the transaction.
+func (rpc *FlashXRoute) EthGetRawTransactionByHash(hash string) (string, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return "", err
+	}
+
+	var raw *string
+	if err := rpc.call("eth_getRawTransactionByHash", &raw, hash); err != nil || raw == nil {
+		return "", err
+	}
+
+	return *raw, nil
 }
 
 // EthGetTransactionByBlockHashAndIndex returns information about a transaction by block hash and transaction index position.
 func (rpc *FlashXRoute) EthGetTransactionByBlockHashAndIndex(blockHash string, transactionIndex int) (*Transaction, error) {
+	if err := rpc.checkHash(blockHash); err != nil {
+		return nil, err
+	}
+
 	return rpc.getTransaction("eth_getTransactionByBlockHashAndIndex", blockHash, IntToHex(transactionIndex))
 }
 
@@ -531,16 +853,38 @@ func (rpc *FlashXRoute) EthGetTransactionByBlockNumberAndIndex(blockNumber, tran
 // EthGetTransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note That the receipt is not available for pending transactions.
 func (rpc *FlashXRoute) EthGetTransactionReceipt(hash string) (*TransactionReceipt, error) {
+	if err := rpc.checkHash(hash); err != nil {
+		return nil, err
+	}
+
+	if rpc.cache != nil {
+		if receipt, ok := cached(rpc.cache, rpc.cache.receipts, cacheKey(hash)); ok {
+			return &receipt, nil
+		}
+	}
+
 	transactionReceipt := new(TransactionReceipt)
 
 	err := rpc.call("eth_getTransactionReceipt", transactionReceipt, hash)
 	if err != nil {
 		return nil, err
 	}
+	if rpc.cache != nil && transactionReceipt.BlockHash != "" && rpc.final(transactionReceipt.BlockNumber) {
+		store(rpc.cache, rpc.cache.receipts, cacheKey(hash), *transactionReceipt)
+	}
 
 	return transactionReceipt, nil
 }
 
+// EthGetBlockReceipts returns the receipts of all transactions in a block, block is a hex number, hash or tag.
+// Not every node supports this method, see GetTransactionReceipts for a fallback.
+func (rpc *FlashXRoute) EthGetBlockReceipts(block string) ([]TransactionReceipt, error) {
+	receipts := []TransactionReceipt{}
+
+	err := rpc.call("eth_getBlockReceipts", &receipts, block)
+	return receipts, err
+}
+
 // EthGetCompilers returns a list of available compilers in the client.
 func (rpc *FlashXRoute) EthGetCompilers() ([]string, error) {
 	compilers := []string{}
@@ -586,6 +930,14 @@ func (rpc *FlashXRoute) EthGetFilterChanges(filterID string) ([]Log, error) {
 	return logs, err
 }
 
+// EthGetFilterChangesHashes polling method for a block or pending transaction filter, which returns an array of block
+// or transaction hashes which occurred since last poll.
+func (rpc *FlashXRoute) EthGetFilterChangesHashes(filterID string) ([]string, error) {
+	var hashes = []string{}
+	err := rpc.call("eth_getFilterChanges", &hashes, filterID)
+	return hashes, err
+}
+
 // EthGetFilterLogs returns an array of all logs matching filter with given id.
 func (rpc *FlashXRoute) EthGetFilterLogs(filterID string) ([]Log, error) {
 	var logs = []Log{}
@@ -607,11 +959,13 @@ func (rpc *FlashXRoute) Eth1() *big.Int {
 
 // Eth1 returns 1 ethereum value (10^18 wei)
 func Eth1() *big.Int {
-	return big.NewInt(1000000000000000000)
+	return big.NewInt(Ether)
 }
 
 // https://docs.bloxroute.com/apis/mev-solution/bundle-simulation
+// Raw transactions are sent without 0x prefix, they are accepted with or without it.
 func (rpc *FlashXRoute) BloxrouteSimulateBundle(authHeader string, params BloxrouteSimulateBundleRequest) (res BloxrouteSimulateBundleResponse, err error) {
+	params.Transaction = mapHex(params.Transaction, StripHexPrefix)
 	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("blxr_simulate_bundle", authHeader, params)
 	if err != nil {
 		return res, err
@@ -623,6 +977,7 @@ func (rpc *FlashXRoute) BloxrouteSimulateBundle(authHeader string, params Bloxro
 
 // https://docs.bloxroute.com/apis/mev-solution/arb-only-bundle-simulation
 func (rpc *FlashXRoute) BloxrouteBrmSimulateBundle(authHeader string, params BloxrouteBrmSimulateBundleRequest) (res BloxrouteSimulateBundleResponse, err error) {
+	params.Transaction = mapHex(params.Transaction, StripHexPrefix)
 	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("simulate_arb_only_bundle", authHeader, params)
 	if err != nil {
 		return res, err
@@ -632,49 +987,93 @@ func (rpc *FlashXRoute) BloxrouteBrmSimulateBundle(authHeader string, params Blo
 }
 
 // https://docs.bloxroute.com/apis/mev-solution/bundle-submission
+// Raw transactions are sent without 0x prefix, they are accepted with or without it.
 func (rpc *FlashXRoute) BloxrouteSubmitBundle(authHeader string, params BloxrouteSubmitBundleRequest) (res BloxrouteSubmitBundleResponse, err error) {
-	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("blxr_submit_bundle", authHeader, params)
-	if err != nil {
-		return res, err
+	return rpc.BloxrouteSubmitBundleContext(context.Background(), authHeader, params)
+}
+
+// BloxrouteSubmitBundleContext is like BloxrouteSubmitBundle, the submission carries the headers of ContextWithHeaders
+// and fills the CallInfo of ContextWithCallInfo
+func (rpc *FlashXRoute) BloxrouteSubmitBundleContext(ctx context.Context, authHeader string, params BloxrouteSubmitBundleRequest) (res BloxrouteSubmitBundleResponse, err error) {
+	return rpc.bloxrouteSubmitBundle(ctx, authHeader, params, true)
+}
+
+// bloxrouteSubmitBundle submits params with blxr_submit_bundle, checked by the profit guard when guarded
+func (rpc *FlashXRoute) bloxrouteSubmitBundle(ctx context.Context, authHeader string, params BloxrouteSubmitBundleRequest, guarded bool) (res BloxrouteSubmitBundleResponse, err error) {
+	params.Transaction = mapHex(params.Transaction, StripHexPrefix)
+	params.MinTimestamp, params.MaxTimestamp = rpc.clock.adjusted(params.MinTimestamp, params.MaxTimestamp)
+	if rpc.policy != nil {
+		if err := rpc.policy.Apply(rpc.network, &params); err != nil {
+			return res, err
+		}
 	}
-	err = json.Unmarshal(rawMsg, &res)
+	if guarded {
+		if err := rpc.guardBundle(authHeader, params.Transaction, params.BlockNumber, params.MinTimestamp); err != nil {
+			return res, err
+		}
+	}
+	ctx, duplicate := contextWithDuplicateFlag(ctx)
+	rawMsg, err := rpc.CallWithBloxrouteAuthHeaderContext(ctx, "blxr_submit_bundle", authHeader, params)
+	if err == nil {
+		err = json.Unmarshal(rawMsg, &res)
+	}
+	rpc.bundleSubmitted("blxr_submit_bundle", params.BlockNumber, params.Transaction, params.CoinbaseProfit, res.BundleHash, err, *duplicate)
 	return res, err
 }
 
+// BloxrouteCancelBundle cancels the bundle submitted with uuid for blockNumber by submitting an empty bundle with the
+// same uuid, the profit guard and policy aren't applied
+func (rpc *FlashXRoute) BloxrouteCancelBundle(authHeader, uuid, blockNumber string) error {
+	params := BloxrouteSubmitBundleRequest{Transaction: []string{}, BlockNumber: blockNumber, Uuid: uuid}
+	_, err := rpc.CallWithBloxrouteAuthHeader("blxr_submit_bundle", authHeader, params)
+	return err
+}
+
 // https://docs.bloxroute.com/apis/mev-solution/arb-only-bundle-submission
 func (rpc *FlashXRoute) BloxrouteBrmSubmitBundle(authHeader string, params BloxrouteBrmSubmitBundleRequest) (res BloxrouteSubmitBundleResponse, err error) {
-	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("submit_arb_only_bundle", authHeader, params)
-	if err != nil {
+	params.Transaction = mapHex(params.Transaction, StripHexPrefix)
+	params.MinTimestamp, params.MaxTimestamp = rpc.clock.adjusted(params.MinTimestamp, params.MaxTimestamp)
+	if err := rpc.guardBundle(authHeader, params.Transaction, params.BlockNumber, params.MinTimestamp); err != nil {
 		return res, err
 	}
-	err = json.Unmarshal(rawMsg, &res)
+	ctx, duplicate := contextWithDuplicateFlag(context.Background())
+	rawMsg, err := rpc.CallWithBloxrouteAuthHeaderContext(ctx, "submit_arb_only_bundle", authHeader, params)
+	if err == nil {
+		err = json.Unmarshal(rawMsg, &res)
+	}
+	rpc.bundleSubmitted("submit_arb_only_bundle", params.BlockNumber, params.Transaction, nil, res.BundleHash, err, *duplicate)
 	return res, err
 }
 
 // Simulate a full Ethereum block. numTx is the maximum number of tx to include, used for troubleshooting (default: 0 - all transactions)
 func (rpc *FlashXRoute) BloxrouteSimulateBlock(authHeader string, block *types.Block, maxTx int) (res BloxrouteSimulateBundleResponse, err error) {
-	if rpc.Debug {
-		fmt.Printf("Simulating block %s 0x%x %s \t %d tx \t timestamp: %d\n", block.Number(), block.Number(), block.Header().Hash(), len(block.Transactions()), block.Header().Time)
-	}
+	return rpc.BloxrouteSimulateBlockWithOptions(authHeader, block, SimulateBlockOptions{MaxTx: maxTx})
+}
+
+// BloxrouteSimulateBlockWithOptions simulates the transactions of a block with the given options
+func (rpc *FlashXRoute) BloxrouteSimulateBlockWithOptions(authHeader string, block *types.Block, options SimulateBlockOptions) (res BloxrouteSimulateBundleResponse, err error) {
+	rpc.debugf(SubsystemBundles, LogRequests, "Simulating block %s 0x%x %s \t %d tx \t timestamp: %d", block.Number(), block.Number(), block.Header().Hash(), len(block.Transactions()), block.Header().Time)
 
 	txs := make([]string, 0)
 	for _, tx := range block.Transactions() {
-		// fmt.Println("tx", i, tx.Hash(), "type", tx.Type())
-		from, fromErr := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
-		txIsFromCoinbase := fromErr == nil && from == block.Coinbase()
-		if txIsFromCoinbase {
-			if rpc.Debug {
-				fmt.Printf("- skip tx from coinbase: %s\n", tx.Hash())
+		if !options.IncludeCoinbaseTxs {
+			from, fromErr := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			txIsFromCoinbase := fromErr == nil && from == block.Coinbase()
+			if txIsFromCoinbase {
+				rpc.debugf(SubsystemBundles, LogBodies, "- skip tx from coinbase: %s", tx.Hash())
+				continue
 			}
-			continue
-		}
 
-		to := tx.To()
-		txIsToCoinbase := to != nil && *to == block.Coinbase()
-		if txIsToCoinbase {
-			if rpc.Debug {
-				fmt.Printf("- skip tx to coinbase: %s\n", tx.Hash())
+			to := tx.To()
+			txIsToCoinbase := to != nil && *to == block.Coinbase()
+			if txIsToCoinbase {
+				rpc.debugf(SubsystemBundles, LogBodies, "- skip tx to coinbase: %s", tx.Hash())
+				continue
 			}
+		}
+
+		if options.Filter != nil && !options.Filter(tx) {
+			rpc.debugf(SubsystemBundles, LogBodies, "- skip filtered tx: %s", tx.Hash())
 			continue
 		}
 
@@ -687,23 +1086,34 @@ func (rpc *FlashXRoute) BloxrouteSimulateBlock(authHeader string, block *types.B
 			rlp = rlp[4:]
 		}
 
-		// callBundle expects a 0x prefix
-		rlp = "0x" + rlp
 		txs = append(txs, rlp)
 
-		if maxTx > 0 && len(txs) == maxTx {
+		if options.MaxTx > 0 && len(txs) == options.MaxTx {
 			break
 		}
 	}
 
-	if rpc.Debug {
-		fmt.Printf("sending %d tx for simulation to %s...\n", len(txs), rpc.url)
+	if options.StateBlock == "" {
+		options.StateBlock = block.ParentHash().Hex()
 	}
 
+	return rpc.BloxrouteSimulateRawBlock(authHeader, block.NumberU64(), txs, options)
+}
+
+// BloxrouteSimulateRawBlock simulates pre-encoded raw transactions as the given block, only MaxTx, StateBlock
+// (default: latest) and Timestamp options apply
+func (rpc *FlashXRoute) BloxrouteSimulateRawBlock(authHeader string, blockNumber uint64, txs []string, options SimulateBlockOptions) (res BloxrouteSimulateBundleResponse, err error) {
+	if options.MaxTx > 0 && len(txs) > options.MaxTx {
+		txs = txs[:options.MaxTx]
+	}
+
+	rpc.debugf(SubsystemBundles, LogRequests, "sending %d tx for simulation to %s...", len(txs), rpc.url)
+
 	params := BloxrouteSimulateBundleRequest{
 		Transaction:      txs,
-		BlockNumber:      fmt.Sprintf("0x%x", block.Number()),
-		StateBlockNumber: block.ParentHash().Hex(),
+		BlockNumber:      Uint64ToHex(blockNumber),
+		StateBlockNumber: options.StateBlock,
+		Timestamp:        options.Timestamp,
 	}
 
 	res, err = rpc.BloxrouteSimulateBundle(authHeader, params)
@@ -711,7 +1121,15 @@ func (rpc *FlashXRoute) BloxrouteSimulateBlock(authHeader string, block *types.B
 }
 
 // This endpoint allows you to send a single transaction that will be distributed faster using the BDN.
+// Setting ValidatorsOnly fails with ErrValidatorsOnlyUnsupported on networks without semi-private transactions.
 func (rpc *FlashXRoute) BloxrouteSendTransaction(authHeader string, params BloxrouteSendTransactionRequest) (txHash string, err error) {
+	params.Transaction = StripHexPrefix(params.Transaction)
+	if params.BlockchainNetwork == "" {
+		params.BlockchainNetwork = rpc.network
+	}
+	if err := checkValidatorsOnly(params); err != nil {
+		return "", err
+	}
 	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("blxr_tx", authHeader, params)
 	if err != nil {
 		return "", err
@@ -720,8 +1138,19 @@ func (rpc *FlashXRoute) BloxrouteSendTransaction(authHeader string, params Bloxr
 	return txHash, err
 }
 
+// BloxrouteSendSignedTransaction is like BloxrouteSendTransaction but encodes the signed transaction into params.Transaction
+func (rpc *FlashXRoute) BloxrouteSendSignedTransaction(authHeader string, tx *types.Transaction, params BloxrouteSendTransactionRequest) (txHash string, err error) {
+	data, err := TxToHex(tx)
+	if err != nil {
+		return "", err
+	}
+	params.Transaction = data
+	return rpc.BloxrouteSendTransaction(authHeader, params)
+}
+
 // This endpoint allows you to send a private transaction that will be distributed faster using the BDN.
 func (rpc *FlashXRoute) BloxrouteSendPrivateTransaction(authHeader string, params BloxrouteSendPrivateTransactionRequest) (txHash string, err error) {
+	params.Transaction = StripHexPrefix(params.Transaction)
 	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("blxr_private_tx", authHeader, params)
 	if err != nil {
 		return "", err
@@ -729,3 +1158,13 @@ func (rpc *FlashXRoute) BloxrouteSendPrivateTransaction(authHeader string, param
 	err = json.Unmarshal(rawMsg, &txHash)
 	return txHash, err
 }
+
+// BloxrouteSendSignedPrivateTransaction is like BloxrouteSendPrivateTransaction but encodes the signed transaction into params.Transaction
+func (rpc *FlashXRoute) BloxrouteSendSignedPrivateTransaction(authHeader string, tx *types.Transaction, params BloxrouteSendPrivateTransactionRequest) (txHash string, err error) {
+	data, err := TxToHex(tx)
+	if err != nil {
+		return "", err
+	}
+	params.Transaction = data
+	return rpc.BloxrouteSendPrivateTransaction(authHeader, params)
+}
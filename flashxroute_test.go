@@ -9,10 +9,14 @@ import (
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/require"
@@ -35,6 +39,53 @@ func (s *FlashXRouteTestSuite) registerResponse(result string, callback func([]b
 	})
 }
 
+// registerMethods responds to each method with its result, keyed by method name or by method name and compact
+// params like `eth_getBalance ["0x1","latest"]` to tell calls apart. Results prefixed with "error:" are sent as errors.
+// Batches are answered entry by entry.
+func (s *FlashXRouteTestSuite) registerMethods(results map[string]string) {
+	respond := func(request gjson.Result) string {
+		method := request.Get("method").String()
+		result, ok := results[method+" "+request.Get("params").Raw]
+		if !ok {
+			result, ok = results[method]
+		}
+		s.Require().True(ok, "unexpected call %s", request.Raw)
+
+		id := request.Get("id").Raw
+		if strings.HasPrefix(result, "error:") {
+			return fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "error": %s}`, id, strings.TrimPrefix(result, "error:"))
+		}
+		return fmt.Sprintf(`{"jsonrpc":"2.0", "id":%s, "result": %s}`, id, result)
+	}
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := gjson.ParseBytes(s.getBody(request))
+		if !body.IsArray() {
+			return httpmock.NewStringResponse(200, respond(body)), nil
+		}
+
+		var responses []string
+		for _, entry := range body.Array() {
+			responses = append(responses, respond(entry))
+		}
+		return httpmock.NewStringResponse(200, "["+strings.Join(responses, ",")+"]"), nil
+	})
+}
+
+// serve starts a test server for calls bypassing the mocked default transport (bloXroute calls) and routes mocked
+// calls to the same URL to its handler
+func (s *FlashXRouteTestSuite) serve(handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	httpmock.RegisterResponder("POST", server.URL, func(request *http.Request) (*http.Response, error) {
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		return recorder.Result(), nil
+	})
+
+	return server
+}
+
 func (s *FlashXRouteTestSuite) registerResponseError(err error) {
 	httpmock.Reset()
 	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
@@ -559,6 +610,32 @@ func (s *FlashXRouteTestSuite) TestEthSendRawTransaction() {
 	s.Require().Equal(result, txid)
 }
 
+func (s *FlashXRouteTestSuite) TestEthSendSignedTransaction() {
+	to := common.HexToAddress("0x1bf21cb1dc384d019a885a06973f7308")
+	tx, err := types.SignNewTx(s.privKey, types.NewLondonSigner(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(50000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	s.Require().Nil(err)
+	data, err := tx.MarshalBinary()
+	s.Require().Nil(err)
+
+	result := "0xe670ec64341771606e55d6b4ca35a1a6b75ee3d5145a99d05921026d1527331"
+	s.registerResponse(fmt.Sprintf(`"%s"`, result), func(body []byte) {
+		s.methodEqual(body, "eth_sendRawTransaction")
+		s.paramsEqual(body, fmt.Sprintf(`["0x%x"]`, data))
+	})
+
+	txid, err := s.rpc.EthSendSignedTransaction(tx)
+	s.Require().Nil(err)
+	s.Require().Equal(result, txid)
+}
+
 func (s *FlashXRouteTestSuite) TestEthGetCompilers() {
 	s.registerResponse(`["solidity", "some comp"]`, func(body []byte) {
 		s.methodEqual(body, "eth_getCompilers")
@@ -802,6 +879,104 @@ func (s *FlashXRouteTestSuite) TestEthGetBlockByNumber() {
 	s.Require().Nil(err)
 }
 
+func (s *FlashXRouteTestSuite) TestEthGetBlockByTag() {
+	s.registerResponse(`{"number": "0x10"}`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockByNumber")
+		s.paramsEqual(body, `["finalized", true]`)
+	})
+
+	block, err := s.rpc.EthGetBlockByTag(BlockFinalized, true)
+	s.Require().Nil(err)
+	s.Require().Equal(16, block.Number)
+
+	httpmock.Reset()
+
+	s.registerResponse(`{}`, func(body []byte) {
+		s.paramsEqual(body, `["0x37f2", false]`)
+	})
+
+	_, err = s.rpc.EthGetBlockByTag(BlockNumberTag(14322), false)
+	s.Require().Nil(err)
+}
+
+func (s *FlashXRouteTestSuite) TestEthGetBlockWithdrawals() {
+	result := `{"number": "0x1", "withdrawalsRoot": "0xabc", "transactions": [], "withdrawals": [
+		{"index": "0x1a", "validatorIndex": "0x3e8", "address": "0xB9D7934878B5FB9610B3FE8A5E441E8FAD7E293F", "amount": "0xde0b6b3a"},
+		{"index": "0x1b", "validatorIndex": "0x3e9", "address": "0x1111111111111111111111111111111111111111", "amount": "0x1"},
+		{"index": "0x1c", "validatorIndex": "0x3ea", "address": "0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f", "amount": "0x2"}]}`
+	for _, withTransactions := range []bool{true, false} {
+		s.registerResponse(result, func(body []byte) {})
+
+		block, err := s.rpc.EthGetBlockByTag(BlockLatest, withTransactions)
+		s.Require().Nil(err)
+		s.Require().Equal("0xabc", block.WithdrawalsRoot)
+		s.Require().Len(block.Withdrawals, 3)
+		s.Require().Equal(Withdrawal{Index: 26, ValidatorIndex: 1000, Address: "0xB9D7934878B5FB9610B3FE8A5E441E8FAD7E293F", Amount: 3725290298}, block.Withdrawals[0])
+		s.Require().Equal("3725290298000000000", block.Withdrawals[0].AmountWei().String())
+		s.Require().Equal("3725290300000000000", block.WithdrawalsTo("0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f").String())
+
+		httpmock.Reset()
+	}
+}
+
+func (s *FlashXRouteTestSuite) TestEthGetBlockHeader() {
+	result := `{"number": "0xf4240", "hash": "0xabc", "parentHash": "0xdef", "receiptsRoot": "0x123", "gasLimit": "0x1c9c380",
+		"gasUsed": "0xe4e1c0", "baseFeePerGas": "0x2cb417800", "transactions": ["0x1", "0x2"]}`
+	s.registerResponse(result, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockByNumber")
+		s.paramsEqual(body, `["0xf4240", false]`)
+	})
+
+	header, err := s.rpc.EthGetBlockHeaderByNumber(1000000)
+	s.Require().Nil(err)
+	s.Require().Equal(1000000, header.Number)
+	s.Require().Equal("0xabc", header.Hash)
+	s.Require().Equal("0x123", header.ReceiptsRoot)
+	s.Require().Equal(30000000, header.GasLimit)
+	s.Require().Equal(15000000, header.GasUsed)
+	s.Require().Equal(int64(12*GWei), header.BaseFeePerGas.Int64())
+
+	httpmock.Reset()
+	s.registerResponse(`null`, func(body []byte) {
+		s.methodEqual(body, "eth_getBlockByHash")
+		s.paramsEqual(body, `["0x111", false]`)
+	})
+
+	header, err = s.rpc.EthGetBlockHeaderByHash("0x111")
+	s.Require().Nil(err)
+	s.Require().Nil(header)
+}
+
+func (s *FlashXRouteTestSuite) TestEthGetUncleByBlockHashAndIndex() {
+	s.registerResponse(`{"number": "0x10", "hash": "0xabc", "transactions": []}`, func(body []byte) {
+		s.methodEqual(body, "eth_getUncleByBlockHashAndIndex")
+		s.paramsEqual(body, `["0x111", "0x1"]`)
+	})
+
+	uncle, err := s.rpc.EthGetUncleByBlockHashAndIndex("0x111", 1)
+	s.Require().Nil(err)
+	s.Require().Equal(16, uncle.Number)
+	s.Require().Equal("0xabc", uncle.Hash)
+
+	httpmock.Reset()
+	s.registerResponse(`null`, func(body []byte) {})
+
+	uncle, err = s.rpc.EthGetUncleByBlockHashAndIndex("0x111", 5)
+	s.Require().Nil(err)
+	s.Require().Nil(uncle)
+}
+
+func (s *FlashXRouteTestSuite) TestEthGetUncleByBlockNumberAndIndex() {
+	s.registerResponse(`{"number": "0x10"}`, func(body []byte) {
+		s.methodEqual(body, "eth_getUncleByBlockNumberAndIndex")
+		s.paramsEqual(body, `["0xa", "0x0"]`)
+	})
+
+	uncle, err := s.rpc.EthGetUncleByBlockNumberAndIndex(10, 0)
+	s.Require().Nil(err)
+	s.Require().Equal(16, uncle.Number)
+}
+
 func (s *FlashXRouteTestSuite) TestEthCall() {
 	s.registerResponse(`"0x11"`, func(body []byte) {
 		s.methodEqual(body, "eth_call")
@@ -883,6 +1058,8 @@ func (s *FlashXRouteTestSuite) TestEthGetTransactionReceipt() {
 	s.Require().Equal("0x001", receipt.LogsBloom)
 	s.Require().Equal("0x55b68780caee96e686eb398371bb679574d4b995614ae94243da4886059a47ee", receipt.Root)
 	s.Require().Equal("0x1", receipt.Status)
+	s.Require().Equal(ReceiptSuccess, receipt.ExecutionStatus())
+	s.Require().True(receipt.Succeeded())
 	s.Require().Equal(1, len(receipt.Logs))
 	s.Require().Equal(Log{
 		Removed:          false,
@@ -1068,6 +1245,26 @@ func (s *FlashXRouteTestSuite) TestEthGetFilterChanges() {
 	}, logs)
 }
 
+func (s *FlashXRouteTestSuite) TestEthGetFilterChangesHashes() {
+	filterID := "0x6996a3a4788d4f2067108d1f536d4330"
+	s.registerResponse(`["0x9d9838090bb7f6194f62acea788688435b79cc44c62dcf1479abd9f2c72a7d5c", "0x78e4fc71ff7e525b3b4660a76336a2046232fd9bba9c65abb22fa3d07d6e7066"]`, func(body []byte) {
+		s.methodEqual(body, "eth_getFilterChanges")
+		s.paramsEqual(body, fmt.Sprintf(`["%s"]`, filterID))
+	})
+
+	hashes, err := s.rpc.EthGetFilterChangesHashes(filterID)
+	s.Require().Nil(err)
+	s.Require().Equal([]string{
+		"0x9d9838090bb7f6194f62acea788688435b79cc44c62dcf1479abd9f2c72a7d5c",
+		"0x78e4fc71ff7e525b3b4660a76336a2046232fd9bba9c65abb22fa3d07d6e7066",
+	}, hashes)
+
+	s.registerResponse(`[]`, func(body []byte) {})
+	hashes, err = s.rpc.EthGetFilterChangesHashes(filterID)
+	s.Require().Nil(err)
+	s.Require().Empty(hashes)
+}
+
 func (s *FlashXRouteTestSuite) TestEthGetFilterLogs() {
 	filterID := "0x6996a3a4788d4f2067108d1f536d4330"
 	result := `[{
@@ -1214,6 +1411,23 @@ func TestEth1(t *testing.T) {
 	require.Equal(t, int64(1000000000000000000), client.Eth1().Int64())
 }
 
+func TestClone(t *testing.T) {
+	base := NewFlashXRoute("https://relay.example", WithHeader("X-Base", "1"), WithTimeout(5*time.Second))
+
+	clone := base.Clone()
+	clone.Headers["X-Clone"] = "2"
+	require.Equal(t, base.URL(), clone.URL())
+	require.Equal(t, 5*time.Second, clone.Timeout)
+	require.NotContains(t, base.Headers, "X-Clone")
+
+	derived := base.With(WithURL("https://other.example"), WithHeader("Authorization", "secret"))
+	require.Equal(t, "https://other.example", derived.URL())
+	require.Equal(t, "1", derived.Headers["X-Base"])
+	require.Equal(t, "secret", derived.Headers["Authorization"])
+	require.Equal(t, "https://relay.example", base.URL())
+	require.NotContains(t, base.Headers, "Authorization")
+}
+
 func ptrInt(i int) *int {
 	return &i
 }
@@ -0,0 +1,290 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Intrinsic gas costs of the yellow paper, EIP-2028, EIP-2930 and EIP-3860
+const (
+	txGas                     = 21000
+	txGasContractCreation     = 53000
+	txDataZeroGas             = 4
+	txDataNonZeroGas          = 16
+	txAccessListAddressGas    = 2400
+	txAccessListStorageKeyGas = 1900
+	initCodeWordGas           = 2
+)
+
+// IntrinsicGas returns the gas charged to a transaction before it executes: the base cost, its calldata, the entries
+// of its access list and, for contract creations, the initcode words
+func IntrinsicGas(data []byte, creation bool, accessListAddresses, accessListKeys int) uint64 {
+	gas := uint64(txGas)
+	if creation {
+		gas = txGasContractCreation + initCodeWordGas*uint64((len(data)+31)/32)
+	}
+	for _, b := range data {
+		if b == 0 {
+			gas += txDataZeroGas
+		} else {
+			gas += txDataNonZeroGas
+		}
+	}
+
+	return gas + txAccessListAddressGas*uint64(accessListAddresses) + txAccessListStorageKeyGas*uint64(accessListKeys)
+}
+
+// rawTxGas - gas relevant fields of a raw transaction
+type rawTxGas struct {
+	hash      string
+	gasLimit  uint64
+	intrinsic uint64
+}
+
+// rawTxGasFields - indexes of gas limit, to, data and access list in the fields of each transaction type, -1 if absent
+var rawTxGasFields = map[byte][4]int{
+	0:          {2, 3, 5, -1},
+	1:          {3, 4, 6, 7},
+	2:          {4, 5, 7, 8},
+	BlobTxType: {4, 5, 7, 8},
+}
+
+// rawTxFields returns the type and the rlp encoded fields of a raw legacy, EIP-2930, EIP-1559 or blob transaction,
+// blob transactions in network form are unwrapped
+func rawTxFields(raw string) (byte, [][]byte, error) {
+	data, err := ParseBytes(raw)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) == 0 {
+		return 0, nil, errors.Wrap(errInvalidRLP, "empty transaction")
+	}
+	txType, body := byte(0), data
+	if data[0] < 0xc0 {
+		txType, body = data[0], data[1:]
+	}
+	if _, ok := rawTxGasFields[txType]; !ok {
+		return txType, nil, errors.Errorf("unsupported transaction type %d", txType)
+	}
+
+	_, content, _, err := rlpSplit(body)
+	if err != nil {
+		return txType, nil, err
+	}
+	fields, err := rlpItems(content)
+	if err != nil {
+		return txType, nil, err
+	}
+	if txType == BlobTxType && len(fields) > 0 {
+		if list, _, _, _ := rlpSplit(fields[0]); list {
+			// network form: [body, blobs, commitments, proofs]
+			_, content, _, _ = rlpSplit(fields[0])
+			if fields, err = rlpItems(content); err != nil {
+				return txType, nil, err
+			}
+		}
+	}
+
+	return txType, fields, nil
+}
+
+// decodeRawTxGas decodes the gas limit and computes the intrinsic gas of a raw legacy, EIP-2930, EIP-1559 or blob
+// transaction
+func decodeRawTxGas(raw string) (rawTxGas, error) {
+	var tx rawTxGas
+	hash, err := RawTxHash(raw)
+	if err != nil {
+		return tx, err
+	}
+	tx.hash = hash
+
+	txType, fields, err := rawTxFields(raw)
+	if err != nil {
+		return tx, err
+	}
+	indexes := rawTxGasFields[txType]
+	if len(fields) <= indexes[2] || len(fields) <= indexes[3] {
+		return tx, errors.Wrapf(errInvalidRLP, "transaction type %d has %d fields", txType, len(fields))
+	}
+
+	_, gas, _, _ := rlpSplit(fields[indexes[0]])
+	if len(gas) > 8 {
+		return tx, errors.Wrap(errInvalidRLP, "gas limit overflows uint64")
+	}
+	for _, b := range gas {
+		tx.gasLimit = tx.gasLimit<<8 | uint64(b)
+	}
+	_, to, _, _ := rlpSplit(fields[indexes[1]])
+	_, input, _, _ := rlpSplit(fields[indexes[2]])
+
+	var addresses, keys int
+	if indexes[3] >= 0 {
+		_, content, _, _ := rlpSplit(fields[indexes[3]])
+		entries, err := rlpItems(content)
+		if err != nil {
+			return tx, err
+		}
+		for _, entry := range entries {
+			_, tuple, _, _ := rlpSplit(entry)
+			items, err := rlpItems(tuple)
+			if err != nil || len(items) != 2 {
+				return tx, errors.Wrap(errInvalidRLP, "malformed access list entry")
+			}
+			_, storageKeys, _, _ := rlpSplit(items[1])
+			slots, err := rlpItems(storageKeys)
+			if err != nil {
+				return tx, err
+			}
+			addresses, keys = addresses+1, keys+len(slots)
+		}
+	}
+	tx.intrinsic = IntrinsicGas(input, len(to) == 0, addresses, keys)
+
+	return tx, nil
+}
+
+// TxGasBreakdown - gas of a bundle transaction as simulated
+type TxGasBreakdown struct {
+	TxHash     string
+	GasLimit   uint64
+	GasUsed    uint64  // gas used in the simulation
+	Intrinsic  uint64  // charged before execution: base cost, calldata and access list
+	Execution  uint64  // GasUsed less Intrinsic
+	LimitUsage float64 // GasUsed / GasLimit
+	Simulated  bool    // false when the simulation has no result for the transaction
+}
+
+// BundleGasBreakdown - gas of a simulated bundle and the warnings of the GasAnalyzer
+type BundleGasBreakdown struct {
+	Txs           []TxGasBreakdown
+	GasLimit      uint64 // sum of the gas limits of the transactions
+	GasUsed       uint64
+	Intrinsic     uint64
+	Execution     uint64
+	BlockGasLimit uint64
+	BlockShare    float64 // GasUsed / BlockGasLimit
+	AvailableGas  uint64  // gas left unused by the average recent block, 0 when unknown
+	Warnings      []string
+}
+
+// GasAnalyzer - correlates the gas used by bundle simulations with the gas limits of the transactions and the block
+type GasAnalyzer struct {
+	LimitUsage float64 // warn when a transaction uses more than this share of its gas limit (default: 0.95)
+	BlockShare float64 // warn when the bundle uses more than this share of the block gas limit (default: 0.5)
+	Blocks     int     // recent blocks whose gasUsedRatio estimates the space left in the target block (default: 10)
+}
+
+func (a GasAnalyzer) withDefaults() GasAnalyzer {
+	if a.LimitUsage <= 0 {
+		a.LimitUsage = 0.95
+	}
+	if a.BlockShare <= 0 {
+		a.BlockShare = 0.5
+	}
+	if a.Blocks <= 0 {
+		a.Blocks = 10
+	}
+
+	return a
+}
+
+// Analyze breaks down the gas of raw transactions txs simulated as res in a block of blockGasLimit, gasUsedRatios of
+// recent blocks estimate the space left in the target block, none skips the estimate. Results are matched to
+// transactions by hash, by position when the simulation doesn't report hashes.
+func (a GasAnalyzer) Analyze(txs []string, res BloxrouteSimulateBundleResponse, blockGasLimit uint64, gasUsedRatios []float64) (BundleGasBreakdown, error) {
+	a = a.withDefaults()
+	breakdown := BundleGasBreakdown{Txs: make([]TxGasBreakdown, len(txs)), BlockGasLimit: blockGasLimit}
+
+	results := make(map[string]BloxrouteSimulateBundleResult, len(res.Results))
+	for _, result := range res.Results {
+		if result.TxHash != "" {
+			results[strings.ToLower(result.TxHash)] = result
+		}
+	}
+	for i, raw := range txs {
+		decoded, err := decodeRawTxGas(raw)
+		if err != nil {
+			return breakdown, errors.Wrapf(err, "transaction %d", i)
+		}
+		tx := TxGasBreakdown{TxHash: decoded.hash, GasLimit: decoded.gasLimit, Intrinsic: decoded.intrinsic}
+		result, ok := results[strings.ToLower(decoded.hash)]
+		if !ok && len(results) == 0 && i < len(res.Results) {
+			result, ok = res.Results[i], true
+		}
+		if ok && result.GasUsed >= 0 {
+			tx.Simulated, tx.GasUsed = true, uint64(result.GasUsed)
+		}
+		if tx.GasUsed > tx.Intrinsic {
+			tx.Execution = tx.GasUsed - tx.Intrinsic
+		}
+		if tx.GasLimit > 0 {
+			tx.LimitUsage = float64(tx.GasUsed) / float64(tx.GasLimit)
+		}
+
+		switch {
+		case !tx.Simulated:
+			breakdown.warn("tx %s has no simulation result", tx.TxHash)
+		case tx.GasLimit < tx.Intrinsic:
+			breakdown.warn("tx %s gas limit %d below its intrinsic gas %d", tx.TxHash, tx.GasLimit, tx.Intrinsic)
+		case tx.LimitUsage > a.LimitUsage:
+			breakdown.warn("tx %s used %.1f%% of its gas limit %d, a state change may run it out of gas", tx.TxHash, 100*tx.LimitUsage, tx.GasLimit)
+		}
+
+		breakdown.Txs[i] = tx
+		breakdown.GasLimit += tx.GasLimit
+		breakdown.GasUsed += tx.GasUsed
+		breakdown.Intrinsic += tx.Intrinsic
+		breakdown.Execution += tx.Execution
+	}
+
+	if blockGasLimit == 0 {
+		return breakdown, nil
+	}
+	breakdown.BlockShare = float64(breakdown.GasUsed) / float64(blockGasLimit)
+	switch {
+	case breakdown.GasLimit > blockGasLimit:
+		breakdown.warn("bundle gas limit %d exceeds the block gas limit %d", breakdown.GasLimit, blockGasLimit)
+	case breakdown.BlockShare > a.BlockShare:
+		breakdown.warn("bundle uses %.1f%% of the block gas limit %d", 100*breakdown.BlockShare, blockGasLimit)
+	}
+
+	if len(gasUsedRatios) > 0 {
+		var sum float64
+		for _, ratio := range gasUsedRatios {
+			sum += ratio
+		}
+		if average := sum / float64(len(gasUsedRatios)); average < 1 {
+			breakdown.AvailableGas = uint64((1 - average) * float64(blockGasLimit))
+		}
+		if breakdown.GasUsed > breakdown.AvailableGas {
+			breakdown.warn("bundle uses %d gas, recent blocks left only %d unused", breakdown.GasUsed, breakdown.AvailableGas)
+		}
+	}
+
+	return breakdown, nil
+}
+
+func (b *BundleGasBreakdown) warn(format string, args ...interface{}) {
+	b.Warnings = append(b.Warnings, fmt.Sprintf(format, args...))
+}
+
+// AnalyzeBundleGas analyzes the gas of raw transactions txs simulated as res with the gas limit of the latest block
+// and the gasUsedRatio of the analyzer's recent blocks
+func (rpc *FlashXRoute) AnalyzeBundleGas(analyzer GasAnalyzer, txs []string, res BloxrouteSimulateBundleResponse) (BundleGasBreakdown, error) {
+	analyzer = analyzer.withDefaults()
+	head, err := rpc.getBlockHeader("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return BundleGasBreakdown{}, err
+	}
+	if head == nil {
+		return BundleGasBreakdown{}, errors.New("latest block not found")
+	}
+	history, err := rpc.EthFeeHistory(analyzer.Blocks, "latest", nil)
+	if err != nil {
+		return BundleGasBreakdown{}, err
+	}
+
+	return analyzer.Analyze(txs, res, uint64(head.GasLimit), history.GasUsedRatio)
+}
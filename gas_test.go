@@ -0,0 +1,90 @@
+package flashxroute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrinsicGas(t *testing.T) {
+	require.Equal(t, uint64(21000), IntrinsicGas(nil, false, 0, 0))
+	require.Equal(t, uint64(21020), IntrinsicGas([]byte{0, 1}, false, 0, 0))
+	// 2 initcode words, 33 non-zero bytes, 1 address and 2 storage keys
+	require.Equal(t, uint64(53000+4+33*16+2400+2*1900), IntrinsicGas(nonZeroBytes(33), true, 1, 2))
+}
+
+func nonZeroBytes(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = 0xff
+	}
+	return data
+}
+
+// gasTestTxs returns a legacy call and an EIP-1559 contract creation with an access list
+func gasTestTxs() []string {
+	legacy := rlpList(rlpBytes(nil), rlpBytes([]byte{1}), rlpBytes([]byte{0x01, 0x86, 0xa0}), rlpBytes(make([]byte, 20)),
+		rlpBytes(nil), rlpBytes([]byte{0, 1}), rlpBytes([]byte{27}), rlpBytes([]byte{1}), rlpBytes([]byte{1}))
+	accessList := rlpList(rlpList(rlpBytes(make([]byte, 20)), rlpList(rlpBytes(make([]byte, 32)), rlpBytes(make([]byte, 32)))))
+	dynamic := rlpList(rlpBytes([]byte{1}), rlpBytes(nil), rlpBytes([]byte{1}), rlpBytes([]byte{2}), rlpBytes([]byte{0xea, 0x60}),
+		rlpBytes(nil), rlpBytes(nil), rlpBytes(nonZeroBytes(33)), accessList, rlpBytes(nil), rlpBytes([]byte{1}), rlpBytes([]byte{1}))
+
+	return []string{BytesToHex(legacy), BytesToHex(append([]byte{2}, dynamic...))}
+}
+
+func TestGasAnalyzer(t *testing.T) {
+	txs := gasTestTxs()
+	hashes := make([]string, len(txs))
+	for i, raw := range txs {
+		hashes[i], _ = RawTxHash(raw)
+	}
+	res := BloxrouteSimulateBundleResponse{Results: []BloxrouteSimulateBundleResult{
+		{TxHash: hashes[1], GasUsed: 59900},
+		{TxHash: hashes[0], GasUsed: 30000},
+	}}
+
+	breakdown, err := GasAnalyzer{}.Analyze(txs, res, 30000000, []float64{0.5})
+	require.Nil(t, err)
+	require.Equal(t, TxGasBreakdown{TxHash: hashes[0], GasLimit: 100000, GasUsed: 30000, Intrinsic: 21020, Execution: 8980, LimitUsage: 0.3, Simulated: true}, breakdown.Txs[0])
+	require.Equal(t, uint64(59732), breakdown.Txs[1].Intrinsic)
+	require.Equal(t, uint64(168), breakdown.Txs[1].Execution)
+	require.Equal(t, uint64(160000), breakdown.GasLimit)
+	require.Equal(t, uint64(89900), breakdown.GasUsed)
+	require.Equal(t, uint64(15000000), breakdown.AvailableGas)
+	require.Len(t, breakdown.Warnings, 1)
+	require.Contains(t, breakdown.Warnings[0], "99.8% of its gas limit 60000")
+
+	// full recent blocks and a small block gas limit
+	breakdown, err = GasAnalyzer{}.Analyze(txs, res, 150000, []float64{1, 1})
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), breakdown.AvailableGas)
+	require.Len(t, breakdown.Warnings, 3)
+	require.Contains(t, breakdown.Warnings[1], "exceeds the block gas limit 150000")
+	require.Contains(t, breakdown.Warnings[2], "recent blocks left only 0 unused")
+
+	// results matched by position without hashes
+	res = BloxrouteSimulateBundleResponse{Results: []BloxrouteSimulateBundleResult{{GasUsed: 21020}}}
+	breakdown, err = GasAnalyzer{}.Analyze(txs, res, 0, nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(21020), breakdown.Txs[0].GasUsed)
+	require.False(t, breakdown.Txs[1].Simulated)
+	require.Equal(t, []string{"tx " + hashes[1] + " has no simulation result"}, breakdown.Warnings)
+
+	_, err = GasAnalyzer{}.Analyze([]string{"0x05c0"}, res, 0, nil)
+	require.NotNil(t, err)
+}
+
+func (s *FlashXRouteTestSuite) TestAnalyzeBundleGas() {
+	s.registerMethods(map[string]string{
+		"eth_getBlockByNumber": `{"number": "0x10", "gasLimit": "0x1c9c380", "gasUsed": "0xe4e1c0", "transactions": []}`,
+		"eth_feeHistory":       `{"oldestBlock": "0x7", "baseFeePerGas": [], "gasUsedRatio": [0.25, 0.75]}`,
+	})
+
+	txs := gasTestTxs()
+	res := BloxrouteSimulateBundleResponse{Results: []BloxrouteSimulateBundleResult{{GasUsed: 30000}, {GasUsed: 55000}}}
+	breakdown, err := s.rpc.AnalyzeBundleGas(GasAnalyzer{}, txs, res)
+	s.Require().Nil(err)
+	s.Require().Equal(uint64(30000000), breakdown.BlockGasLimit)
+	s.Require().Equal(uint64(15000000), breakdown.AvailableGas)
+	s.Require().Empty(breakdown.Warnings)
+}
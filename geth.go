@@ -0,0 +1,259 @@
+package flashxroute
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// parseHash parse 32 bytes hex string, empty string is the zero hash
+func parseHash(value string) (common.Hash, error) {
+	if value == "" {
+		return common.Hash{}, nil
+	}
+	data, err := ParseBytes(value)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(data) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("%w: %q is not a hash", ErrInvalidHex, value)
+	}
+
+	return common.BytesToHash(data), nil
+}
+
+// parseAddress parse 20 bytes hex string, empty string is the zero address
+func parseAddress(value string) (common.Address, error) {
+	if value == "" {
+		return common.Address{}, nil
+	}
+	data, err := ParseBytes(value)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(data) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("%w: %q is not an address", ErrInvalidHex, value)
+	}
+
+	return common.BytesToAddress(data), nil
+}
+
+// addressHex returns lower case hex of address, as nodes return it
+func addressHex(address common.Address) string {
+	return strings.ToLower(address.Hex())
+}
+
+// bigValue returns value of b, zero for nil
+func bigValue(b *big.Int) big.Int {
+	if b == nil {
+		return big.Int{}
+	}
+
+	return *new(big.Int).Set(b)
+}
+
+// ToGethHeader converts block to go-ethereum header. Block has no receipts root and mix digest, so the hash of the
+// result differs from block.Hash unless they are zero.
+func ToGethHeader(block Block) (*types.Header, error) {
+	header := &types.Header{
+		Difficulty: new(big.Int).Set(&block.Difficulty),
+		Number:     big.NewInt(int64(block.Number)),
+		GasLimit:   uint64(block.GasLimit),
+		GasUsed:    uint64(block.GasUsed),
+		Time:       uint64(block.Timestamp),
+	}
+	if block.BaseFeePerGas.Sign() > 0 {
+		header.BaseFee = new(big.Int).Set(&block.BaseFeePerGas)
+	}
+
+	var err error
+	for _, field := range []struct {
+		value  string
+		target *common.Hash
+	}{
+		{block.ParentHash, &header.ParentHash},
+		{block.Sha3Uncles, &header.UncleHash},
+		{block.StateRoot, &header.Root},
+		{block.TransactionsRoot, &header.TxHash},
+	} {
+		if *field.target, err = parseHash(field.value); err != nil {
+			return nil, err
+		}
+	}
+	if header.Coinbase, err = parseAddress(block.Miner); err != nil {
+		return nil, err
+	}
+	if header.Extra, err = ParseBytes(block.ExtraData); err != nil {
+		return nil, err
+	}
+
+	bloom, err := ParseBytes(block.LogsBloom)
+	if err != nil {
+		return nil, err
+	}
+	header.Bloom = types.BytesToBloom(bloom)
+
+	nonce, err := ParseBytes(block.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) > len(header.Nonce) {
+		return nil, fmt.Errorf("%w: %q is not a block nonce", ErrInvalidHex, block.Nonce)
+	}
+	copy(header.Nonce[len(header.Nonce)-len(nonce):], nonce)
+
+	return header, nil
+}
+
+// FromGethHeader converts go-ethereum header to block without transactions
+func FromGethHeader(header *types.Header) Block {
+	block := Block{
+		Hash:             header.Hash().Hex(),
+		ParentHash:       header.ParentHash.Hex(),
+		Nonce:            BytesToHex(header.Nonce[:]),
+		Sha3Uncles:       header.UncleHash.Hex(),
+		LogsBloom:        BytesToHex(header.Bloom.Bytes()),
+		TransactionsRoot: header.TxHash.Hex(),
+		StateRoot:        header.Root.Hex(),
+		Miner:            addressHex(header.Coinbase),
+		Difficulty:       bigValue(header.Difficulty),
+		ExtraData:        BytesToHex(header.Extra),
+		GasLimit:         int(header.GasLimit),
+		GasUsed:          int(header.GasUsed),
+		Timestamp:        int(header.Time),
+		BaseFeePerGas:    bigValue(header.BaseFee),
+		Uncles:           []string{},
+		Transactions:     []Transaction{},
+	}
+	if header.Number != nil {
+		block.Number = int(header.Number.Int64())
+	}
+
+	return block
+}
+
+// FromGethBlock converts go-ethereum block with its transactions and uncle hashes
+func FromGethBlock(gethBlock *types.Block) (Block, error) {
+	block := FromGethHeader(gethBlock.Header())
+	block.Hash = gethBlock.Hash().Hex()
+	for _, uncle := range gethBlock.Uncles() {
+		block.Uncles = append(block.Uncles, uncle.Hash().Hex())
+	}
+
+	for i, gethTx := range gethBlock.Transactions() {
+		tx, err := FromGethTx(gethTx)
+		if err != nil {
+			return block, err
+		}
+		number, index := block.Number, i
+		tx.BlockHash, tx.BlockNumber, tx.TransactionIndex = block.Hash, &number, &index
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block, nil
+}
+
+// ToGethTx converts transaction to an unsigned go-ethereum legacy transaction, Transaction carries no signature
+// and fee caps, so the result is meant to be completed and signed again, e.g. with SignTransaction
+func ToGethTx(tx Transaction) (*types.Transaction, error) {
+	data, err := ParseBytes(tx.Input)
+	if err != nil {
+		return nil, err
+	}
+	legacy := &types.LegacyTx{
+		Nonce:    uint64(tx.Nonce),
+		GasPrice: new(big.Int).Set(&tx.GasPrice),
+		Gas:      uint64(tx.Gas),
+		Value:    new(big.Int).Set(&tx.Value),
+		Data:     data,
+	}
+	if tx.To != "" {
+		to, err := parseAddress(tx.To)
+		if err != nil {
+			return nil, err
+		}
+		legacy.To = &to
+	}
+
+	return types.NewTx(legacy), nil
+}
+
+// FromGethTx converts go-ethereum transaction, From is recovered from the signature and GasPrice is the fee cap of
+// dynamic fee transactions
+func FromGethTx(gethTx *types.Transaction) (Transaction, error) {
+	tx := Transaction{
+		Hash:     gethTx.Hash().Hex(),
+		Nonce:    int(gethTx.Nonce()),
+		Value:    bigValue(gethTx.Value()),
+		Gas:      int(gethTx.Gas()),
+		GasPrice: bigValue(gethTx.GasPrice()),
+		Input:    BytesToHex(gethTx.Data()),
+	}
+	if to := gethTx.To(); to != nil {
+		tx.To = addressHex(*to)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(gethTx.ChainId()), gethTx)
+	if err != nil {
+		return tx, err
+	}
+	tx.From = addressHex(from)
+
+	return tx, nil
+}
+
+// ToGethLog converts log to go-ethereum log
+func ToGethLog(log Log) (*types.Log, error) {
+	gethLog := &types.Log{
+		BlockNumber: uint64(log.BlockNumber),
+		TxIndex:     uint(log.TransactionIndex),
+		Index:       uint(log.LogIndex),
+		Removed:     log.Removed,
+	}
+
+	var err error
+	if gethLog.Address, err = parseAddress(log.Address); err != nil {
+		return nil, err
+	}
+	if gethLog.Data, err = ParseBytes(log.Data); err != nil {
+		return nil, err
+	}
+	if gethLog.TxHash, err = parseHash(log.TransactionHash); err != nil {
+		return nil, err
+	}
+	if gethLog.BlockHash, err = parseHash(log.BlockHash); err != nil {
+		return nil, err
+	}
+	for _, topic := range log.Topics {
+		hash, err := parseHash(topic)
+		if err != nil {
+			return nil, err
+		}
+		gethLog.Topics = append(gethLog.Topics, hash)
+	}
+
+	return gethLog, nil
+}
+
+// FromGethLog converts go-ethereum log
+func FromGethLog(gethLog *types.Log) Log {
+	log := Log{
+		Removed:          gethLog.Removed,
+		LogIndex:         int(gethLog.Index),
+		TransactionIndex: int(gethLog.TxIndex),
+		TransactionHash:  gethLog.TxHash.Hex(),
+		BlockNumber:      int(gethLog.BlockNumber),
+		BlockHash:        gethLog.BlockHash.Hex(),
+		Address:          addressHex(gethLog.Address),
+		Data:             BytesToHex(gethLog.Data),
+		Topics:           []string{},
+	}
+	for _, topic := range gethLog.Topics {
+		log.Topics = append(log.Topics, topic.Hex())
+	}
+
+	return log
+}
@@ -0,0 +1,84 @@
+package flashxroute
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGethHeaderConversion(t *testing.T) {
+	block := Block{
+		Number:           100,
+		ParentHash:       "0x" + repeatHex("11", 32),
+		Nonce:            "0x0000000000000042",
+		Sha3Uncles:       "0x" + repeatHex("22", 32),
+		LogsBloom:        "0x" + repeatHex("00", 256),
+		TransactionsRoot: "0x" + repeatHex("33", 32),
+		StateRoot:        "0x" + repeatHex("44", 32),
+		Miner:            "0x" + repeatHex("55", 20),
+		Difficulty:       *big.NewInt(0),
+		ExtraData:        "0x6265617665726275696c642e6f7267",
+		GasLimit:         30000000,
+		GasUsed:          15000000,
+		Timestamp:        1663000000,
+		BaseFeePerGas:    *big.NewInt(12 * GWei),
+	}
+
+	header, err := ToGethHeader(block)
+	require.Nil(t, err)
+	require.Equal(t, common.HexToHash(block.ParentHash), header.ParentHash)
+	require.Equal(t, common.HexToAddress(block.Miner), header.Coinbase)
+	require.Equal(t, big.NewInt(100), header.Number)
+	require.Equal(t, big.NewInt(12*GWei), header.BaseFee)
+	require.Equal(t, byte(0x42), header.Nonce[7])
+	require.Equal(t, []byte("beaverbuild.org"), header.Extra)
+
+	converted := FromGethHeader(header)
+	converted.Hash = block.Hash
+	converted.Uncles, converted.Transactions = block.Uncles, block.Transactions
+	require.Equal(t, block, converted)
+
+	block.Miner = "0x1234"
+	_, err = ToGethHeader(block)
+	require.ErrorIs(t, err, ErrInvalidHex)
+}
+
+func TestGethLogConversion(t *testing.T) {
+	log := Log{
+		LogIndex:         6,
+		TransactionIndex: 1,
+		TransactionHash:  "0x" + repeatHex("aa", 32),
+		BlockNumber:      520909,
+		BlockHash:        "0x" + repeatHex("bb", 32),
+		Address:          "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf",
+		Data:             "0x" + repeatHex("00", 32),
+		Topics:           []string{"0x78e4fc71ff7e525b3b4660a76336a2046232fd9bba9c65abb22fa3d07d6e7066"},
+	}
+
+	gethLog, err := ToGethLog(log)
+	require.Nil(t, err)
+	require.Equal(t, uint64(520909), gethLog.BlockNumber)
+	require.Equal(t, common.HexToHash(log.Topics[0]), gethLog.Topics[0])
+	require.Equal(t, log, FromGethLog(gethLog))
+}
+
+func TestToGethTx(t *testing.T) {
+	tx, err := ToGethTx(Transaction{Nonce: 1, Gas: 21000, GasPrice: *big.NewInt(GWei), To: "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf", Input: "0x"})
+	require.Nil(t, err)
+	require.NotNil(t, tx)
+	require.IsType(t, &types.Transaction{}, tx)
+
+	_, err = ToGethTx(Transaction{Input: "0x1"})
+	require.ErrorIs(t, err, ErrOddLengthHex)
+}
+
+func repeatHex(b string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += b
+	}
+	return result
+}
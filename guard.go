@@ -0,0 +1,136 @@
+package flashxroute
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnprofitable is returned when the profit guard refuses to submit a bundle, see UnprofitableError for details
+var ErrUnprofitable = errors.New("bundle unprofitable")
+
+// ProfitGuard - simulates bundles before submission and refuses to submit those netting less than MinProfit. It
+// guards blxr_submit_bundle, submit_arb_only_bundle, the eth_sendBundle of BuilderClient and BundleManager.
+type ProfitGuard struct {
+	MinProfit *big.Int                                                    // minimum net profit in wei
+	Profit    func(res BloxrouteSimulateBundleResponse) (*big.Int, error) // net profit of the simulated bundle in wei, CoinbaseProfit when nil
+	BlobFees  bool                                                        // subtract blob fees of blob transactions at eth_blobBaseFee from the profit
+	Simulator *FlashXRoute                                                // bloXroute client simulating the bundles, the guarded client when nil, set it to guard builder clients
+}
+
+// CoinbaseProfit is the default Profit of ProfitGuard: the coinbase diff of the simulation, i.e. gas fees and direct
+// payments the bundle pays the block builder. Simulations with a failed transaction are refused with an error. It
+// can't see the searcher's own balance, set Profit to compute that.
+func CoinbaseProfit(res BloxrouteSimulateBundleResponse) (*big.Int, error) {
+	for _, result := range res.Results {
+		if result.Error != "" {
+			return nil, errors.Errorf("transaction %s failed: %s", result.TxHash, result.Error)
+		}
+	}
+	if res.CoinbaseDiff == "" {
+		return new(big.Int), nil
+	}
+	profit, ok := new(big.Int).SetString(res.CoinbaseDiff, 0)
+	if !ok {
+		return nil, errors.Errorf("coinbase diff %q", res.CoinbaseDiff)
+	}
+
+	return profit, nil
+}
+
+// UnprofitableError - details of a bundle refused by the profit guard
+type UnprofitableError struct {
+	Profit     *big.Int
+	MinProfit  *big.Int
+	Simulation BloxrouteSimulateBundleResponse
+}
+
+func (err *UnprofitableError) Error() string {
+	return fmt.Sprintf("%s: profit %s wei below minimum %s wei (bundle %s)", ErrUnprofitable, err.Profit, err.MinProfit, err.Simulation.BundleHash)
+}
+
+// Is makes errors.Is(err, ErrUnprofitable) match
+func (err *UnprofitableError) Is(target error) bool {
+	return target == ErrUnprofitable
+}
+
+// Check simulates the bundle on its target block and returns an *UnprofitableError if it nets less than MinProfit.
+// The bundle is simulated on rpc with authHeader unless the guard has a Simulator.
+func (guard ProfitGuard) Check(rpc *FlashXRoute, authHeader string, params BloxrouteSubmitBundleRequest) (BloxrouteSimulateBundleResponse, error) {
+	profitOf := guard.Profit
+	if profitOf == nil {
+		profitOf = CoinbaseProfit
+	}
+	if guard.Simulator != nil {
+		rpc, authHeader = guard.Simulator, ""
+	}
+
+	simulation := BloxrouteSimulateBundleRequest{
+		Transaction: params.Transaction,
+		BlockNumber: params.BlockNumber,
+	}
+	if params.MinTimestamp != nil {
+		simulation.Timestamp = int64(*params.MinTimestamp)
+	}
+	res, err := rpc.BloxrouteSimulateBundle(authHeader, simulation)
+	if err != nil {
+		return res, err
+	}
+
+	profit, err := profitOf(res)
+	if err != nil {
+		return res, err
+	}
+	if guard.BlobFees {
+		if profit, err = guard.subtractBlobFees(rpc, profit, params.Transaction); err != nil {
+			return res, err
+		}
+	}
+	minProfit := guard.MinProfit
+	if minProfit == nil {
+		minProfit = new(big.Int)
+	}
+	if profit.Cmp(minProfit) < 0 {
+		return res, &UnprofitableError{Profit: profit, MinProfit: minProfit, Simulation: res}
+	}
+
+	return res, nil
+}
+
+// subtractBlobFees returns profit less the blob fees burned by the blob transactions of txs, the simulation's
+// coinbaseDiff doesn't account for them
+func (guard ProfitGuard) subtractBlobFees(rpc *FlashXRoute, profit *big.Int, txs []string) (*big.Int, error) {
+	blobGas, err := BundleBlobGas(txs)
+	if err != nil || blobGas == 0 {
+		return profit, err
+	}
+	blobBaseFee, err := rpc.EthBlobBaseFee()
+	if err != nil {
+		return nil, err
+	}
+	cost, err := BundleBlobCost(txs, &blobBaseFee)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Sub(profit, cost), nil
+}
+
+// guardBundle checks the bundle of txs for blockNumber with the profit guard of the client, if any, and notifies
+// BundleSimulationFailed when it is refused
+func (rpc *FlashXRoute) guardBundle(authHeader string, txs []string, blockNumber string, minTimestamp *uint64) error {
+	if rpc.guard == nil {
+		return nil
+	}
+
+	params := BloxrouteSubmitBundleRequest{Transaction: mapHex(txs, StripHexPrefix), BlockNumber: blockNumber, MinTimestamp: minTimestamp}
+	if _, err := rpc.guard.Check(rpc, authHeader, params); err != nil {
+		event := BundleEvent{Kind: BundleSimulationFailed, Transactions: params.Transaction, Err: err.Error()}
+		event.BlockNumber, _ = ParseInt(blockNumber)
+		rpc.notify(event)
+		return err
+	}
+
+	return nil
+}
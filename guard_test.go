@@ -0,0 +1,91 @@
+package flashxroute
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestProfitGuard() {
+	var mu sync.Mutex
+	var methods []string
+	simulation := `{"result": {"bundleHash": "0xs", "coinbaseDiff": "1000"}}`
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		method := gjson.GetBytes(s.getBody(r), "method").String()
+		methods = append(methods, method)
+		if method == "blxr_simulate_bundle" {
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, ` + simulation[1:]))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	guard := ProfitGuard{MinProfit: big.NewInt(100)}
+	rpc := New(server.URL, WithProfitGuard(guard))
+	bundle := BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"}
+
+	// profitable with the default CoinbaseProfit
+	res, err := rpc.BloxrouteSubmitBundle("auth", bundle)
+	s.Require().Nil(err)
+	s.Require().Equal("0xb", res.BundleHash)
+	s.Require().Equal([]string{"blxr_simulate_bundle", "blxr_submit_bundle"}, methods)
+
+	// unprofitable
+	methods, simulation = nil, `{"result": {"bundleHash": "0xs", "coinbaseDiff": "10"}}`
+	_, err = rpc.BloxrouteSubmitBundle("auth", bundle)
+	var unprofitable *UnprofitableError
+	s.Require().True(errors.As(err, &unprofitable))
+	s.Require().ErrorIs(err, ErrUnprofitable)
+	s.Require().Equal(big.NewInt(10), unprofitable.Profit)
+	s.Require().Equal(big.NewInt(100), unprofitable.MinProfit)
+	s.Require().Equal("0xs", unprofitable.Simulation.BundleHash)
+	s.Require().Equal([]string{"blxr_simulate_bundle"}, methods)
+
+	_, err = rpc.BloxrouteBrmSubmitBundle("auth", BloxrouteBrmSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().ErrorIs(err, ErrUnprofitable)
+	s.Require().Equal([]string{"blxr_simulate_bundle", "blxr_simulate_bundle"}, methods)
+
+	// simulation error
+	methods, simulation = nil, `{"error": {"code": -32000, "message": "nonce too low"}}`
+	_, err = rpc.BloxrouteSubmitBundle("auth", bundle)
+	s.Require().ErrorIs(err, ErrRelayErrorResponse)
+	s.Require().Equal([]string{"blxr_simulate_bundle"}, methods)
+
+	// failed transaction
+	methods, simulation = nil, `{"result": {"coinbaseDiff": "1000", "results": [{"txHash": "0x1", "error": "execution reverted"}]}}`
+	_, err = rpc.BloxrouteSubmitBundle("auth", bundle)
+	s.Require().ErrorContains(err, "execution reverted")
+	s.Require().Equal([]string{"blxr_simulate_bundle"}, methods)
+
+	// builder clients simulate on the guard's Simulator
+	methods, simulation = nil, `{"result": {"coinbaseDiff": "10"}}`
+	guard.Simulator = New(server.URL)
+	builder, err := NewBuilderClient(Builder{Name: "builder", URL: server.URL}, WithProfitGuard(guard))
+	s.Require().Nil(err)
+	_, err = builder.SendBundle(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	s.Require().ErrorIs(err, ErrUnprofitable)
+	s.Require().Equal([]string{"blxr_simulate_bundle"}, methods)
+
+	// the manager checks once for every path
+	methods = nil
+	manager := NewBundleManager(rpc, "auth", builder)
+	_, submissions := manager.Submit(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	s.Require().Len(submissions, 2)
+	for _, submission := range submissions {
+		s.Require().ErrorIs(submission.Err, ErrUnprofitable)
+	}
+	s.Require().Equal([]string{"blxr_simulate_bundle"}, methods)
+
+	methods, simulation = nil, `{"result": {"coinbaseDiff": "1000"}}`
+	_, submissions = manager.Submit(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	for _, submission := range submissions {
+		s.Require().Nil(submission.Err)
+	}
+	s.Require().ElementsMatch([]string{"blxr_simulate_bundle", "blxr_submit_bundle", "eth_sendBundle"}, methods)
+}
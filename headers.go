@@ -0,0 +1,28 @@
+package flashxroute
+
+import "context"
+
+// callHeadersKey - context key of the headers of ContextWithHeaders
+type callHeadersKey struct{}
+
+// ContextWithHeaders returns ctx carrying headers sent with the calls made with it by CallContext,
+// CallWithBloxrouteAuthHeaderContext and CallWithFlashbotsSignerContext, on top of and taking precedence over
+// Headers, e.g. a one-off X-Flashbots-Signature or a request trace id. Headers already carried by ctx are kept
+// unless overridden.
+func ContextWithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := make(map[string]string, len(headers))
+	for k, v := range callHeaders(ctx) {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+
+	return context.WithValue(ctx, callHeadersKey{}, merged)
+}
+
+// callHeaders returns the headers carried by ctx
+func callHeaders(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(callHeadersKey{}).(map[string]string)
+	return headers
+}
@@ -0,0 +1,60 @@
+package flashxroute
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestCallContextHeaders() {
+	var headers []http.Header
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		headers = append(headers, request.Header)
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+
+	rpc := s.rpc.With(WithHeader("X-Trace-Id", "shared"))
+	ctx := ContextWithHeaders(context.Background(), map[string]string{"X-Trace-Id": "t1"})
+	ctx = ContextWithHeaders(ctx, map[string]string{"X-Flashbots-Signature": "0xa:0xb"})
+	_, err := rpc.CallContext(ctx, "eth_blockNumber")
+	s.Require().Nil(err)
+	_, err = rpc.Call("eth_blockNumber")
+	s.Require().Nil(err)
+
+	s.Require().Len(headers, 2)
+	s.Require().Equal([]string{"t1"}, headers[0].Values("X-Trace-Id"))
+	s.Require().Equal("0xa:0xb", headers[0].Get("X-Flashbots-Signature"))
+	s.Require().Equal("shared", headers[1].Get("X-Trace-Id"))
+	s.Require().Empty(headers[1].Get("X-Flashbots-Signature"))
+	s.Require().Equal(map[string]string{"X-Trace-Id": "shared"}, rpc.Headers)
+}
+
+func (s *FlashXRouteTestSuite) TestRelayCallContextHeaders() {
+	var headers []http.Header
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL), WithHeader("X-Trace-Id", "shared"))
+	ctx := ContextWithHeaders(context.Background(), map[string]string{"X-Trace-Id": "t1", "X-Flashbots-Signature": "0xa:0xb"})
+	_, err := rpc.CallWithBloxrouteAuthHeaderContext(ctx, "blxr_submit_bundle", "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	key, _ := crypto.GenerateKey()
+	_, err = rpc.CallWithFlashbotsSignerContext(ctx, "eth_sendBundle", NewPrivateKeySigner(key), SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	_, err = rpc.CallWithBloxrouteAuthHeader("blxr_submit_bundle", "auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+
+	s.Require().Len(headers, 3)
+	for _, header := range headers[:2] {
+		s.Require().Equal([]string{"t1"}, header.Values("X-Trace-Id"))
+		s.Require().Equal("0xa:0xb", header.Get("X-Flashbots-Signature"))
+	}
+	s.Require().Equal("auth", headers[0].Get("Authorization"))
+	s.Require().Equal("shared", headers[2].Get("X-Trace-Id"))
+	s.Require().Empty(headers[2].Get("X-Flashbots-Signature"))
+}
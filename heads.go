@@ -0,0 +1,36 @@
+package flashxroute
+
+import (
+	"context"
+	"time"
+)
+
+// WatchHeads polls eth_blockNumber every pollInterval (default: 1s) and calls onHead with every new head until ctx
+// is done, skipped blocks are reported once with the latest number. Failed polls are passed to onError when not nil.
+func (rpc *FlashXRoute) WatchHeads(ctx context.Context, pollInterval time.Duration, onHead func(head int), onError func(err error)) error {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	last := -1
+	for {
+		head, err := rpc.EthBlockNumber()
+		switch {
+		case err != nil:
+			if onError != nil {
+				onError(err)
+			}
+		case head != last:
+			last = head
+			onHead(head)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
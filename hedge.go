@@ -0,0 +1,60 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type hedge struct {
+	url   string
+	delay time.Duration
+}
+
+type hedgeOutcome struct {
+	result json.RawMessage
+	err    error
+}
+
+// hedged sends the request to the primary url and, unless it answers within the hedge delay, to the hedge url as
+// well. The first successful response wins and the other request is cancelled. A failing primary triggers the
+// hedge request right away.
+func (rpc *FlashXRoute) hedged(ctx context.Context, method string, body []byte) (json.RawMessage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make(chan hedgeOutcome, 2)
+	send := func(url string) {
+		result, err := rpc.post(ctx, url, method, body)
+		outcomes <- hedgeOutcome{result, err}
+	}
+
+	go send(rpc.url)
+	pending := 1
+	delay := time.After(rpc.hedge.delay)
+	var firstErr error
+	for {
+		select {
+		case <-delay:
+			delay = nil
+			go send(rpc.hedge.url)
+			pending++
+		case outcome := <-outcomes:
+			pending--
+			if outcome.err == nil {
+				return outcome.result, nil
+			}
+			if firstErr == nil {
+				firstErr = outcome.err
+			}
+			if delay != nil {
+				delay = nil
+				go send(rpc.hedge.url)
+				pending++
+			}
+			if pending == 0 {
+				return nil, firstErr
+			}
+		}
+	}
+}
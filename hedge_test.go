@@ -0,0 +1,54 @@
+package flashxroute
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestHedging() {
+	hedgeURL := "http://127.0.0.1:8546"
+	rpc := s.rpc.With(WithHedging(hedgeURL, 10*time.Millisecond))
+
+	httpmock.Reset()
+	var hedgeCalls int32
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		time.Sleep(200 * time.Millisecond)
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+	httpmock.RegisterResponder("POST", hedgeURL, func(request *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&hedgeCalls, 1)
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x2"}`), nil
+	})
+
+	started := time.Now()
+	number, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(2, number)
+	s.Require().Less(time.Since(started), 200*time.Millisecond)
+	s.Require().EqualValues(1, atomic.LoadInt32(&hedgeCalls))
+
+	// state-changing calls only go to the primary endpoint
+	_, err = rpc.EthSendRawTransaction("0x1234")
+	s.Require().Nil(err)
+	s.Require().EqualValues(1, atomic.LoadInt32(&hedgeCalls))
+}
+
+func (s *FlashXRouteTestSuite) TestHedgingPrimaryError() {
+	hedgeURL := "http://127.0.0.1:8546"
+	rpc := s.rpc.With(WithHedging(hedgeURL, time.Minute))
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", s.rpc.url, httpmock.NewStringResponder(200, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "header not found"}}`))
+	httpmock.RegisterResponder("POST", hedgeURL, httpmock.NewStringResponder(200, `{"jsonrpc":"2.0", "id":1, "result": "0x2"}`))
+
+	number, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(2, number)
+
+	httpmock.RegisterResponder("POST", hedgeURL, httpmock.NewStringResponder(200, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "busy"}}`))
+	_, err = rpc.EthBlockNumber()
+	s.Require().Equal(RpcError{Code: -32000, Message: "header not found"}, err)
+}
@@ -2,45 +2,261 @@ package flashxroute
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
-	"math/big"
-	"encoding/base64"
-	
+
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Denominations in wei
+const (
+	Wei   = 1
+	GWei  = 1000000000
+	Ether = 1000000000000000000
+)
+
+// Number of decimals of the denominations
+const (
+	GWeiDecimals  = 9
+	EtherDecimals = 18
+)
+
+// ErrInvalidAmount is returned when a decimal amount can't be parsed
+var ErrInvalidAmount = errors.New("invalid decimal amount")
+
+// Hex codec errors
+var (
+	ErrEmptyHex     = errors.New("empty hex string")
+	ErrInvalidHex   = errors.New("invalid hex string")
+	ErrOddLengthHex = errors.New("hex string of odd length")
+	ErrHexOverflow  = errors.New("hex number does not fit")
 )
 
+// trimHexPrefix strips an optional 0x/0X prefix
+func trimHexPrefix(value string) string {
+	if len(value) >= 2 && value[0] == '0' && (value[1] == 'x' || value[1] == 'X') {
+		return value[2:]
+	}
+
+	return value
+}
+
+// AddHexPrefix returns data with 0x prefix, as Flashbots and builders expect raw transactions
+func AddHexPrefix(data string) string {
+	return "0x" + trimHexPrefix(data)
+}
+
+// StripHexPrefix returns data without 0x prefix, as bloXroute expects raw transactions
+func StripHexPrefix(data string) string {
+	return trimHexPrefix(data)
+}
+
+// mapHex returns a copy of values converted by AddHexPrefix or StripHexPrefix, nil stays nil
+func mapHex(values []string, convert func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = convert(value)
+	}
+
+	return result
+}
+
+// parseQuantity returns the hex digits of a quantity, the 0x prefix is optional. Quantities are unsigned, a sign
+// is invalid.
+func parseQuantity(value string) (string, error) {
+	digits := trimHexPrefix(value)
+	if digits == "" {
+		return "", fmt.Errorf("%w: %q", ErrEmptyHex, value)
+	}
+	for _, c := range digits {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidHex, value)
+		}
+	}
+
+	return digits, nil
+}
+
 // ParseInt parse hex string value to int
 func ParseInt(value string) (int, error) {
-	i, err := strconv.ParseInt(strings.TrimPrefix(value, "0x"), 16, 64)
+	digits, err := parseQuantity(value)
 	if err != nil {
 		return 0, err
 	}
+	i, err := strconv.ParseUint(digits, 16, 63)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrHexOverflow, value)
+	}
 
 	return int(i), nil
 }
 
+// ParseUint64 parse hex string value to uint64
+func ParseUint64(value string) (uint64, error) {
+	digits, err := parseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrHexOverflow, value)
+	}
+
+	return i, nil
+}
+
 // ParseBigInt parse hex string value to big.Int
 func ParseBigInt(value string) (big.Int, error) {
+	digits, err := parseQuantity(value)
+	if err != nil {
+		return big.Int{}, err
+	}
+
 	i := big.Int{}
-	_, err := fmt.Sscan(value, &i)
+	i.SetString(digits, 16)
+	if i.Sign() == 0 {
+		// canonical zero value so results compare equal to big.Int{}
+		return big.Int{}, nil
+	}
 
-	return i, err
+	return i, nil
 }
 
-// IntToHex convert int to hexadecimal representation
+// IntToHex convert int to hexadecimal representation. JSON-RPC quantities are unsigned, negative i is formatted as
+// -0x.. for display only and nodes reject it as a param.
 func IntToHex(i int) string {
+	if i < 0 {
+		return fmt.Sprintf("-0x%x", -i)
+	}
+
 	return fmt.Sprintf("0x%x", i)
 }
 
-// BigToHex covert big.Int to hexadecimal representation
+// Uint64ToHex convert uint64 to hexadecimal representation
+func Uint64ToHex(i uint64) string {
+	return "0x" + strconv.FormatUint(i, 16)
+}
+
+// BigToHex covert big.Int to hexadecimal representation, like IntToHex negative values aren't valid params
 func BigToHex(bigInt big.Int) string {
-	if bigInt.BitLen() == 0 {
-		return "0x0"
+	if bigInt.Sign() < 0 {
+		return "-0x" + new(big.Int).Neg(&bigInt).Text(16)
+	}
+
+	return "0x" + bigInt.Text(16)
+}
+
+// BytesToHex convert bytes to 0x prefixed hexadecimal data representation, "0x" for empty data
+func BytesToHex(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
+
+// ParseBytes parse hex data string to bytes, the 0x prefix is optional but the length must be even
+func ParseBytes(value string) ([]byte, error) {
+	digits := trimHexPrefix(value)
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("%w: %q", ErrOddLengthHex, value)
+	}
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidHex, value)
+	}
+
+	return data, nil
+}
+
+// Keccak256 returns 0x prefixed Keccak-256 hash of the concatenated data computed locally, same result as Web3Sha3 without a network round trip
+func Keccak256(data ...[]byte) string {
+	return BytesToHex(crypto.Keccak256(data...))
+}
+
+// ParseUnits parse decimal string like "1.5" scaled by 10^decimals, e.g. ParseUnits("1.5", 18) is 1.5 ether in wei.
+// Fractions with more digits than decimals are rejected instead of silently truncated.
+func ParseUnits(value string, decimals int) (*big.Int, error) {
+	negative := strings.HasPrefix(value, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+	if whole == "" && fraction == "" || len(fraction) > decimals || strings.ContainsAny(whole+fraction, "+-") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	amount, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		amount.Neg(amount)
+	}
+
+	return amount, nil
+}
+
+// FormatUnits format amount scaled down by 10^decimals as exact decimal string without trailing zeros
+func FormatUnits(amount big.Int, decimals int) string {
+	abs := new(big.Int).Abs(&amount)
+	digits := abs.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	result := whole
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
 	}
 
-	return "0x" + strings.TrimPrefix(fmt.Sprintf("%x", bigInt.Bytes()), "0")
+	return result
+}
+
+// ParseEther parse decimal ether amount like "1.5" to wei
+func ParseEther(value string) (*big.Int, error) {
+	return ParseUnits(value, EtherDecimals)
+}
+
+// ParseGwei parse decimal gwei amount like "1.5" to wei
+func ParseGwei(value string) (*big.Int, error) {
+	return ParseUnits(value, GWeiDecimals)
+}
+
+// FormatEther format wei amount as exact decimal ether string
+func FormatEther(wei big.Int) string {
+	return FormatUnits(wei, EtherDecimals)
+}
+
+// FormatGwei format wei amount as exact decimal gwei string
+func FormatGwei(wei big.Int) string {
+	return FormatUnits(wei, GWeiDecimals)
+}
+
+// WeiToEther convert wei to ether for display
+func WeiToEther(wei big.Int) *big.Float {
+	return new(big.Float).Quo(new(big.Float).SetInt(&wei), big.NewFloat(Ether))
+}
+
+// WeiToGwei convert wei to gwei for display
+func WeiToGwei(wei big.Int) *big.Float {
+	return new(big.Float).Quo(new(big.Float).SetInt(&wei), big.NewFloat(GWei))
+}
+
+// GweiToWei convert whole gwei to wei
+func GweiToWei(gwei int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(gwei), big.NewInt(GWei))
+}
+
+// EtherToWei convert whole ether to wei
+func EtherToWei(ether int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(ether), big.NewInt(Ether))
 }
 
 func TxToRlp(tx *types.Transaction) string {
@@ -49,6 +265,16 @@ func TxToRlp(tx *types.Transaction) string {
 	return fmt.Sprintf("%x", buff.Bytes())
 }
 
+// TxToHex encode signed transaction in its canonical binary form (typed envelope for EIP-2718 transactions) as 0x prefixed hex
+func TxToHex(tx *types.Transaction) (string, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	return BytesToHex(data), nil
+}
+
 func AuthorizationHeader(accountId string, secretHash string) string {
 	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", accountId, secretHash)))
 }
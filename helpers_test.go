@@ -23,6 +23,34 @@ func TestParseInt(t *testing.T) {
 	i, err = ParseInt("1*29")
 	assert.NotNil(t, err)
 	assert.Equal(t, 0, i)
+
+	_, err = ParseInt("-0x10")
+	assert.ErrorIs(t, err, ErrInvalidHex)
+
+	for _, value := range []string{"", "0x", "0xg", "0x8000000000000000"} {
+		_, err = ParseInt(value)
+		assert.NotNil(t, err, value)
+	}
+
+	_, err = ParseInt("0x")
+	assert.ErrorIs(t, err, ErrEmptyHex)
+	_, err = ParseInt("0x1ffffffffffffffff")
+	assert.ErrorIs(t, err, ErrHexOverflow)
+}
+
+func TestParseUint64(t *testing.T) {
+	i, err := ParseUint64("0xffffffffffffffff")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(18446744073709551615), i)
+
+	i, err = ParseUint64("0X0")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), i)
+
+	for _, value := range []string{"", "0x", "-0x1", "0x10000000000000000", "0xz"} {
+		_, err = ParseUint64(value)
+		assert.NotNil(t, err, value)
+	}
 }
 
 func TestParseBigInt(t *testing.T) {
@@ -32,11 +60,31 @@ func TestParseBigInt(t *testing.T) {
 
 	i, err = ParseBigInt("$%1")
 	assert.NotNil(t, err)
+
+	i, err = ParseBigInt("0x0")
+	assert.Nil(t, err)
+	assert.Equal(t, big.Int{}, i)
+
+	i, err = ParseBigInt("0x51248487c7466b7062d")
+	assert.Nil(t, err)
+	assert.Equal(t, "23949082357483433297453", i.String())
+
+	for _, value := range []string{"", "0x", "0x1g", "12.5", "-0xff"} {
+		_, err = ParseBigInt(value)
+		assert.NotNil(t, err, value)
+	}
 }
 
 func TestIntToHex(t *testing.T) {
 	assert.Equal(t, "0xde0b6b3a7640000", IntToHex(1000000000000000000))
 	assert.Equal(t, "0x6f", IntToHex(111))
+	assert.Equal(t, "0x0", IntToHex(0))
+	assert.Equal(t, "-0x6f", IntToHex(-111))
+}
+
+func TestUint64ToHex(t *testing.T) {
+	assert.Equal(t, "0x0", Uint64ToHex(0))
+	assert.Equal(t, "0xffffffffffffffff", Uint64ToHex(18446744073709551615))
 }
 
 func TestBigToHex(t *testing.T) {
@@ -48,4 +96,102 @@ func TestBigToHex(t *testing.T) {
 
 	i3, _ := big.NewInt(0).SetString("0", 10)
 	assert.Equal(t, "0x0", BigToHex(*i3))
+
+	assert.Equal(t, "0x0", BigToHex(big.Int{}))
+	assert.Equal(t, "0xa", BigToHex(*big.NewInt(10)))
+	assert.Equal(t, "0x100", BigToHex(*big.NewInt(256)))
+	assert.Equal(t, "-0x100", BigToHex(*big.NewInt(-256)))
+
+	for _, value := range []int64{0, 1, 15, 16, 255, 256, 4095, 4096, 1 << 40} {
+		parsed, err := ParseBigInt(BigToHex(*big.NewInt(value)))
+		assert.Nil(t, err)
+		assert.Equal(t, value, parsed.Int64())
+	}
+}
+
+func TestBytesToHex(t *testing.T) {
+	assert.Equal(t, "0x", BytesToHex(nil))
+	assert.Equal(t, "0x00", BytesToHex([]byte{0}))
+	assert.Equal(t, "0x0001ff", BytesToHex([]byte{0, 1, 255}))
+}
+
+func TestParseBytes(t *testing.T) {
+	data, err := ParseBytes("0x0001ff")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 1, 255}, data)
+
+	data, err = ParseBytes("0001FF")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 1, 255}, data)
+
+	data, err = ParseBytes("0x")
+	assert.Nil(t, err)
+	assert.Empty(t, data)
+
+	_, err = ParseBytes("0x123")
+	assert.ErrorIs(t, err, ErrOddLengthHex)
+
+	_, err = ParseBytes("0xzz")
+	assert.ErrorIs(t, err, ErrInvalidHex)
+}
+
+func TestHexPrefix(t *testing.T) {
+	assert.Equal(t, "0x02f8", AddHexPrefix("02f8"))
+	assert.Equal(t, "0x02f8", AddHexPrefix("0x02f8"))
+	assert.Equal(t, "02f8", StripHexPrefix("0x02f8"))
+	assert.Equal(t, "02f8", StripHexPrefix("02f8"))
+	assert.Equal(t, []string{"01", "02"}, mapHex([]string{"0x01", "02"}, StripHexPrefix))
+	assert.Nil(t, mapHex(nil, AddHexPrefix))
+}
+
+func TestKeccak256(t *testing.T) {
+	assert.Equal(t, "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", Keccak256())
+	assert.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", Keccak256([]byte("Transfer(address,address,uint256)")))
+	assert.Equal(t, Keccak256([]byte("data")), Keccak256([]byte("da"), []byte("ta")))
+}
+
+func TestParseUnits(t *testing.T) {
+	wei, err := ParseEther("1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "1500000000000000000", wei.String())
+
+	wei, err = ParseEther("2")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, wei.Cmp(EtherToWei(2)))
+
+	wei, err = ParseEther(".000000000000000001")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), wei.Int64())
+
+	wei, err = ParseGwei("-30.25")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-30250000000), wei.Int64())
+
+	amount, err := ParseUnits("12.34", 6)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12340000), amount.Int64())
+
+	for _, value := range []string{"", ".", "1.2.3", "abc", "1e18", "--1", "+1", "0.0000000000000000001"} {
+		_, err = ParseEther(value)
+		assert.ErrorIs(t, err, ErrInvalidAmount, value)
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	assert.Equal(t, "1.5", FormatEther(*big.NewInt(1500000000000000000)))
+	assert.Equal(t, "0.000000000000000001", FormatEther(*big.NewInt(1)))
+	assert.Equal(t, "0", FormatEther(big.Int{}))
+	assert.Equal(t, "-30.25", FormatGwei(*big.NewInt(-30250000000)))
+	assert.Equal(t, "100", FormatUnits(*big.NewInt(100000000), 6))
+	assert.Equal(t, "42", FormatUnits(*big.NewInt(42), 0))
+}
+
+func TestUnitConversions(t *testing.T) {
+	assert.Equal(t, int64(30000000000), GweiToWei(30).Int64())
+
+	ether, _ := WeiToEther(*big.NewInt(2500000000000000000)).Float64()
+	assert.Equal(t, 2.5, ether)
+
+	gwei, _ := WeiToGwei(*big.NewInt(1500000000)).Float64()
+	assert.Equal(t, 1.5, gwei)
 }
@@ -0,0 +1,103 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hooks - callbacks observing every json-rpc request sent over http or ipc, nil callbacks are skipped
+type Hooks struct {
+	OnRequest  func(method string, body []byte)
+	OnResponse func(method string, status int, body []byte, duration time.Duration) // status is 0 for ipc
+	OnError    func(method string, err error, duration time.Duration)               // transport failures only, json-rpc errors arrive in OnResponse
+	OnCallInfo func(info CallInfo)                                                  // http responses only, with their headers
+}
+
+// redacted is logged instead of the value of sensitive headers
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are substrings of lower case header names whose values are never logged
+var sensitiveHeaders = []string{"authorization", "signature", "token", "secret", "api-key"}
+
+// RedactHeaders returns copy of header with the values of Authorization, signature and token headers replaced
+func RedactHeaders(header http.Header) http.Header {
+	result := make(http.Header, len(header))
+	for name, values := range header {
+		result[name] = values
+		lower := strings.ToLower(name)
+		for _, sensitive := range sensitiveHeaders {
+			if strings.Contains(lower, sensitive) {
+				result[name] = []string{redacted}
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// formatHeaders formats header one per line in a stable order
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, strings.Join(header[name], ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// observe reports request to the hooks and returns func reporting its response or transport error, the debug log
+// prints the exchange with sensitive headers redacted
+func (rpc *FlashXRoute) observe(method string, req *http.Request, body []byte) func(response *http.Response, data []byte, err error) {
+	if rpc.hooks.OnRequest != nil {
+		rpc.hooks.OnRequest(method, body)
+	}
+	var header http.Header
+	if req != nil {
+		header = req.Header
+	}
+	started := time.Now()
+
+	return func(response *http.Response, data []byte, err error) {
+		duration := time.Since(started)
+		status := 0
+		if response != nil {
+			status = response.StatusCode
+			rpc.reportCallInfo(req, newCallInfo(method, req, response, duration))
+		}
+		rpc.recordStats(req, status, duration, err)
+		if err != nil {
+			if rpc.hooks.OnError != nil {
+				rpc.hooks.OnError(method, err, duration)
+			}
+			switch level := rpc.logLevel(methodSubsystem(method)); {
+			case level >= LogBodies:
+				rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nError: %s\n", method, body, err))
+			case level >= LogErrors:
+				rpc.log.Println(fmt.Sprintf("%s failed after %s: %s", method, duration, err))
+			}
+			return
+		}
+
+		if rpc.hooks.OnResponse != nil {
+			rpc.hooks.OnResponse(method, status, data, duration)
+		}
+		switch level := rpc.logLevel(methodSubsystem(method)); {
+		case level >= LogBodies && len(header) > 0:
+			rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\n%s\nResponse: %s\n", method, body, formatHeaders(RedactHeaders(header)), data))
+		case level >= LogBodies:
+			rpc.log.Println(fmt.Sprintf("%s\nRequest: %s\nResponse: %s\n", method, body, data))
+		case level >= LogRequests:
+			rpc.log.Println(fmt.Sprintf("%s %d %s", method, status, duration))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type bufferLogger struct {
+	lines []string
+}
+
+func (l *bufferLogger) Println(v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(v...))
+}
+
+func (s *FlashXRouteTestSuite) TestHooks() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	})
+	defer server.Close()
+
+	var requests, responses, failures []string
+	log := new(bufferLogger)
+	rpc := s.rpc.With(WithURL(server.URL), WithDebug(true), WithLogger(log), WithHooks(Hooks{
+		OnRequest: func(method string, body []byte) {
+			requests = append(requests, method)
+		},
+		OnResponse: func(method string, status int, body []byte, duration time.Duration) {
+			responses = append(responses, fmt.Sprintf("%s %d %s", method, status, body))
+		},
+		OnError: func(method string, err error, duration time.Duration) {
+			failures = append(failures, method)
+		},
+	}))
+
+	_, err := rpc.CallWithBloxrouteAuthHeader("blxr_tx", "secret-auth-header", nil)
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"blxr_tx"}, requests)
+	s.Require().Equal([]string{`blxr_tx 200 {"jsonrpc":"2.0", "id":1, "result": "0x1"}`}, responses)
+	s.Require().Len(log.lines, 1)
+	s.Require().Contains(log.lines[0], "Authorization: [REDACTED]")
+	s.Require().False(strings.Contains(log.lines[0], "secret-auth-header"))
+
+	httpmock.RegisterResponder("POST", server.URL, httpmock.NewErrorResponder(fmt.Errorf("connection refused")))
+	_, err = rpc.EthBlockNumber()
+	s.Require().NotNil(err)
+	s.Require().Equal([]string{"eth_blockNumber"}, failures)
+}
+
+func (s *FlashXRouteTestSuite) TestRedactHeaders() {
+	header := http.Header{
+		"Authorization":         {"Bearer abc"},
+		"X-Flashbots-Signature": {"0x1:0x2"},
+		"X-Api-Key":             {"key"},
+		"Content-Type":          {"application/json"},
+	}
+
+	redacted := RedactHeaders(header)
+	s.Require().Equal([]string{"[REDACTED]"}, redacted["Authorization"])
+	s.Require().Equal([]string{"[REDACTED]"}, redacted["X-Flashbots-Signature"])
+	s.Require().Equal([]string{"[REDACTED]"}, redacted["X-Api-Key"])
+	s.Require().Equal([]string{"application/json"}, redacted["Content-Type"])
+	s.Require().Equal([]string{"Bearer abc"}, header["Authorization"])
+}
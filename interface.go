@@ -1,10 +1,14 @@
 package flashxroute
 
 import (
+	"encoding/json"
 	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
-type EthereumAPI interface {
+// EthereumReader - read-only ethereum json-rpc methods
+type EthereumReader interface {
 	Web3ClientVersion() (string, error)
 	Web3Sha3(data []byte) (string, error)
 	NetVersion() (string, error)
@@ -18,22 +22,30 @@ type EthereumAPI interface {
 	EthGasPrice() (big.Int, error)
 	EthAccounts() ([]string, error)
 	EthBlockNumber() (int, error)
+	EthChainId() (big.Int, error)
+	ChainID() (*big.Int, error)
 	EthGetBalance(address, block string) (big.Int, error)
 	EthGetStorageAt(data string, position int, tag string) (string, error)
+	EthGetStorageAtSlot(address, slot, tag string) (string, error)
 	EthGetTransactionCount(address, block string) (int, error)
+	PendingNonceAt(address string) (int, error)
+	NonceAt(address, block string) (int, error)
 	EthGetBlockTransactionCountByHash(hash string) (int, error)
 	EthGetBlockTransactionCountByNumber(number int) (int, error)
 	EthGetUncleCountByBlockHash(hash string) (int, error)
 	EthGetUncleCountByBlockNumber(number int) (int, error)
 	EthGetCode(address, block string) (string, error)
-	EthSign(address, data string) (string, error)
-	EthSendTransaction(transaction T) (string, error)
-	EthSendRawTransaction(data string) (string, error)
 	EthCall(transaction T, tag string) (string, error)
 	EthEstimateGas(transaction T) (int, error)
 	EthGetBlockByHash(hash string, withTransactions bool) (*Block, error)
 	EthGetBlockByNumber(number int, withTransactions bool) (*Block, error)
+	EthGetBlockByTag(tag BlockTag, withTransactions bool) (*Block, error)
+	EthGetBlockHeaderByHash(hash string) (*BlockHeader, error)
+	EthGetBlockHeaderByNumber(number int) (*BlockHeader, error)
+	EthGetUncleByBlockHashAndIndex(hash string, index int) (*Block, error)
+	EthGetUncleByBlockNumberAndIndex(number, index int) (*Block, error)
 	EthGetTransactionByHash(hash string) (*Transaction, error)
+	EthGetRawTransactionByHash(hash string) (string, error)
 	EthGetTransactionByBlockHashAndIndex(blockHash string, transactionIndex int) (*Transaction, error)
 	EthGetTransactionByBlockNumberAndIndex(blockNumber, transactionIndex int) (*Transaction, error)
 	EthGetTransactionReceipt(hash string) (*TransactionReceipt, error)
@@ -43,8 +55,53 @@ type EthereumAPI interface {
 	EthNewPendingTransactionFilter() (string, error)
 	EthUninstallFilter(filterID string) (bool, error)
 	EthGetFilterChanges(filterID string) ([]Log, error)
+	EthGetFilterChangesHashes(filterID string) ([]string, error)
 	EthGetFilterLogs(filterID string) ([]Log, error)
 	EthGetLogs(params FilterParams) ([]Log, error)
 }
 
+// EthereumSender - ethereum json-rpc methods which sign or send transactions
+type EthereumSender interface {
+	EthSign(address, data string) (string, error)
+	EthSignTypedDataV4(address string, data TypedData) (string, error)
+	EthSendTransaction(transaction T) (string, error)
+	EthSendRawTransaction(data string) (string, error)
+	EthSendSignedTransaction(tx *types.Transaction) (string, error)
+}
+
+// EthereumAPI - all ethereum json-rpc methods
+type EthereumAPI interface {
+	EthereumReader
+	EthereumSender
+}
+
+// BundleSubmitter - bloXroute bundle simulation and submission methods
+type BundleSubmitter interface {
+	BloxrouteSimulateBundle(authHeader string, params BloxrouteSimulateBundleRequest) (BloxrouteSimulateBundleResponse, error)
+	BloxrouteBrmSimulateBundle(authHeader string, params BloxrouteBrmSimulateBundleRequest) (BloxrouteSimulateBundleResponse, error)
+	BloxrouteSubmitBundle(authHeader string, params BloxrouteSubmitBundleRequest) (BloxrouteSubmitBundleResponse, error)
+	BloxrouteCancelBundle(authHeader, uuid, blockNumber string) error
+	BloxrouteBrmSubmitBundle(authHeader string, params BloxrouteBrmSubmitBundleRequest) (BloxrouteSubmitBundleResponse, error)
+	BloxrouteSimulateBlock(authHeader string, block *types.Block, maxTx int) (BloxrouteSimulateBundleResponse, error)
+}
+
+// BloxrouteAPI - all bloXroute methods
+type BloxrouteAPI interface {
+	BundleSubmitter
+	BloxrouteSendTransaction(authHeader string, params BloxrouteSendTransactionRequest) (string, error)
+	BloxrouteSendPrivateTransaction(authHeader string, params BloxrouteSendPrivateTransactionRequest) (string, error)
+	BloxrouteSendSignedTransaction(authHeader string, tx *types.Transaction, params BloxrouteSendTransactionRequest) (string, error)
+	BloxrouteSendSemiPrivateTransaction(authHeader, raw string) (string, error)
+	BloxrouteSendSignedPrivateTransaction(authHeader string, tx *types.Transaction, params BloxrouteSendPrivateTransactionRequest) (string, error)
+}
+
+// Client - full method surface of FlashXRoute, depend on it (or one of the narrower interfaces) to substitute fakes in tests
+type Client interface {
+	EthereumAPI
+	BloxrouteAPI
+	URL() string
+	Call(method string, params ...interface{}) (json.RawMessage, error)
+}
+
 var _ EthereumAPI = (*FlashXRoute)(nil)
+var _ Client = (*FlashXRoute)(nil)
@@ -0,0 +1,115 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+)
+
+// ipcClient - json-rpc over the unix domain socket of a local node, connections are kept open and reused
+type ipcClient struct {
+	path string
+
+	mu   sync.Mutex
+	idle []*ipcConn
+}
+
+type ipcConn struct {
+	net.Conn
+	decoder *json.Decoder
+}
+
+func (c *ipcClient) get() (*ipcConn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		conn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return conn, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := net.Dial("unix", c.path)
+	if err != nil {
+		return nil, err
+	}
+	return &ipcConn{Conn: conn, decoder: json.NewDecoder(conn)}, nil
+}
+
+func (c *ipcClient) put(conn *ipcConn) {
+	c.mu.Lock()
+	c.idle = append(c.idle, conn)
+	c.mu.Unlock()
+}
+
+// Close closes the idle connections
+func (c *ipcClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, conn := range c.idle {
+		conn.Close()
+	}
+	c.idle = nil
+
+	return nil
+}
+
+// roundTrip writes the request body and reads one response, a connection failing midway is discarded
+func (c *ipcClient) roundTrip(body []byte, timeout time.Duration) ([]byte, error) {
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	} else {
+		conn.SetDeadline(time.Time{})
+	}
+
+	var data json.RawMessage
+	if _, err = conn.Write(body); err == nil {
+		err = conn.decoder.Decode(&data)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+
+	return data, nil
+}
+
+// callIPC sends the json-rpc request body over the ipc socket
+func (rpc *FlashXRoute) callIPC(method string, body []byte) (json.RawMessage, error) {
+	data, err := rpc.roundTripIPC(method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResponse(data)
+}
+
+// roundTripIPC sends the json-rpc request body over the ipc socket and returns the response
+func (rpc *FlashXRoute) roundTripIPC(method string, body []byte) ([]byte, error) {
+	finish := rpc.observe(method, nil, body)
+	data, err := rpc.ipc.roundTrip(body, rpc.timeout(method))
+	finish(nil, data, err)
+
+	return data, err
+}
+
+// NewIPC create new rpc client talking to a local node over its ipc socket, e.g. ~/.ethereum/geth.ipc.
+// Only Call and the methods built on it use the socket.
+func NewIPC(path string, options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	return New(path, append([]func(rpc *FlashXRoute){WithIPC(path)}, options...)...)
+}
+
+// CloseIPC closes the idle ipc connections of the client
+func (rpc *FlashXRoute) CloseIPC() error {
+	if rpc.ipc == nil {
+		return nil
+	}
+
+	return rpc.ipc.Close()
+}
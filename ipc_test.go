@@ -0,0 +1,62 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestIPC() {
+	path := filepath.Join(s.T().TempDir(), "geth.ipc")
+	listener, err := net.Listen("unix", path)
+	s.Require().Nil(err)
+	defer listener.Close()
+
+	var connections int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&connections, 1)
+			go func(conn net.Conn) {
+				defer conn.Close()
+				decoder := json.NewDecoder(conn)
+				for {
+					var request json.RawMessage
+					if err := decoder.Decode(&request); err != nil {
+						return
+					}
+
+					switch gjson.GetBytes(request, "method").String() {
+					case "eth_blockNumber":
+						fmt.Fprintf(conn, `{"jsonrpc":"2.0","id":1,"result":"0x10"}`+"\n")
+					default:
+						fmt.Fprintf(conn, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`+"\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	rpc := NewIPC(path, WithLogger(nil))
+	defer rpc.CloseIPC()
+
+	for i := 0; i < 3; i++ {
+		number, err := rpc.EthBlockNumber()
+		s.Require().Nil(err)
+		s.Require().Equal(16, number)
+	}
+	s.Require().EqualValues(1, atomic.LoadInt32(&connections))
+
+	_, err = rpc.Call("eth_unknown")
+	s.Require().Equal(RpcError{Code: -32601, Message: "method not found"}, err)
+
+	_, err = NewIPC(filepath.Join(s.T().TempDir(), "missing.ipc")).EthBlockNumber()
+	s.Require().NotNil(err)
+}
@@ -0,0 +1,295 @@
+package flashxroute
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownJournalEntry is returned when updating an entry the journal never recorded
+var ErrUnknownJournalEntry = errors.New("unknown journal entry")
+
+// InclusionStatus - what happened to a journaled bundle
+type InclusionStatus int
+
+// Bundle inclusion statuses
+const (
+	InclusionPending  InclusionStatus = iota // target block not reached yet
+	InclusionIncluded                        // first transaction landed in IncludedIn
+	InclusionMissed                          // target block passed without the bundle
+	InclusionFailed                          // submission returned an error
+)
+
+// String returns name of the status
+func (s InclusionStatus) String() string {
+	switch s {
+	case InclusionPending:
+		return "pending"
+	case InclusionIncluded:
+		return "included"
+	case InclusionMissed:
+		return "missed"
+	case InclusionFailed:
+		return "failed"
+	}
+
+	return fmt.Sprintf("InclusionStatus(%d)", int(s))
+}
+
+// JournalEntry - a recorded bundle submission
+type JournalEntry struct {
+	ID             int64           `json:"id"` // assigned by the journal
+	SubmittedAt    time.Time       `json:"submittedAt"`
+	Relay          string          `json:"relay"`  // url of the relay the bundle was sent to
+	Method         string          `json:"method"` // e.g. blxr_submit_bundle
+	BlockNumber    int             `json:"blockNumber"`
+	Transactions   []string        `json:"transactions"`
+	CoinbaseProfit string          `json:"coinbaseProfit,omitempty"` // declared coinbase profit in wei
+	BundleHash     string          `json:"bundleHash,omitempty"`     // hash returned by the relay
+	Error          string          `json:"error,omitempty"`          // submission error
+	Status         InclusionStatus `json:"status"`
+	IncludedIn     int             `json:"includedIn,omitempty"` // block the first transaction landed in
+}
+
+// Journal - persistence of bundle submissions surviving restarts, implement it on top of SQLite, Bolt or any other
+// store, FileJournal is a dependency free implementation
+type Journal interface {
+	Record(entry JournalEntry) (int64, error) // stores entry and returns its ID
+	Update(id int64, status InclusionStatus, includedIn int) error
+	Entries() ([]JournalEntry, error) // all entries in submission order
+}
+
+// FileJournal - Journal appending json lines to a file, the latest line of every entry wins when the file is loaded
+type FileJournal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries []JournalEntry // entry ID is index + 1
+}
+
+// OpenFileJournal opens or creates the journal file at path and loads its entries
+func OpenFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &FileJournal{file: file}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "corrupt journal %s", path)
+		}
+		if entry.ID < 1 || entry.ID > int64(len(j.entries))+1 {
+			file.Close()
+			return nil, errors.Errorf("corrupt journal %s: unexpected entry id %d", path, entry.ID)
+		}
+		if entry.ID == int64(len(j.entries))+1 {
+			j.entries = append(j.entries, entry)
+		} else {
+			j.entries[entry.ID-1] = entry
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Record appends entry with the next ID
+func (j *FileJournal) Record(entry JournalEntry) (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.ID = int64(len(j.entries)) + 1
+	if err := j.write(entry); err != nil {
+		return 0, err
+	}
+	j.entries = append(j.entries, entry)
+
+	return entry.ID, nil
+}
+
+// Update appends the entry with new inclusion status
+func (j *FileJournal) Update(id int64, status InclusionStatus, includedIn int) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if id < 1 || id > int64(len(j.entries)) {
+		return errors.Wrapf(ErrUnknownJournalEntry, "id %d", id)
+	}
+	entry := j.entries[id-1]
+	entry.Status, entry.IncludedIn = status, includedIn
+	if err := j.write(entry); err != nil {
+		return err
+	}
+	j.entries[id-1] = entry
+
+	return nil
+}
+
+// Entries returns copy of all entries
+func (j *FileJournal) Entries() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return append([]JournalEntry(nil), j.entries...), nil
+}
+
+// Close closes the journal file
+func (j *FileJournal) Close() error {
+	return j.file.Close()
+}
+
+func (j *FileJournal) write(entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = j.file.Write(append(line, '\n'))
+	return err
+}
+
+// bundleSubmitted notifies about bundle submission and records it when a journal is set, journal failures are
+// logged and never fail the submission
+func (rpc *FlashXRoute) bundleSubmitted(method, blockNumber string, txs []string, coinbaseProfit *string, bundleHash string, err error, duplicate bool) {
+	event := BundleEvent{Kind: BundleSubmitted, BundleHash: bundleHash, Transactions: txs}
+	if duplicate {
+		event.Kind = BundleDuplicate
+	}
+	event.BlockNumber, _ = ParseInt(blockNumber)
+	if err != nil {
+		event.Err = err.Error()
+	}
+	rpc.notify(event)
+
+	if rpc.journal == nil || duplicate {
+		return
+	}
+
+	entry := JournalEntry{
+		SubmittedAt:  time.Now(),
+		Relay:        rpc.url,
+		Method:       method,
+		Transactions: txs,
+		BundleHash:   bundleHash,
+	}
+	entry.BlockNumber, _ = ParseInt(blockNumber)
+	if coinbaseProfit != nil {
+		entry.CoinbaseProfit = *coinbaseProfit
+	}
+	if err != nil {
+		entry.Status, entry.Error = InclusionFailed, err.Error()
+	}
+
+	if _, err := rpc.journal.Record(entry); err != nil && rpc.log != nil {
+		rpc.log.Println(fmt.Sprintf("journal: can't record %s bundle for block %s: %s", method, blockNumber, err))
+	}
+}
+
+// ResolveJournal checks pending journal entries whose target block was reached: entries whose first transaction
+// has a receipt become included, the others missed, and notifies about them. Returns the number of updated entries.
+func (rpc *FlashXRoute) ResolveJournal() (int, error) {
+	if rpc.journal == nil {
+		return 0, errors.New("no journal configured, set one with WithJournal")
+	}
+
+	entries, err := rpc.journal.Entries()
+	if err != nil {
+		return 0, err
+	}
+	head, err := rpc.EthBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, entry := range entries {
+		if entry.Status != InclusionPending || entry.BlockNumber > head || len(entry.Transactions) == 0 {
+			continue
+		}
+
+		raw, err := ParseBytes(entry.Transactions[0])
+		if err != nil {
+			return updated, err
+		}
+		receipt, err := rpc.EthGetTransactionReceipt(Keccak256(raw))
+		if err != nil {
+			return updated, err
+		}
+
+		status, includedIn := InclusionMissed, 0
+		if receipt.BlockHash != "" {
+			status, includedIn = InclusionIncluded, receipt.BlockNumber
+		}
+		if err := rpc.journal.Update(entry.ID, status, includedIn); err != nil {
+			return updated, err
+		}
+		updated++
+
+		event := BundleEvent{Kind: BundleExpired, Relay: entry.Relay, BlockNumber: entry.BlockNumber, BundleHash: entry.BundleHash, Transactions: entry.Transactions}
+		if status == InclusionIncluded {
+			event.Kind, event.IncludedIn = BundleIncluded, includedIn
+		}
+		rpc.notify(event)
+	}
+
+	return updated, nil
+}
+
+// JournalSummary - inclusion statistics of journal entries
+type JournalSummary struct {
+	Submitted int
+	Included  int
+	Missed    int
+	Failed    int
+	Pending   int
+	Profit    *big.Int // sum of the declared coinbase profit of included entries
+}
+
+// InclusionRate returns included share of the resolved submissions
+func (s JournalSummary) InclusionRate() float64 {
+	resolved := s.Included + s.Missed
+	if resolved == 0 {
+		return 0
+	}
+
+	return float64(s.Included) / float64(resolved)
+}
+
+// SummarizeJournal counts entries by status, filter (optional) selects the entries to count
+func SummarizeJournal(entries []JournalEntry, filter func(JournalEntry) bool) JournalSummary {
+	summary := JournalSummary{Profit: new(big.Int)}
+	for _, entry := range entries {
+		if filter != nil && !filter(entry) {
+			continue
+		}
+
+		summary.Submitted++
+		switch entry.Status {
+		case InclusionIncluded:
+			summary.Included++
+			if profit, ok := new(big.Int).SetString(entry.CoinbaseProfit, 10); ok {
+				summary.Profit.Add(summary.Profit, profit)
+			}
+		case InclusionMissed:
+			summary.Missed++
+		case InclusionFailed:
+			summary.Failed++
+		default:
+			summary.Pending++
+		}
+	}
+
+	return summary
+}
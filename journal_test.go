@@ -0,0 +1,73 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestJournal() {
+	included := Keccak256([]byte{0x01})
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		result := "null"
+		switch gjson.GetBytes(body, "method").String() {
+		case "eth_blockNumber":
+			result = `"0x11"`
+		case "blxr_submit_bundle":
+			if gjson.GetBytes(body, "params.block_number").String() == "0x13" {
+				w.Write([]byte(`{"error": "block too far"}`))
+				return
+			}
+			result = `{"bundleHash": "0xb"}`
+		case "eth_getTransactionReceipt":
+			if gjson.GetBytes(body, "params.0").String() == included {
+				result = `{"blockHash": "0xbh", "blockNumber": "0x10"}`
+			}
+		}
+		w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result)))
+	})
+	defer server.Close()
+
+	path := filepath.Join(s.T().TempDir(), "journal.jsonl")
+	journal, err := OpenFileJournal(path)
+	s.Require().Nil(err)
+	rpc := s.rpc.With(WithURL(server.URL), WithJournal(journal))
+
+	profit := "1000"
+	for _, bundle := range []BloxrouteSubmitBundleRequest{
+		{Transaction: []string{"01"}, BlockNumber: "0x10", CoinbaseProfit: &profit},
+		{Transaction: []string{"02"}, BlockNumber: "0x11"},
+		{Transaction: []string{"03"}, BlockNumber: "0x12"},
+		{Transaction: []string{"04"}, BlockNumber: "0x13"},
+	} {
+		rpc.BloxrouteSubmitBundle("", bundle)
+	}
+
+	updated, err := rpc.ResolveJournal()
+	s.Require().Nil(err)
+	s.Require().Equal(2, updated)
+	s.Require().Nil(journal.Close())
+
+	reopened, err := OpenFileJournal(path)
+	s.Require().Nil(err)
+	defer reopened.Close()
+	entries, err := reopened.Entries()
+	s.Require().Nil(err)
+	s.Require().Len(entries, 4)
+	s.Require().Equal(InclusionIncluded, entries[0].Status)
+	s.Require().Equal(16, entries[0].IncludedIn)
+	s.Require().Equal("0xb", entries[0].BundleHash)
+	s.Require().Equal(server.URL, entries[0].Relay)
+	s.Require().Equal(InclusionMissed, entries[1].Status)
+	s.Require().Equal(InclusionPending, entries[2].Status)
+	s.Require().Equal(InclusionFailed, entries[3].Status)
+	s.Require().Contains(entries[3].Error, "block too far")
+
+	summary := SummarizeJournal(entries, nil)
+	s.Require().Equal(JournalSummary{Submitted: 4, Included: 1, Missed: 1, Failed: 1, Pending: 1, Profit: summary.Profit}, summary)
+	s.Require().Equal("1000", summary.Profit.String())
+	s.Require().Equal(0.5, summary.InclusionRate())
+}
@@ -0,0 +1,27 @@
+package flashxroute
+
+import (
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// NewKeystoreSigner create signer from an encrypted JSON keystore file (web3 secret storage, scrypt or pbkdf2)
+func NewKeystoreSigner(path, password string) (*PrivateKeySigner, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeystoreSignerFromJSON(keyJSON, password)
+}
+
+// NewKeystoreSignerFromJSON create signer from encrypted JSON keystore content
+func NewKeystoreSignerFromJSON(keyJSON []byte, password string) (*PrivateKeySigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
@@ -0,0 +1,28 @@
+package flashxroute
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeystoreSigner(t *testing.T) {
+	privateKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{Address: address, PrivateKey: privateKey}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.Nil(t, ioutil.WriteFile(path, keyJSON, 0600))
+
+	signer, err := NewKeystoreSigner(path, "secret")
+	require.Nil(t, err)
+	require.Equal(t, address, signer.Address())
+
+	_, err = NewKeystoreSigner(filepath.Join(t.TempDir(), "missing.json"), "secret")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,129 @@
+package flashxroute
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// BidLadder - simulates variants of a bundle paying increasing coinbase tips, so a strategy can pick its bid from
+// the resulting profit/bid curve just before submission
+type BidLadder struct {
+	Base        []string                                                                  // raw transactions of the bundle without its tip
+	Tip         func(tip *big.Int) (string, error)                                        // signed raw transaction paying tip, appended to Base, e.g. SignCoinbaseTip with a fixed nonce
+	Profit      func(tip *big.Int, res BloxrouteSimulateBundleResponse) (*big.Int, error) // net profit of a variant, optional
+	Concurrency int                                                                       // variants simulated at once (default: 4)
+}
+
+// BidStep - simulated variant of a BidLadder
+type BidStep struct {
+	Tip        *big.Int
+	Simulation BloxrouteSimulateBundleResponse
+	Profit     *big.Int // nil without BidLadder.Profit
+	Err        error    // signing, simulation or profit error, or a *SimulationError of a reverted transaction
+}
+
+// TipRange returns steps tips evenly spaced from min to max included
+func TipRange(min, max *big.Int, steps int) []*big.Int {
+	if steps <= 1 {
+		return []*big.Int{new(big.Int).Set(min)}
+	}
+
+	tips := make([]*big.Int, steps)
+	span := new(big.Int).Sub(max, min)
+	for i := range tips {
+		tip := new(big.Int).Mul(span, big.NewInt(int64(i)))
+		tips[i] = tip.Quo(tip, big.NewInt(int64(steps-1))).Add(tip, min)
+	}
+
+	return tips
+}
+
+// Simulate simulates a variant per tip with blxr_simulate_bundle for blockNumber concurrently and returns the curve
+// in tips order
+func (l BidLadder) Simulate(rpc *FlashXRoute, authHeader string, blockNumber int, tips []*big.Int) []BidStep {
+	concurrency := l.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	steps := make([]BidStep, len(tips))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, tip := range tips {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, tip *big.Int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			steps[i] = l.simulate(rpc, authHeader, blockNumber, tip)
+		}(i, tip)
+	}
+	wg.Wait()
+
+	return steps
+}
+
+// simulate simulates the variant paying tip
+func (l BidLadder) simulate(rpc *FlashXRoute, authHeader string, blockNumber int, tip *big.Int) BidStep {
+	step := BidStep{Tip: tip}
+	if l.Tip == nil {
+		step.Err = errors.New("bid ladder has no Tip function")
+		return step
+	}
+	raw, err := l.Tip(tip)
+	if err != nil {
+		step.Err = errors.Wrap(err, "sign tip")
+		return step
+	}
+
+	bundle := BloxrouteSimulateBundleRequest{Transaction: append(append([]string{}, l.Base...), raw), BlockNumber: IntToHex(blockNumber)}
+	if step.Simulation, step.Err = rpc.BloxrouteSimulateBundle(authHeader, bundle); step.Err != nil {
+		return step
+	}
+	if errs := step.Simulation.Errors(); len(errs) > 0 {
+		step.Err = errs[0]
+		return step
+	}
+	if l.Profit != nil {
+		step.Profit, step.Err = l.Profit(tip, step.Simulation)
+	}
+
+	return step
+}
+
+// BestBid returns the step with the highest profit among the successful steps with a profit, false when there is
+// none
+func BestBid(steps []BidStep) (BidStep, bool) {
+	var best BidStep
+	found := false
+	for _, step := range steps {
+		if step.Err != nil || step.Profit == nil {
+			continue
+		}
+		if !found || step.Profit.Cmp(best.Profit) > 0 {
+			best, found = step, true
+		}
+	}
+
+	return best, found
+}
+
+// HighestBid returns the step with the highest tip whose profit is at least minProfit, false when there is none
+func HighestBid(steps []BidStep, minProfit *big.Int) (BidStep, bool) {
+	var best BidStep
+	found := false
+	for _, step := range steps {
+		if step.Err != nil || step.Profit == nil || step.Profit.Cmp(minProfit) < 0 {
+			continue
+		}
+		if !found || step.Tip.Cmp(best.Tip) > 0 {
+			best, found = step, true
+		}
+	}
+
+	return best, found
+}
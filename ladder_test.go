@@ -0,0 +1,59 @@
+package flashxroute
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestTipRange(t *testing.T) {
+	require.Equal(t, []*big.Int{big.NewInt(100), big.NewInt(150), big.NewInt(200)}, TipRange(big.NewInt(100), big.NewInt(200), 3))
+	require.Equal(t, []*big.Int{big.NewInt(7)}, TipRange(big.NewInt(7), big.NewInt(9), 1))
+}
+
+func (s *FlashXRouteTestSuite) TestBidLadder() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		params := gjson.GetBytes(s.getBody(r), "params")
+		s.Require().Equal("0x10", params.Get("block_number").String())
+		s.Require().Equal("aa", params.Get("transaction.0").String())
+		tip, _ := new(big.Int).SetString(params.Get("transaction.1").String(), 16)
+		result := `{"gasUsed": 21000}`
+		if tip.Int64() >= 300 {
+			result = `{"gasUsed": 21000, "error": "execution reverted", "value": "0x"}`
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0", "id":1, "result": {"coinbaseDiff": "%d", "results": [%s]}}`, 1000+tip.Int64(), result)
+	})
+	defer server.Close()
+
+	ladder := BidLadder{
+		Base: []string{"0xaa"},
+		Tip:  func(tip *big.Int) (string, error) { return fmt.Sprintf("0x%04x", tip), nil },
+		Profit: func(tip *big.Int, res BloxrouteSimulateBundleResponse) (*big.Int, error) {
+			coinbaseDiff, _ := new(big.Int).SetString(res.CoinbaseDiff, 10)
+			// searcher keeps 500 wei of the opportunity less the tip
+			return new(big.Int).Sub(big.NewInt(500), new(big.Int).Sub(coinbaseDiff, big.NewInt(1000))), nil
+		},
+		Concurrency: 2,
+	}
+	steps := ladder.Simulate(s.rpc.With(WithURL(server.URL)), "auth", 16, TipRange(big.NewInt(100), big.NewInt(400), 4))
+	s.Require().Len(steps, 4)
+	s.Require().Nil(steps[0].Err)
+	s.Require().Equal(big.NewInt(400), steps[0].Profit)
+	s.Require().Equal(big.NewInt(300), steps[1].Profit)
+	var simulationErr *SimulationError
+	s.Require().ErrorAs(steps[2].Err, &simulationErr)
+	s.Require().ErrorAs(steps[3].Err, &simulationErr)
+
+	best, ok := BestBid(steps)
+	s.Require().True(ok)
+	s.Require().Equal(big.NewInt(100), best.Tip)
+	highest, ok := HighestBid(steps, big.NewInt(250))
+	s.Require().True(ok)
+	s.Require().Equal(big.NewInt(200), highest.Tip)
+	_, ok = HighestBid(steps, big.NewInt(1000))
+	s.Require().False(ok)
+}
@@ -0,0 +1,153 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// WETHMainnet - WETH9 contract on ethereum mainnet
+const WETHMainnet = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+
+// WETH function selectors
+const (
+	wethDeposit  = "0xd0e30db0" // deposit()
+	wethWithdraw = "0x2e1a7d4d" // withdraw(uint256)
+)
+
+// Coinbase tip init codes, PUSH0 requires Shanghai
+const (
+	// CoinbaseTipInitCode - COINBASE SELFDESTRUCT, a contract creation sending its value to block.coinbase without
+	// leaving code behind
+	CoinbaseTipInitCode = "0x41ff"
+	// CoinbasePayerInitCode - deploys a payer contract whose code forwards the value of every call to block.coinbase:
+	// CALL(GAS, COINBASE, CALLVALUE, 0, 0, 0, 0)
+	CoinbasePayerInitCode = "0x6009600a5f3960095ff35f5f5f5f34415af100"
+)
+
+// CoinbaseTipGas - gas limit of CoinbaseTip, covering the 25000 gas charged when block.coinbase is an empty account
+const CoinbaseTipGas = 85000
+
+// TxOptions - chain, nonce, gas and fees of a transaction signed by SignCall
+type TxOptions struct {
+	ChainID   *big.Int // chain id of the signature (default: ChainID of the endpoint)
+	Nonce     *uint64  // nonce (default: pending nonce of the signer)
+	Gas       uint64   // gas limit (default: eth_estimateGas)
+	GasTipCap *big.Int // priority fee per gas in wei, required
+	GasFeeCap *big.Int // maximum fee per gas in wei, required
+}
+
+// WETHDeposit returns the call of weth.deposit() wrapping amount wei
+func WETHDeposit(weth string, amount *big.Int) T {
+	return T{To: weth, Value: amount, Data: wethDeposit}
+}
+
+// WETHWithdraw returns the call of weth.withdraw(amount) unwrapping amount wei
+func WETHWithdraw(weth string, amount *big.Int) (T, error) {
+	word, err := abiUint(amount)
+	if err != nil {
+		return T{}, err
+	}
+
+	return T{To: weth, Data: wethWithdraw + BytesToHex(word)[2:]}, nil
+}
+
+// CoinbaseTransfer returns the call sending tip wei to payer, a contract forwarding the value it receives to
+// block.coinbase, so the builder is paid whatever the gas price
+func CoinbaseTransfer(payer string, tip *big.Int) T {
+	return T{To: payer, Value: tip}
+}
+
+// CoinbaseTip returns the contract creation paying tip wei to block.coinbase with CoinbaseTipInitCode, so a bundle
+// bids with a tip instead of its gas price without a deployed payer contract, see SignCoinbaseTip
+func CoinbaseTip(tip *big.Int) T {
+	return T{Value: tip, Data: CoinbaseTipInitCode}
+}
+
+// DeployCoinbasePayer returns the contract creation of a payer contract for CoinbaseTransfer
+func DeployCoinbasePayer() T {
+	return T{Data: CoinbasePayerInitCode}
+}
+
+// SignCoinbaseTip returns the signed raw CoinbaseTip transaction of tip wei, the gas limit defaults to
+// CoinbaseTipGas
+func (rpc *FlashXRoute) SignCoinbaseTip(tip *big.Int, options TxOptions) (string, error) {
+	if options.Gas == 0 {
+		options.Gas = CoinbaseTipGas
+	}
+
+	return rpc.SignCall(CoinbaseTip(tip), options)
+}
+
+// SignCall returns call as a signed raw EIP-1559 transaction of the configured signer, ready for
+// BundleBuilder.AddRawTransaction. Missing nonce, gas limit and chain id are read from the endpoint.
+func (rpc *FlashXRoute) SignCall(call T, options TxOptions) (string, error) {
+	if rpc.signer == nil {
+		return "", ErrNoSigner
+	}
+	if options.GasTipCap == nil || options.GasFeeCap == nil {
+		return "", errors.New("sign call without GasTipCap and GasFeeCap")
+	}
+	from := rpc.signer.Address().Hex()
+	call.From = from
+
+	chainID := options.ChainID
+	if chainID == nil {
+		id, err := rpc.ChainID()
+		if err != nil {
+			return "", err
+		}
+		chainID = id
+	}
+	var nonce uint64
+	if options.Nonce != nil {
+		nonce = *options.Nonce
+	} else {
+		pending, err := rpc.PendingNonceAt(from)
+		if err != nil {
+			return "", err
+		}
+		nonce = uint64(pending)
+	}
+	gas := options.Gas
+	if gas == 0 {
+		estimate, err := rpc.EthEstimateGas(call)
+		if err != nil {
+			return "", errors.Wrap(err, "estimate gas")
+		}
+		gas = uint64(estimate)
+	}
+
+	data, err := ParseBytes(call.Data)
+	if err != nil {
+		return "", err
+	}
+	to, err := parseAddress(call.To)
+	if err != nil {
+		return "", err
+	}
+	value := new(big.Int)
+	if call.Value != nil {
+		value.Set(call.Value)
+	}
+	tx := &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: options.GasTipCap,
+		GasFeeCap: options.GasFeeCap,
+		Gas:       gas,
+		Value:     value,
+		Data:      data,
+	}
+	if call.To != "" {
+		tx.To = &to
+	}
+
+	signed, err := rpc.SignTransaction(types.NewTx(tx), chainID)
+	if err != nil {
+		return "", err
+	}
+
+	return TxToHex(signed)
+}
@@ -0,0 +1,61 @@
+package flashxroute
+
+import (
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegCalls(t *testing.T) {
+	amount := big.NewInt(1000)
+	require.Equal(t, T{To: WETHMainnet, Value: amount, Data: "0xd0e30db0"}, WETHDeposit(WETHMainnet, amount))
+
+	withdraw, err := WETHWithdraw(WETHMainnet, amount)
+	require.Nil(t, err)
+	require.Equal(t, "0x2e1a7d4d"+strings.Repeat("0", 61)+"3e8", withdraw.Data)
+	_, err = WETHWithdraw(WETHMainnet, big.NewInt(-1))
+	require.NotNil(t, err)
+
+	require.Equal(t, T{To: "0xaa", Value: amount}, CoinbaseTransfer("0xaa", amount))
+	require.Equal(t, T{Value: amount, Data: "0x41ff"}, CoinbaseTip(amount))
+	// intrinsic gas, COINBASE, SELFDESTRUCT and the new account charge of an empty coinbase
+	require.GreaterOrEqual(t, uint64(CoinbaseTipGas), IntrinsicGas([]byte{0x41, 0xff}, true, 0, 0)+2+5000+25000)
+}
+
+func TestCoinbasePayerInitCode(t *testing.T) {
+	code, err := ParseBytes(CoinbasePayerInitCode)
+	require.Nil(t, err)
+	// the init code copies and returns the 9 bytes runtime code following it
+	require.Equal(t, []byte{0x60, 0x09, 0x60, 0x0a, 0x5f, 0x39, 0x60, 0x09, 0x5f, 0xf3}, code[:10])
+	require.Len(t, code[10:], 9)
+	require.Equal(t, T{Data: CoinbasePayerInitCode}, DeployCoinbasePayer())
+}
+
+func (s *FlashXRouteTestSuite) TestSignCall() {
+	key, _ := crypto.GenerateKey()
+	from := NewPrivateKeySigner(key).Address().Hex()
+	s.registerMethods(map[string]string{
+		"eth_chainId":             `"0x1"`,
+		"eth_getTransactionCount": `"0x7"`,
+		`eth_estimateGas [{"data":"0xd0e30db0","from":"` + from + `","to":"` + WETHMainnet + `","value":"0x3e8"}]`: `"0xb411"`,
+	})
+	rpc := New(s.rpc.url, WithHttpClient(http.DefaultClient), WithSigner(NewPrivateKeySigner(key)))
+
+	options := TxOptions{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100)}
+	raw, err := rpc.SignCall(WETHDeposit(WETHMainnet, big.NewInt(1000)), options)
+	s.Require().Nil(err)
+	s.Require().True(strings.HasPrefix(raw, "0x"))
+
+	raw, err = rpc.SignCoinbaseTip(big.NewInt(1000), options)
+	s.Require().Nil(err)
+	s.Require().True(strings.HasPrefix(raw, "0x"))
+
+	_, err = rpc.SignCall(T{To: WETHMainnet}, TxOptions{})
+	s.Require().NotNil(err)
+	_, err = s.rpc.SignCall(T{To: WETHMainnet}, options)
+	s.Require().ErrorIs(err, ErrNoSigner)
+}
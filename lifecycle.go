@@ -0,0 +1,160 @@
+package flashxroute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Lifecycle errors
+var (
+	ErrAlreadyStarted = errors.New("lifecycle already started")
+	ErrStopped        = errors.New("lifecycle stopped")
+)
+
+// Lifecycle - runs the long-running components of a bot (SubscribeEvent, SubmitAndChase, WaitUntilSynced,
+// Scheduler.Run, ...) on a shared context so they shut down together. Components are functions bound to ctx that
+// return once it is done, Stop cancels it and waits for all of them before running the closers registered with
+// OnStop, e.g. WebhookNotifier.Close or CloseIPC. The zero value is ready to use.
+type Lifecycle struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	pending  []component
+	closers  []component
+	wg       sync.WaitGroup
+	err      error
+	started  bool
+	stopped  bool
+	finished chan struct{}
+}
+
+type component struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Go registers component run under name, it starts with Start or right away when the lifecycle is already running
+func (l *Lifecycle) Go(name string, run func(ctx context.Context) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case l.stopped:
+		return errors.Wrap(ErrStopped, name)
+	case l.started:
+		l.launch(component{name: name, run: run})
+	default:
+		l.pending = append(l.pending, component{name: name, run: run})
+	}
+
+	return nil
+}
+
+// OnStop registers close to run on Stop once all components returned, closers run in reverse registration order
+func (l *Lifecycle) OnStop(name string, close func() error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closers = append(l.closers, component{name: name, run: func(context.Context) error { return close() }})
+}
+
+// Start runs the registered components on a context derived from ctx, cancelling ctx has the same effect on them
+// as Stop but doesn't run the closers
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stopped {
+		return ErrStopped
+	}
+	if l.started {
+		return ErrAlreadyStarted
+	}
+	l.started = true
+	l.ctx, l.cancel = context.WithCancel(ctx)
+	for _, c := range l.pending {
+		l.launch(c)
+	}
+	l.pending = nil
+
+	return nil
+}
+
+// launch runs c in its own goroutine, the first error other than the context's is kept for Stop
+func (l *Lifecycle) launch(c component) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		err := c.run(l.ctx)
+		if err == nil || l.ctx.Err() != nil && errors.Is(err, l.ctx.Err()) {
+			return
+		}
+		l.mu.Lock()
+		if l.err == nil {
+			l.err = errors.Wrap(err, c.name)
+		}
+		l.mu.Unlock()
+	}()
+}
+
+// Stop cancels the components, waits for them to return and runs the closers. It gives up waiting when ctx is done
+// and returns ctx.Err(), otherwise the first error returned by a component or closer. Stop is idempotent.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	if !l.stopped {
+		l.stopped = true
+		if l.finished == nil {
+			l.finished = make(chan struct{})
+		}
+		if l.cancel != nil {
+			l.cancel()
+		}
+		go l.finish()
+	}
+	finished := l.finished
+	l.mu.Unlock()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// finish waits for the components and runs the closers
+func (l *Lifecycle) finish() {
+	l.wg.Wait()
+
+	l.mu.Lock()
+	closers := l.closers
+	l.closers = nil
+	l.mu.Unlock()
+
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].run(context.Background()); err != nil {
+			l.mu.Lock()
+			if l.err == nil {
+				l.err = errors.Wrap(err, closers[i].name)
+			}
+			l.mu.Unlock()
+		}
+	}
+	close(l.finished)
+}
+
+// Done returns a channel closed once Stop finished waiting for the components and closers
+func (l *Lifecycle) Done() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.finished == nil {
+		l.finished = make(chan struct{})
+	}
+	return l.finished
+}
@@ -0,0 +1,58 @@
+package flashxroute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle(t *testing.T) {
+	var lifecycle Lifecycle
+	var order []string
+	stopped := make(chan string, 3)
+	wait := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			stopped <- name
+			return ctx.Err()
+		}
+	}
+
+	require.NoError(t, lifecycle.Go("poller", wait("poller")))
+	lifecycle.OnStop("first", func() error { order = append(order, "first"); return nil })
+	lifecycle.OnStop("second", func() error { order = append(order, "second"); return nil })
+	require.NoError(t, lifecycle.Start(context.Background()))
+	require.ErrorIs(t, lifecycle.Start(context.Background()), ErrAlreadyStarted)
+	require.NoError(t, lifecycle.Go("stream", wait("stream")))
+	require.NoError(t, lifecycle.Go("failing", func(ctx context.Context) error { return errors.New("boom") }))
+
+	done := lifecycle.Done()
+	require.EqualError(t, lifecycle.Stop(context.Background()), "failing: boom")
+	require.ElementsMatch(t, []string{"poller", "stream"}, []string{<-stopped, <-stopped})
+	require.Equal(t, []string{"second", "first"}, order)
+	<-done
+
+	require.EqualError(t, lifecycle.Stop(context.Background()), "failing: boom")
+	require.ErrorIs(t, lifecycle.Go("late", wait("late")), ErrStopped)
+	require.ErrorIs(t, lifecycle.Start(context.Background()), ErrStopped)
+}
+
+func TestLifecycleStopTimeout(t *testing.T) {
+	var lifecycle Lifecycle
+	release := make(chan struct{})
+	require.NoError(t, lifecycle.Go("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+	require.NoError(t, lifecycle.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, lifecycle.Stop(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, lifecycle.Stop(context.Background()))
+}
@@ -0,0 +1,93 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LogLevel - verbosity of the debug log of a subsystem, see WithLogLevel
+type LogLevel int
+
+// Log levels, each includes the previous ones
+const (
+	LogOff      LogLevel = iota
+	LogErrors            // failed calls, dropped connections
+	LogRequests          // every call with its duration, every subscription
+	LogBodies            // requests, responses and notifications in full
+)
+
+// Log subsystems
+const (
+	SubsystemTransport = "transport" // json-rpc calls
+	SubsystemStreams   = "streams"   // websocket subscriptions of FlashXRoute.DialStream
+	SubsystemBundles   = "bundles"   // bundle simulation, submission and cancellation calls
+)
+
+var logLevelNames = []string{"off", "errors", "requests", "bodies"}
+
+// String returns name of the level
+func (l LogLevel) String() string {
+	if l >= 0 && int(l) < len(logLevelNames) {
+		return logLevelNames[l]
+	}
+
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// UnmarshalText parses level name, e.g. in config files
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	for i, name := range logLevelNames {
+		if strings.EqualFold(string(text), name) {
+			*l = LogLevel(i)
+			return nil
+		}
+	}
+
+	return errors.Errorf("unknown log level %q", text)
+}
+
+// MarshalText returns name of the level
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// bundleMethods - methods logged as SubsystemBundles
+var bundleMethods = map[string]bool{
+	"blxr_submit_bundle": true, "blxr_simulate_bundle": true, "blxr_brm_simulate_bundle": true,
+	"submit_arb_only_bundle": true, "eth_sendBundle": true, "eth_callBundle": true, "eth_cancelBundle": true,
+	"mev_sendBundle": true, "mev_simBundle": true,
+}
+
+// methodSubsystem returns the subsystem method is logged as
+func methodSubsystem(method string) string {
+	if bundleMethods[method] {
+		return SubsystemBundles
+	}
+
+	return SubsystemTransport
+}
+
+// logLevel returns the verbosity of subsystem, LogBodies for every subsystem when Debug is set and none is
+// configured with WithLogLevel
+func (rpc *FlashXRoute) logLevel(subsystem string) LogLevel {
+	if level, ok := rpc.logLevels[subsystem]; ok {
+		return level
+	}
+	if level, ok := rpc.logLevels[""]; ok {
+		return level
+	}
+	if rpc.Debug {
+		return LogBodies
+	}
+
+	return LogOff
+}
+
+// debugf logs the message when subsystem is at least at level
+func (rpc *FlashXRoute) debugf(subsystem string, level LogLevel, format string, args ...interface{}) {
+	if rpc.logLevel(subsystem) >= level {
+		rpc.log.Println(fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,103 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *FlashXRouteTestSuite) TestLogLevels() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	})
+	defer server.Close()
+
+	log := new(bufferLogger)
+	rpc := s.rpc.With(WithURL(server.URL), WithLogger(log), WithLogLevel("", LogRequests), WithLogLevel(SubsystemBundles, LogBodies))
+	_, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	_, err = rpc.Call("eth_sendBundle", map[string]string{"blockNumber": "0x10"})
+	s.Require().Nil(err)
+	s.Require().Len(log.lines, 2)
+	s.Require().True(strings.HasPrefix(log.lines[0], "eth_blockNumber 200 "))
+	s.Require().NotContains(log.lines[0], "Response")
+	s.Require().Contains(log.lines[1], `"blockNumber":"0x10"`)
+
+	// Debug logs what isn't configured otherwise in full
+	log.lines = nil
+	rpc = rpc.With(WithDebug(true), WithLogLevel("", LogOff), WithLogLevel(SubsystemTransport, LogErrors))
+	_, err = rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Empty(log.lines)
+	_, err = rpc.Call("eth_sendBundle", map[string]string{"blockNumber": "0x10"})
+	s.Require().Nil(err)
+	s.Require().Len(log.lines, 1)
+
+	var config ClientConfig
+	s.Require().Nil(json.Unmarshal([]byte(`{"logLevels": {"streams": "errors", "": "Requests"}}`), &config))
+	s.Require().Equal(map[string]LogLevel{SubsystemStreams: LogErrors, "": LogRequests}, config.LogLevels)
+	s.Require().NotNil(json.Unmarshal([]byte(`{"logLevels": {"streams": "verbose"}}`), &config))
+}
+
+type syncLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *syncLogger) Println(v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, v[0].(string))
+}
+
+func TestStreamLogLevel(t *testing.T) {
+	conn := new(fakeStreamConn)
+	log := new(syncLogger)
+	stream, err := newStream(context.Background(), "ws://node", StreamOptions{Logger: log, LogLevel: LogRequests}, func(ctx context.Context, url string) (streamConn, error) {
+		return conn, nil
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	heads, err := stream.Subscribe(context.Background(), "eth", "newHeads")
+	require.NoError(t, err)
+	conn.upstreams[0].channel <- json.RawMessage(`"0x1"`)
+	<-heads.Events
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	require.Equal(t, []string{"eth_subscribe [newHeads]"}, log.lines)
+}
+
+func TestClientStreamLogLevel(t *testing.T) {
+	log := new(syncLogger)
+	rpc := New("http://127.0.0.1:8545", WithLogger(log), WithLogLevel(SubsystemStreams, LogErrors))
+	options := rpc.streamOptions(StreamOptions{})
+	require.Equal(t, LogErrors, options.LogLevel)
+
+	conn := new(fakeStreamConn)
+	stream, err := newStream(context.Background(), "ws://node", options, func(ctx context.Context, url string) (streamConn, error) {
+		return conn, nil
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	_, err = stream.Subscribe(context.Background(), "eth", "newHeads")
+	require.NoError(t, err)
+	log.mu.Lock()
+	require.Empty(t, log.lines)
+	log.mu.Unlock()
+
+	own := new(syncLogger)
+	options = rpc.streamOptions(StreamOptions{Logger: own, LogLevel: LogBodies})
+	require.Equal(t, own, options.Logger)
+	require.Equal(t, LogBodies, options.LogLevel)
+
+	rpc = New("http://127.0.0.1:8545", WithLogger(log), WithLogLevel(SubsystemStreams, LogRequests))
+	require.Equal(t, LogRequests, rpc.streamOptions(StreamOptions{}).LogLevel)
+}
@@ -0,0 +1,161 @@
+package flashxroute
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// logRangeErrors - messages of providers refusing eth_getLogs ranges with too many results or blocks
+var logRangeErrors = []string{
+	"more than 10000 results",
+	"query returned more than",
+	"response size exceeded",
+	"response size should not",
+	"block range",
+	"range too large",
+	"range is too large",
+	"too many blocks",
+	"too many results",
+	"too many logs",
+}
+
+// rateLimitErrors - messages of providers throttling requests, some of them with code -32005 too
+var rateLimitErrors = []string{"rate limit", "request rate", "requests per", "request count", "too many requests", "capacity"}
+
+// isLogRangeError reports whether err asks for a smaller eth_getLogs range, rate limit errors don't
+func isLogRangeError(err error) bool {
+	var rpcErr RpcError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	message := strings.ToLower(rpcErr.Message)
+	for _, part := range rateLimitErrors {
+		if strings.Contains(message, part) {
+			return false
+		}
+	}
+	if rpcErr.Code == -32005 {
+		return true
+	}
+	for _, part := range logRangeErrors {
+		if strings.Contains(message, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLogsChunked fetches logs of params.FromBlock..params.ToBlock in chunks of chunkSize blocks using up to
+// concurrency parallel requests. Chunks refused by the provider for returning too many results are halved until
+// they succeed. Logs are returned in block order, the first failure aborts remaining fetches and is returned. An
+// empty or "latest" ToBlock is resolved with eth_blockNumber.
+func (rpc *FlashXRoute) GetLogsChunked(params FilterParams, chunkSize, concurrency int) ([]Log, error) {
+	from, err := ParseInt(params.FromBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "fromBlock")
+	}
+	var to int
+	if params.ToBlock == "" || params.ToBlock == "latest" {
+		to, err = rpc.EthBlockNumber()
+	} else {
+		to, err = ParseInt(params.ToBlock)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "toBlock")
+	}
+	if to < from {
+		return []Log{}, nil
+	}
+
+	if chunkSize < 1 {
+		chunkSize = to - from + 1
+	}
+	chunks := (to-from)/chunkSize + 1
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > chunks {
+		concurrency = chunks
+	}
+
+	results := make([][]Log, chunks)
+	indexes := make(chan int)
+	done := make(chan struct{})
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				start := from + i*chunkSize
+				end := start + chunkSize - 1
+				if end > to {
+					end = to
+				}
+				logs, err := rpc.getLogsRange(params, start, end)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(done)
+					})
+					continue
+				}
+				results[i] = logs
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < chunks; i++ {
+		select {
+		case indexes <- i:
+		case <-done:
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	logs := []Log{}
+	for _, chunk := range results {
+		logs = append(logs, chunk...)
+	}
+
+	return logs, nil
+}
+
+// getLogsRange fetches logs of blocks from..to, halving the range while the provider refuses it
+func (rpc *FlashXRoute) getLogsRange(params FilterParams, from, to int) ([]Log, error) {
+	params.FromBlock, params.ToBlock = IntToHex(from), IntToHex(to)
+	logs, err := rpc.EthGetLogs(params)
+	if err == nil {
+		return logs, nil
+	}
+	if from == to || !isLogRangeError(err) {
+		return nil, errors.Wrapf(err, "logs %d..%d", from, to)
+	}
+
+	middle := from + (to-from)/2
+	first, err := rpc.getLogsRange(params, from, middle)
+	if err != nil {
+		return nil, err
+	}
+	second, err := rpc.getLogsRange(params, middle+1, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(first, second...), nil
+}
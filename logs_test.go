@@ -0,0 +1,67 @@
+package flashxroute
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *FlashXRouteTestSuite) TestGetLogsChunked() {
+	logs := func(numbers ...string) string {
+		result := "["
+		for i, number := range numbers {
+			if i > 0 {
+				result += ","
+			}
+			result += `{"blockNumber":"` + number + `","address":"0x1"}`
+		}
+		return result + "]"
+	}
+	tooMany := `error:{"code":-32000,"message":"query returned more than 10000 results"}`
+	s.registerMethods(map[string]string{
+		`eth_blockNumber`: `"0x8"`,
+		`eth_getLogs [{"fromBlock":"0x1","toBlock":"0x4","address":["0x1"]}]`: tooMany,
+		`eth_getLogs [{"fromBlock":"0x1","toBlock":"0x2","address":["0x1"]}]`: logs("0x1", "0x2"),
+		`eth_getLogs [{"fromBlock":"0x3","toBlock":"0x4","address":["0x1"]}]`: logs("0x4"),
+		`eth_getLogs [{"fromBlock":"0x5","toBlock":"0x8","address":["0x1"]}]`: logs("0x5", "0x7"),
+	})
+
+	result, err := s.rpc.GetLogsChunked(FilterParams{FromBlock: "0x1", ToBlock: "latest", Address: []string{"0x1"}}, 4, 2)
+	s.Require().Nil(err)
+	s.Require().Len(result, 5)
+	for i, number := range []int{1, 2, 4, 5, 7} {
+		s.Require().Equal(number, result[i].BlockNumber)
+	}
+
+	s.registerMethods(map[string]string{
+		`eth_getLogs [{"fromBlock":"0x1","toBlock":"0x2"}]`: tooMany,
+		`eth_getLogs [{"fromBlock":"0x1","toBlock":"0x1"}]`: logs("0x1"),
+		`eth_getLogs [{"fromBlock":"0x2","toBlock":"0x2"}]`: tooMany,
+	})
+
+	_, err = s.rpc.GetLogsChunked(FilterParams{FromBlock: "0x1", ToBlock: "0x2"}, 0, 1)
+	s.Require().ErrorContains(err, "logs 2..2")
+}
+
+func TestIsLogRangeError(t *testing.T) {
+	for _, err := range []error{
+		RpcError{-32000, "query returned more than 10000 results"},
+		RpcError{-32602, "eth_getLogs block range is too large"},
+		RpcError{-32000, "too many blocks in range"},
+		RpcError{-32005, "query timeout exceeded"},
+	} {
+		require.True(t, isLogRangeError(err), err)
+	}
+
+	for _, err := range []error{
+		RpcError{-32005, "project ID request rate exceeded"},
+		RpcError{-32005, "daily request count exceeded, request rate limited"},
+		RpcError{-32029, "too many requests"},
+		RpcError{-32000, "limit exceeded"},
+		RpcError{429, "Your app has exceeded its compute units per second capacity"},
+		errors.New("block range"),
+	} {
+		require.False(t, isLogRangeError(err), err)
+	}
+}
@@ -0,0 +1,179 @@
+package flashxroute
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownBundle is returned when cancelling a bundle the BundleManager didn't submit
+var ErrUnknownBundle = errors.New("unknown bundle uuid")
+
+// bloxrouteSubmission - name of the bloXroute submission in the results of BundleManager
+const bloxrouteSubmission = "bloxroute"
+
+// BundleManager - submits bundles to bloXroute and builders under a replacement uuid, so they can be replaced by
+// submitting again with the same uuid and cancelled on every path at once: with an empty uuid bundle on bloXroute
+// and eth_cancelBundle on the Flashbots relay and builders
+type BundleManager struct {
+	rpc        *FlashXRoute // bloXroute client, nil to submit to builders only
+	authHeader string
+	builders   []*BuilderClient
+
+	mu      sync.Mutex
+	bundles map[string]string // target block of the submitted bundles by uuid
+}
+
+// NewBundleManager create manager submitting with blxr_submit_bundle on rpc, when not nil, and eth_sendBundle on the
+// builders, e.g. a client of the Flashbots relay with a signer
+func NewBundleManager(rpc *FlashXRoute, authHeader string, builders ...*BuilderClient) *BundleManager {
+	return &BundleManager{rpc: rpc, authHeader: authHeader, builders: builders, bundles: map[string]string{}}
+}
+
+// Submit submits params concurrently on every path and returns its replacement uuid, generated when params has
+// none, with one submission per path, bloXroute first. The bundle is checked once by the profit guard, when refused
+// every submission fails with its error. The policy of the bloXroute client also applies to the builders, those it
+// doesn't allow fail with ErrBuilderExcluded.
+func (m *BundleManager) Submit(params SendBundleRequest) (string, []BuilderSubmission) {
+	if params.ReplacementUUID == "" {
+		params.ReplacementUUID = newUUID()
+	}
+	if err := m.guard(params); err != nil {
+		return params.ReplacementUUID, m.each(func(*BuilderClient) (string, error) {
+			return "", err
+		})
+	}
+	m.mu.Lock()
+	m.bundles[params.ReplacementUUID] = params.BlockNumber
+	m.mu.Unlock()
+
+	return params.ReplacementUUID, m.each(func(client *BuilderClient) (string, error) {
+		if client == nil {
+			res, err := m.rpc.bloxrouteSubmitBundle(context.Background(), m.authHeader, bloxrouteBundleRequest(params), false)
+			return res.BundleHash, err
+		}
+		if m.rpc != nil && m.rpc.policy != nil && !m.rpc.policy.Allows(m.rpc.network, false, client.Builder) {
+			return "", errors.Wrap(ErrBuilderExcluded, client.Builder.Name)
+		}
+		res, err := client.sendBundle(params, false)
+		return res.BundleHash, err
+	})
+}
+
+// guard checks params once with the profit guard of the bloXroute client, or of the first builder client having one
+func (m *BundleManager) guard(params SendBundleRequest) error {
+	if m.rpc != nil && m.rpc.guard != nil {
+		return m.rpc.guardBundle(m.authHeader, params.Txs, params.BlockNumber, params.MinTimestamp)
+	}
+	for _, client := range m.builders {
+		if client.guard != nil {
+			return client.guardBundle("", params.Txs, params.BlockNumber, params.MinTimestamp)
+		}
+	}
+
+	return nil
+}
+
+// Cancel cancels the bundle submitted with uuid on every path, builders not accepting replacementUuid are reported
+// with ErrCancelUnsupported. The bundle is forgotten once every other path cancelled it, until then Cancel can be
+// retried.
+func (m *BundleManager) Cancel(uuid string) ([]BuilderSubmission, error) {
+	m.mu.Lock()
+	blockNumber, ok := m.bundles[uuid]
+	m.mu.Unlock()
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownBundle, uuid)
+	}
+
+	submissions := m.each(func(client *BuilderClient) (string, error) {
+		if client == nil {
+			return "", m.rpc.BloxrouteCancelBundle(m.authHeader, uuid, blockNumber)
+		}
+		return "", client.CancelBundle(uuid)
+	})
+	for _, submission := range submissions {
+		if submission.Err != nil && !errors.Is(submission.Err, ErrCancelUnsupported) {
+			return submissions, nil
+		}
+	}
+	m.mu.Lock()
+	delete(m.bundles, uuid)
+	m.mu.Unlock()
+
+	return submissions, nil
+}
+
+// Prune forgets the bundles targeting head or an earlier block, they can't be cancelled anymore, and returns how
+// many were forgotten. Call it with every new head, e.g. from WatchHeads.
+func (m *BundleManager) Prune(head int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pruned := 0
+	for uuid, blockNumber := range m.bundles {
+		if target, err := ParseInt(blockNumber); err == nil && target <= head {
+			delete(m.bundles, uuid)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+// each runs fn concurrently for bloXroute, as a nil client, and every builder
+func (m *BundleManager) each(fn func(client *BuilderClient) (string, error)) []BuilderSubmission {
+	clients := m.builders
+	if m.rpc != nil {
+		clients = append([]*BuilderClient{nil}, clients...)
+	}
+
+	submissions := make([]BuilderSubmission, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		submissions[i].Builder = bloxrouteSubmission
+		if client != nil {
+			submissions[i].Builder = client.Builder.Name
+		}
+
+		wg.Add(1)
+		go func(i int, client *BuilderClient) {
+			defer wg.Done()
+			submissions[i].BundleHash, submissions[i].Err = fn(client)
+		}(i, client)
+	}
+	wg.Wait()
+
+	return submissions
+}
+
+// bloxrouteBundleRequest returns the blxr_submit_bundle params of an eth_sendBundle request
+func bloxrouteBundleRequest(params SendBundleRequest) BloxrouteSubmitBundleRequest {
+	request := BloxrouteSubmitBundleRequest{
+		Transaction:  params.Txs,
+		BlockNumber:  params.BlockNumber,
+		MinTimestamp: params.MinTimestamp,
+		MaxTimestamp: params.MaxTimestamp,
+		Uuid:         params.ReplacementUUID,
+	}
+	if len(params.RevertingTxHashes) > 0 {
+		reverting := append([]string{}, params.RevertingTxHashes...)
+		request.RevertingHashes = &reverting
+	}
+
+	return request
+}
+
+// newUUID returns a random version 4 uuid
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,76 @@
+package flashxroute
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestBundleManager() {
+	var mu sync.Mutex
+	failCancel := true
+	bodies := map[string][][]byte{}
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		name, body := r.Header.Get("X-Builder"), s.getBody(r)
+		switch name {
+		case "":
+			bodies["bloxroute"] = append(bodies["bloxroute"], body)
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xa"}}`))
+			return
+		case "flashbots":
+			s.Require().NotEmpty(r.Header.Get("X-Flashbots-Signature"))
+			if gjson.GetBytes(body, "method").String() == "eth_cancelBundle" && failCancel {
+				failCancel = false
+				w.Write([]byte(`{"error": "busy"}`))
+				return
+			}
+		}
+		bodies[name] = append(bodies[name], body)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	key, _ := crypto.GenerateKey()
+	flashbots, _ := NewBuilderClient(Builder{Name: "flashbots", URL: server.URL, Headers: map[string]string{"X-Builder": "flashbots"}},
+		WithSigner(NewPrivateKeySigner(key)))
+	legacy, _ := NewBuilderClient(Builder{Name: "legacy", URL: server.URL, Headers: map[string]string{"X-Builder": "legacy"},
+		Fields: []string{BundleFieldRevertingTxHashes}})
+	manager := NewBundleManager(s.rpc.With(WithURL(server.URL)), "auth", flashbots, legacy)
+
+	uuid, submissions := manager.Submit(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	s.Require().Len(uuid, 36)
+	s.Require().Equal([]BuilderSubmission{{Builder: "bloxroute", BundleHash: "0xa"}, {Builder: "flashbots", BundleHash: "0xb"},
+		{Builder: "legacy", BundleHash: "0xb"}}, submissions)
+	s.Require().Equal(uuid, gjson.GetBytes(bodies["bloxroute"][0], "params.uuid").String())
+	s.Require().Equal(uuid, gjson.GetBytes(bodies["flashbots"][0], "params.0.replacementUuid").String())
+
+	// a failed cancellation can be retried
+	submissions, err := manager.Cancel(uuid)
+	s.Require().Nil(err)
+	s.Require().ErrorIs(submissions[1].Err, ErrRelayErrorResponse)
+	submissions, err = manager.Cancel(uuid)
+	s.Require().Nil(err)
+	s.Require().Nil(submissions[0].Err)
+	s.Require().Nil(submissions[1].Err)
+	s.Require().ErrorIs(submissions[2].Err, ErrCancelUnsupported)
+	s.Require().Equal(`{"transaction":[],"block_number":"0x10","uuid":"`+uuid+`"}`, gjson.GetBytes(bodies["bloxroute"][2], "params").Raw)
+	s.Require().Equal("eth_cancelBundle", gjson.GetBytes(bodies["flashbots"][1], "method").String())
+	s.Require().Equal(uuid, gjson.GetBytes(bodies["flashbots"][1], "params.0.replacementUuid").String())
+	s.Require().Len(bodies["legacy"], 1)
+
+	_, err = manager.Cancel(uuid)
+	s.Require().ErrorIs(err, ErrUnknownBundle)
+
+	for _, block := range []string{"0x11", "0x12"} {
+		manager.Submit(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: block, ReplacementUUID: block})
+	}
+	s.Require().Equal(1, manager.Prune(0x11))
+	_, err = manager.Cancel("0x11")
+	s.Require().ErrorIs(err, ErrUnknownBundle)
+	_, err = manager.Cancel("0x12")
+	s.Require().Nil(err)
+}
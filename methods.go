@@ -0,0 +1,86 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// submissionMethods - state-changing methods: recorded instead of sent in dry-run mode, never hedged, retried only
+// when configured by exact name and deduplicated by WithDeduplication when they submit a bundle
+var submissionMethods = map[string]bool{
+	"eth_sendRawTransaction": true, "eth_sendTransaction": true, "personal_sendTransaction": true,
+	"eth_sendPrivateRawTransaction": true, "blxr_tx": true, "blxr_private_tx": true, "blxr_submit_bundle": true, "submit_arb_only_bundle": true,
+	"eth_sendBundle": true, "mev_sendBundle": true, "eth_cancelBundle": true, "flashbots_setFeeRefundRecipient": true,
+}
+
+// MethodConfig - timeout and retries of a method or class of methods, zero values fall back to the client defaults.
+// Transactions and bundles are only retried after failures to connect, unless configured by exact method name.
+type MethodConfig struct {
+	Timeout    time.Duration // request timeout instead of Timeout
+	Retries    int           // retries after transport failures, json-rpc and relay errors are returned right away
+	RetryDelay time.Duration // delay before each retry
+}
+
+// methodConfig returns the config of method: an exact match, else the longest matching prefix pattern like
+// "trace_*", else the "*" pattern
+func (rpc *FlashXRoute) methodConfig(method string) MethodConfig {
+	if config, ok := rpc.methods[method]; ok {
+		return config
+	}
+
+	var best string
+	var config MethodConfig
+	for pattern, c := range rpc.methods {
+		prefix := strings.TrimSuffix(pattern, "*")
+		if prefix != pattern && strings.HasPrefix(method, prefix) && len(pattern) > len(best) {
+			best, config = pattern, c
+		}
+	}
+
+	return config
+}
+
+// timeout returns the request timeout of method
+func (rpc *FlashXRoute) timeout(method string) time.Duration {
+	if timeout := rpc.methodConfig(method).Timeout; timeout > 0 {
+		return timeout
+	}
+
+	return rpc.Timeout
+}
+
+// retry calls fn and retries it after transport failures as configured for method. A state-changing request may have
+// reached the endpoint before failing, so it is only retried after failing to connect, unless the config of its exact
+// name asks for retries: patterns like "*" or "blxr_*" never re-send a transaction or bundle.
+func (rpc *FlashXRoute) retry(method string, stateChanging bool, fn func() (json.RawMessage, error)) (json.RawMessage, error) {
+	config := rpc.methodConfig(method)
+	_, exact := rpc.methods[method]
+	again := func(err error) bool {
+		return retryable(err) && (!stateChanging || exact || dialError(err))
+	}
+
+	result, err := fn()
+	for attempt := 0; attempt < config.Retries && err != nil && again(err); attempt++ {
+		time.Sleep(config.RetryDelay)
+		result, err = fn()
+	}
+
+	return result, err
+}
+
+// dialError reports whether err is a failure to connect, the request wasn't sent
+func dialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryable reports whether err is a transport failure rather than an answer of the endpoint
+func retryable(err error) bool {
+	var rpcErr RpcError
+	var unprofitable *UnprofitableError
+	return !errors.As(err, &rpcErr) && !errors.Is(err, ErrRelayErrorResponse) && !errors.As(err, &unprofitable)
+}
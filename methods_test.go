@@ -0,0 +1,91 @@
+package flashxroute
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestMethodConfig() {
+	rpc := s.rpc.With(WithMethodConfig(map[string]MethodConfig{
+		"*":                      {Timeout: time.Second},
+		"eth_*":                  {Retries: 2},
+		"eth_getLogs":            {Timeout: time.Minute},
+		"eth_sendRawTransaction": {Retries: 0},
+	}))
+
+	s.Require().Equal(time.Minute, rpc.timeout("eth_getLogs"))
+	s.Require().Equal(time.Second, rpc.timeout("trace_block"))
+	s.Require().Equal(s.rpc.Timeout, s.rpc.timeout("trace_block"))
+	s.Require().Equal(2, rpc.methodConfig("eth_blockNumber").Retries)
+	s.Require().Equal(0, rpc.methodConfig("eth_sendRawTransaction").Retries)
+
+	httpmock.Reset()
+	calls := 0
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("connection reset")
+		}
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+
+	number, err := rpc.EthBlockNumber()
+	s.Require().Nil(err)
+	s.Require().Equal(1, number)
+	s.Require().Equal(3, calls)
+
+	calls = 0
+	_, err = rpc.EthSendRawTransaction("0x00")
+	s.Require().NotNil(err)
+	s.Require().Equal(1, calls)
+
+	// patterns never re-send state-changing requests which may have reached the endpoint
+	calls = 0
+	patterns := s.rpc.With(WithMethodConfig(map[string]MethodConfig{"*": {Retries: 2}}))
+	_, err = patterns.EthSendRawTransaction("0x00")
+	s.Require().NotNil(err)
+	s.Require().Equal(1, calls)
+
+	// the connection drops after the bundle was written
+	var submitted int32
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&submitted, 1)
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer relay.Close()
+	_, err = patterns.With(WithURL(relay.URL)).BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"00"}, BlockNumber: "0x10"})
+	s.Require().NotNil(err)
+	s.Require().EqualValues(1, atomic.LoadInt32(&submitted))
+
+	// unless the method asks for it by name
+	calls = 0
+	named := s.rpc.With(WithMethodConfig(map[string]MethodConfig{"eth_sendRawTransaction": {Retries: 2}}))
+	_, err = named.EthSendRawTransaction("0x00")
+	s.Require().Nil(err)
+	s.Require().Equal(3, calls)
+
+	// failures to connect are retried, the request wasn't sent
+	httpmock.Reset()
+	calls = 0
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": "0x1"}`), nil
+	})
+	_, err = patterns.EthSendRawTransaction("0x00")
+	s.Require().Nil(err)
+	s.Require().Equal(3, calls)
+
+	s.registerMethods(map[string]string{"eth_blockNumber": `error:{"code": -32000, "message": "failed"}`})
+	_, err = rpc.EthBlockNumber()
+	s.Require().IsType(RpcError{}, err)
+}
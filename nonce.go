@@ -0,0 +1,62 @@
+package flashxroute
+
+import (
+	"strings"
+	"sync"
+)
+
+// NonceManager - hands out consecutive nonces per sender without a round trip per transaction, the first nonce of
+// an address is read from the node at Tag
+type NonceManager struct {
+	rpc *FlashXRoute
+	Tag string // block tag the first nonce is read at: "pending" (default) counts mempool transactions, "latest" only mined ones
+
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewNonceManager create nonce manager reading first nonces at tag, "pending" when empty
+func NewNonceManager(rpc *FlashXRoute, tag string) *NonceManager {
+	if tag == "" {
+		tag = "pending"
+	}
+
+	return &NonceManager{rpc: rpc, Tag: tag, next: map[string]int{}}
+}
+
+// Next returns the nonce to use for the next transaction of address and reserves it
+func (m *NonceManager) Next(address string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.ToLower(address)
+	nonce, ok := m.next[key]
+	if !ok {
+		var err error
+		if nonce, err = m.rpc.EthGetTransactionCount(address, m.Tag); err != nil {
+			return 0, err
+		}
+	}
+	m.next[key] = nonce + 1
+
+	return nonce, nil
+}
+
+// Reset forgets the nonces reserved for address, the next one is read from the node again, e.g. after a
+// transaction was dropped or sent outside the manager
+func (m *NonceManager) Reset(address string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.next, strings.ToLower(address))
+}
+
+// Peek returns the nonce Next would return for address without reserving it, false when the manager didn't read
+// the nonce of address yet
+func (m *NonceManager) Peek(address string) (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce, ok := m.next[strings.ToLower(address)]
+	return nonce, ok
+}
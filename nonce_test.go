@@ -0,0 +1,32 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestNonces() {
+	address := "0xD10E3Be2bc8f959Bc8C41CF65F60dE721cF89ADF"
+	s.registerMethods(map[string]string{
+		`eth_getTransactionCount ["` + address + `","pending"]`: `"0x7"`,
+		`eth_getTransactionCount ["` + address + `","latest"]`:  `"0x5"`,
+		`eth_getTransactionCount ["` + address + `","0x10"]`:    `"0x3"`,
+	})
+
+	nonce, err := s.rpc.PendingNonceAt(address)
+	s.Require().Nil(err)
+	s.Require().Equal(7, nonce)
+	nonce, err = s.rpc.NonceAt(address, "")
+	s.Require().Nil(err)
+	s.Require().Equal(5, nonce)
+	nonce, err = s.rpc.NonceAt(address, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(3, nonce)
+
+	manager := NewNonceManager(s.rpc, "")
+	for _, expected := range []int{7, 8, 9} {
+		nonce, err = manager.Next(address)
+		s.Require().Nil(err)
+		s.Require().Equal(expected, nonce)
+	}
+	manager.Reset(address)
+	manager.Tag = "latest"
+	nonce, err = manager.Next(address)
+	s.Require().Nil(err)
+	s.Require().Equal(5, nonce)
+}
@@ -0,0 +1,196 @@
+package flashxroute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NonceIssueKind - kind of problem found by a NonceWatcher
+type NonceIssueKind int
+
+// Nonce issue kinds
+const (
+	NonceStuck NonceIssueKind = iota // pending transactions not mined for longer than StuckAfter
+	NonceGap                         // nonces handed out by the NonceManager missing from the mempool, later ones can't be mined
+)
+
+func (kind NonceIssueKind) String() string {
+	switch kind {
+	case NonceStuck:
+		return "stuck"
+	case NonceGap:
+		return "gap"
+	}
+
+	return fmt.Sprintf("NonceIssueKind(%d)", int(kind))
+}
+
+// NonceIssue - stuck or missing transactions of a monitored address
+type NonceIssue struct {
+	Kind     NonceIssueKind
+	Address  string
+	Nonce    int       // first stuck or missing nonce, the one to replace or resend
+	Latest   int       // nonce at the latest block, the number of mined transactions
+	Pending  int       // nonce at the pending block, mined and contiguous mempool transactions
+	Expected int       // next nonce of the NonceManager, 0 without one
+	Since    time.Time // when the latest nonce last changed
+	Err      error     // error of Replace, if called
+}
+
+// nonceState - last nonces seen for a monitored address
+type nonceState struct {
+	latest   int
+	since    time.Time
+	reported map[NonceIssueKind]int // nonce each kind was last reported for
+}
+
+// NonceWatcher - polls latest and pending nonces of monitored addresses to detect transactions stuck in the mempool
+// and nonce holes left by transactions handed a nonce by a NonceManager but never sent or dropped. Each issue is
+// reported once per nonce: passed to Replace, e.g. resending the transaction with bumped fees, then to OnIssue.
+type NonceWatcher struct {
+	StuckAfter time.Duration                // how long pending transactions may wait before they are stuck (default: 1m)
+	Replace    func(issue NonceIssue) error // optional replacement of the stuck or missing transaction
+	OnIssue    func(issue NonceIssue)       // optional alert hook
+
+	rpc     *FlashXRoute
+	manager *NonceManager
+	now     func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*nonceState
+}
+
+// NewNonceWatcher create watcher of addresses, manager is optional and enables nonce hole detection
+func NewNonceWatcher(rpc *FlashXRoute, manager *NonceManager, addresses ...string) *NonceWatcher {
+	w := &NonceWatcher{StuckAfter: time.Minute, rpc: rpc, manager: manager, now: time.Now, states: map[string]*nonceState{}}
+	for _, address := range addresses {
+		w.Watch(address)
+	}
+
+	return w
+}
+
+// Watch adds address to the monitored addresses
+func (w *NonceWatcher) Watch(address string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := strings.ToLower(address)
+	if _, ok := w.states[key]; !ok {
+		w.states[key] = &nonceState{latest: -1}
+	}
+}
+
+// Unwatch removes address from the monitored addresses
+func (w *NonceWatcher) Unwatch(address string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.states, strings.ToLower(address))
+}
+
+// Check polls the nonces of the monitored addresses once and returns the new issues, after Replace and OnIssue
+// were called for them
+func (w *NonceWatcher) Check() ([]NonceIssue, error) {
+	w.mu.Lock()
+	addresses := make([]string, 0, len(w.states))
+	for address := range w.states {
+		addresses = append(addresses, address)
+	}
+	w.mu.Unlock()
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	counts := make([]hexInt, 2*len(addresses))
+	requests := make([]BatchRequest, 0, len(counts))
+	for i, address := range addresses {
+		requests = append(requests,
+			BatchRequest{Method: "eth_getTransactionCount", Params: []interface{}{address, "latest"}, Result: &counts[2*i]},
+			BatchRequest{Method: "eth_getTransactionCount", Params: []interface{}{address, "pending"}, Result: &counts[2*i+1]},
+		)
+	}
+	for _, result := range w.rpc.batchOrEach(requests...) {
+		if result.Err != nil {
+			return nil, errors.Wrap(result.Err, result.Method)
+		}
+	}
+
+	var issues []NonceIssue
+	for i, address := range addresses {
+		issues = append(issues, w.detect(address, int(counts[2*i]), int(counts[2*i+1]))...)
+	}
+	for i := range issues {
+		if w.Replace != nil {
+			issues[i].Err = w.Replace(issues[i])
+		}
+		if w.OnIssue != nil {
+			w.OnIssue(issues[i])
+		}
+	}
+
+	return issues, nil
+}
+
+// detect updates the state of address with its latest and pending nonces and returns its unreported issues
+func (w *NonceWatcher) detect(address string, latest, pending int) []NonceIssue {
+	now := w.now()
+	expected, managed := 0, false
+	if w.manager != nil {
+		expected, managed = w.manager.Peek(address)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state, ok := w.states[address]
+	if !ok {
+		return nil
+	}
+	if latest != state.latest {
+		state.latest, state.since, state.reported = latest, now, map[NonceIssueKind]int{}
+	}
+
+	issue := NonceIssue{Address: address, Latest: latest, Pending: pending, Expected: expected, Since: state.since}
+	var issues []NonceIssue
+	report := func(kind NonceIssueKind, nonce int) {
+		if reported, ok := state.reported[kind]; ok && reported == nonce {
+			return
+		}
+		state.reported[kind] = nonce
+		issue.Kind, issue.Nonce = kind, nonce
+		issues = append(issues, issue)
+	}
+
+	if managed && expected > pending {
+		report(NonceGap, pending)
+	}
+	if pending > latest && now.Sub(state.since) >= w.StuckAfter {
+		report(NonceStuck, latest)
+	}
+
+	return issues
+}
+
+// Run checks the monitored addresses every interval until ctx is done, errors of single polls are passed to
+// onError when not nil
+func (w *NonceWatcher) Run(ctx context.Context, interval time.Duration, onError func(err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Check(); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
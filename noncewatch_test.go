@@ -0,0 +1,60 @@
+package flashxroute
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (s *FlashXRouteTestSuite) TestNonceWatcher() {
+	address := "0x00000000000000000000000000000000000000aa"
+	s.registerMethods(map[string]string{
+		`eth_getTransactionCount ["` + address + `","latest"]`:  `"0x5"`,
+		`eth_getTransactionCount ["` + address + `","pending"]`: `"0x6"`,
+	})
+
+	manager := NewNonceManager(s.rpc, "")
+	manager.next[address] = 7
+	watcher := NewNonceWatcher(s.rpc, manager, "0x00000000000000000000000000000000000000AA")
+	now := time.Unix(1700000000, 0)
+	watcher.now = func() time.Time { return now }
+	var replaced, alerted []NonceIssue
+	watcher.Replace = func(issue NonceIssue) error {
+		replaced = append(replaced, issue)
+		return errors.New("underpriced")
+	}
+	watcher.OnIssue = func(issue NonceIssue) { alerted = append(alerted, issue) }
+
+	issues, err := watcher.Check()
+	s.Require().Nil(err)
+	s.Require().Equal([]NonceIssue{{Kind: NonceGap, Address: address, Nonce: 6, Latest: 5, Pending: 6, Expected: 7, Since: now,
+		Err: issues[0].Err}}, issues)
+	s.Require().EqualError(issues[0].Err, "underpriced")
+
+	now = now.Add(2 * time.Minute)
+	issues, err = watcher.Check()
+	s.Require().Nil(err)
+	s.Require().Len(issues, 1)
+	s.Require().Equal(NonceStuck, issues[0].Kind)
+	s.Require().Equal(5, issues[0].Nonce)
+	s.Require().Equal("stuck", issues[0].Kind.String())
+
+	issues, err = watcher.Check()
+	s.Require().Nil(err)
+	s.Require().Empty(issues)
+	s.Require().Len(replaced, 2)
+	s.Require().Len(alerted, 2)
+
+	s.registerMethods(map[string]string{
+		`eth_getTransactionCount ["` + address + `","latest"]`:  `"0x6"`,
+		`eth_getTransactionCount ["` + address + `","pending"]`: `"0x7"`,
+	})
+	issues, err = watcher.Check()
+	s.Require().Nil(err)
+	s.Require().Empty(issues)
+
+	watcher.Unwatch(address)
+	issues, err = watcher.Check()
+	s.Require().Nil(err)
+	s.Require().Empty(issues)
+}
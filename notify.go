@@ -0,0 +1,152 @@
+package flashxroute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BundleEventKind - kind of bundle lifecycle event
+type BundleEventKind int
+
+// Bundle lifecycle events
+const (
+	BundleSubmitted        BundleEventKind = iota // bundle sent to the relay, Err is set when the relay refused it
+	BundleIncluded                                // first transaction landed in IncludedIn
+	BundleExpired                                 // target block passed without the bundle
+	BundleSimulationFailed                        // simulation before submission failed or was unprofitable, nothing sent
+	BundleDuplicate                               // suppressed by WithDeduplication, nothing sent, BundleHash is the earlier submission's
+)
+
+var bundleEventNames = []string{"submitted", "included", "expired", "simulation_failed", "duplicate"}
+
+// String returns name of the event kind
+func (k BundleEventKind) String() string {
+	if k >= 0 && int(k) < len(bundleEventNames) {
+		return bundleEventNames[k]
+	}
+
+	return fmt.Sprintf("BundleEventKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind by name
+func (k BundleEventKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText decodes kind name
+func (k *BundleEventKind) UnmarshalText(text []byte) error {
+	for i, name := range bundleEventNames {
+		if name == string(text) {
+			*k = BundleEventKind(i)
+			return nil
+		}
+	}
+
+	return errors.Errorf("unknown bundle event %q", text)
+}
+
+// BundleEvent - notification about a bundle
+type BundleEvent struct {
+	Kind         BundleEventKind `json:"kind"`
+	Time         time.Time       `json:"time"`
+	Relay        string          `json:"relay"`
+	BlockNumber  int             `json:"blockNumber"` // target block
+	BundleHash   string          `json:"bundleHash,omitempty"`
+	Transactions []string        `json:"transactions,omitempty"`
+	IncludedIn   int             `json:"includedIn,omitempty"`
+	Err          string          `json:"error,omitempty"`
+}
+
+// BundleNotifier - receives bundle events, notifiers are called synchronously so slow ones should hand events off
+type BundleNotifier func(event BundleEvent)
+
+// notify sends event to every notifier set with WithNotifier
+func (rpc *FlashXRoute) notify(event BundleEvent) {
+	if len(rpc.notifiers) == 0 {
+		return
+	}
+
+	event.Time = time.Now()
+	if event.Relay == "" {
+		event.Relay = rpc.url
+	}
+	for _, notifier := range rpc.notifiers {
+		notifier(event)
+	}
+}
+
+// WebhookNotifier - posts bundle events as json to an http endpoint in the background
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+	Kinds   []BundleEventKind                  // events to post (default: all)
+	Client  *http.Client                       // default: client with 10s timeout
+	OnError func(event BundleEvent, err error) // called when posting fails or the endpoint does not answer 2xx
+
+	inflight sync.WaitGroup
+}
+
+// Notify posts event unless filtered out by Kinds, use it as BundleNotifier
+func (w *WebhookNotifier) Notify(event BundleEvent) {
+	if len(w.Kinds) > 0 {
+		wanted := false
+		for _, kind := range w.Kinds {
+			wanted = wanted || kind == event.Kind
+		}
+		if !wanted {
+			return
+		}
+	}
+
+	w.inflight.Add(1)
+	go func() {
+		defer w.inflight.Done()
+		if err := w.post(event); err != nil && w.OnError != nil {
+			w.OnError(event, err)
+		}
+	}()
+}
+
+// Close waits for the events being posted, register it with Lifecycle.OnStop so no event is lost on shutdown
+func (w *WebhookNotifier) Close() error {
+	w.inflight.Wait()
+	return nil
+}
+
+func (w *WebhookNotifier) post(event BundleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", w.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Add(k, v)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		return errors.Errorf("webhook %s answered %s", w.URL, response.Status)
+	}
+
+	return nil
+}
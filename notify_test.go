@@ -0,0 +1,69 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestNotifier() {
+	relay := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		result := `{"bundleHash": "0xb"}`
+		if gjson.GetBytes(body, "method").String() == "blxr_simulate_bundle" {
+			result = `{"bundleHash": "0xb", "coinbaseDiff": "10", "results": []}`
+		}
+		w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result)))
+	})
+	defer relay.Close()
+
+	posted := make(chan BundleEvent, 2)
+	webhook := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		var event BundleEvent
+		s.Require().Nil(json.Unmarshal(s.getBody(r), &event))
+		s.Require().Equal("secret", r.Header.Get("X-Token"))
+		posted <- event
+	})
+	defer webhook.Close()
+
+	var events []BundleEvent
+	notifier := &WebhookNotifier{URL: webhook.URL, Headers: map[string]string{"X-Token": "secret"}, Kinds: []BundleEventKind{BundleSimulationFailed}}
+	rpc := s.rpc.With(WithURL(relay.URL), WithNotifier(func(event BundleEvent) { events = append(events, event) }), WithNotifier(notifier.Notify))
+
+	_, err := rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().Nil(err)
+	s.Require().Len(events, 1)
+	s.Require().Equal(BundleSubmitted, events[0].Kind)
+	s.Require().Equal(16, events[0].BlockNumber)
+	s.Require().Equal("0xb", events[0].BundleHash)
+	s.Require().Equal(relay.URL, events[0].Relay)
+
+	guarded := rpc.With(WithProfitGuard(ProfitGuard{
+		MinProfit: big.NewInt(100),
+		Profit: func(res BloxrouteSimulateBundleResponse) (*big.Int, error) {
+			return big.NewInt(10), nil
+		},
+	}))
+	_, err = guarded.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x11"})
+	s.Require().ErrorIs(err, ErrUnprofitable)
+	s.Require().Len(events, 2)
+	s.Require().Equal(BundleSimulationFailed, events[1].Kind)
+
+	select {
+	case event := <-posted:
+		s.Require().Equal(BundleSimulationFailed, event.Kind)
+		s.Require().Equal(17, event.BlockNumber)
+		s.Require().Contains(event.Err, "below minimum")
+	case <-time.After(time.Second):
+		s.FailNow("webhook not called")
+	}
+	s.Require().Len(posted, 0)
+
+	data, err := json.Marshal(BundleEvent{Kind: BundleExpired})
+	s.Require().Nil(err)
+	s.Require().Equal("expired", gjson.GetBytes(data, "kind").String())
+}
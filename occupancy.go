@@ -0,0 +1,117 @@
+package flashxroute
+
+import (
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// OccupancyEstimator - estimates the probability the target block has room for a bundle from the gasUsed/gasLimit
+// ratios of recent blocks and the mempool pressure, the gas of pending transactions seen on feeds since the last
+// block. Safe for concurrent use, e.g. fed by Stream subscriptions and read by a Scheduler callback.
+type OccupancyEstimator struct {
+	window int
+
+	mu         sync.Mutex
+	ratios     []float64 // gasUsed/gasLimit of the recent blocks, oldest first
+	gasLimit   uint64    // gas limit of the latest block
+	pendingGas uint64
+}
+
+// NewOccupancyEstimator create estimator sampling the given number of recent blocks (default: 20)
+func NewOccupancyEstimator(window int) *OccupancyEstimator {
+	if window <= 0 {
+		window = 20
+	}
+
+	return &OccupancyEstimator{window: window}
+}
+
+// Load replaces the recent blocks with the gas used ratios of the latest blocks and the gas limit of the latest one
+func (e *OccupancyEstimator) Load(rpc *FlashXRoute) error {
+	head, err := rpc.getBlockHeader("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return errors.New("latest block not found")
+	}
+	history, err := rpc.EthFeeHistory(e.window, "latest", nil)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ratios = append([]float64(nil), history.GasUsedRatio...)
+	if len(e.ratios) > e.window {
+		e.ratios = e.ratios[len(e.ratios)-e.window:]
+	}
+	e.gasLimit = uint64(head.GasLimit)
+	e.pendingGas = 0
+
+	return nil
+}
+
+// AddBlock records a new block, e.g. of a newHeads subscription, pending gas seen so far is assumed to be included
+func (e *OccupancyEstimator) AddBlock(gasUsed, gasLimit uint64) {
+	if gasLimit == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ratios = append(e.ratios, math.Min(float64(gasUsed)/float64(gasLimit), 1))
+	if len(e.ratios) > e.window {
+		e.ratios = e.ratios[len(e.ratios)-e.window:]
+	}
+	e.gasLimit = gasLimit
+	e.pendingGas = 0
+}
+
+// AddPending adds the gas limit of a pending transaction seen on a mempool feed to the pressure on the next block
+func (e *OccupancyEstimator) AddPending(gas uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pendingGas += gas
+}
+
+// PendingGas returns the gas of the pending transactions seen since the last block
+func (e *OccupancyEstimator) PendingGas() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.pendingGas
+}
+
+// RoomProbability returns the estimated probability the next block has room for a bundle of gas: the share of the
+// recent blocks which would have left gas unused, counting at least the pending gas as used. Without recent blocks
+// it returns 1, the estimator can't tell.
+func (e *OccupancyEstimator) RoomProbability(gas uint64) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.ratios) == 0 {
+		return 1
+	}
+	if gas > e.gasLimit {
+		return 0
+	}
+
+	limit := float64(e.gasLimit)
+	room := 0
+	for _, ratio := range e.ratios {
+		used := math.Max(ratio*limit, float64(e.pendingGas))
+		if limit-used >= float64(gas) {
+			room++
+		}
+	}
+
+	return float64(room) / float64(len(e.ratios))
+}
+
+// Admit reports whether a bundle of gas has room in the next block with at least probability minProbability, for
+// a Scheduler callback deciding whether to submit or wait for a later block
+func (e *OccupancyEstimator) Admit(gas uint64, minProbability float64) bool {
+	return e.RoomProbability(gas) >= minProbability
+}
@@ -0,0 +1,46 @@
+package flashxroute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccupancyEstimator(t *testing.T) {
+	estimator := NewOccupancyEstimator(4)
+	require.Equal(t, 1.0, estimator.RoomProbability(1000000))
+
+	for _, gasUsed := range []uint64{30000000, 29000000, 20000000, 15000000, 10000000} {
+		estimator.AddBlock(gasUsed, 30000000)
+	}
+	// the first block left the window
+	require.Equal(t, 1.0, estimator.RoomProbability(500000))
+	require.Equal(t, 0.75, estimator.RoomProbability(5000000))
+	require.Equal(t, 0.25, estimator.RoomProbability(15000001))
+	require.Equal(t, 0.0, estimator.RoomProbability(30000001))
+
+	estimator.AddPending(18000000)
+	estimator.AddPending(1000000)
+	require.Equal(t, uint64(19000000), estimator.PendingGas())
+	require.Equal(t, 0.75, estimator.RoomProbability(5000000))
+	require.Equal(t, 0.0, estimator.RoomProbability(12000000))
+	require.False(t, estimator.Admit(12000000, 0.5))
+	require.True(t, estimator.Admit(5000000, 0.5))
+
+	estimator.AddBlock(0, 30000000)
+	require.Equal(t, uint64(0), estimator.PendingGas())
+	require.Equal(t, 0.75, estimator.RoomProbability(12000000))
+}
+
+func (s *FlashXRouteTestSuite) TestOccupancyEstimatorLoad() {
+	s.registerMethods(map[string]string{
+		"eth_getBlockByNumber": `{"number": "0x10", "gasLimit": "0x1c9c380", "gasUsed": "0xe4e1c0", "transactions": []}`,
+		"eth_feeHistory":       `{"oldestBlock": "0x7", "baseFeePerGas": [], "gasUsedRatio": [0.25, 0.5, 1]}`,
+	})
+
+	estimator := NewOccupancyEstimator(0)
+	estimator.AddPending(1)
+	s.Require().Nil(estimator.Load(s.rpc))
+	s.Require().Equal(uint64(0), estimator.PendingGas())
+	s.Require().InDelta(2.0/3, estimator.RoomProbability(15000000), 1e-9)
+}
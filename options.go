@@ -3,6 +3,7 @@ package flashxroute
 import (
 	"io"
 	"net/http"
+	"time"
 )
 
 type httpClient interface {
@@ -27,9 +28,261 @@ func WithLogger(l logger) func(rpc *FlashXRoute) {
 	}
 }
 
-// WithDebug set debug flag
+// WithDebug set debug flag, logging requests and responses of every subsystem in full, see WithLogLevel
 func WithDebug(enabled bool) func(rpc *FlashXRoute) {
 	return func(rpc *FlashXRoute) {
 		rpc.Debug = enabled
 	}
 }
+
+// WithLogLevel set debug log verbosity of subsystem, one of Subsystem*, or of every subsystem not set otherwise
+// when subsystem is empty, e.g. WithLogLevel("", LogRequests) with WithLogLevel(SubsystemBundles, LogBodies)
+func WithLogLevel(subsystem string, level LogLevel) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		levels := make(map[string]LogLevel, len(rpc.logLevels)+1)
+		for k, v := range rpc.logLevels {
+			levels[k] = v
+		}
+		levels[subsystem] = level
+		rpc.logLevels = levels
+	}
+}
+
+// WithURL set rpc url
+func WithURL(url string) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.url = url
+		rpc.chainID = &chainIDCache{}
+		if rpc.cache != nil {
+			rpc.cache = newObjectCache(rpc.cache.size, rpc.cache.depth)
+		}
+	}
+}
+
+// WithHeader set additional header sent with every request
+func WithHeader(key, value string) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.Headers[key] = value
+	}
+}
+
+// WithTimeout set request timeout
+func WithTimeout(timeout time.Duration) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.Timeout = timeout
+	}
+}
+
+// WithBloxrouteAuthHeader set default bloXroute Authorization header used when a call passes an empty one
+func WithBloxrouteAuthHeader(authHeader string) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.authHeader = authHeader
+	}
+}
+
+// WithNetwork set bloXroute blockchain network name (Mainnet, BSC-Mainnet, Polygon-Mainnet)
+func WithNetwork(network string) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.network = network
+	}
+}
+
+// WithPersonalAPI enable personal_* methods, they send account passwords to the node so only use them against local dev nodes
+func WithPersonalAPI(enabled bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.personal = enabled
+	}
+}
+
+// WithSigner set signer used for transactions and relay signatures. Hardware wallet signers only sign transactions,
+// relay calls signed with them fail with ErrTextSigningUnsupported.
+func WithSigner(signer Signer) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.signer = signer
+	}
+}
+
+// WithPolicy set builder selection policy applied to bundles submitted without mev_builders
+func WithPolicy(policy Policy) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.policy = &policy
+	}
+}
+
+// WithProfitGuard simulate every bundle before submission and refuse those netting less than guard.MinProfit
+func WithProfitGuard(guard ProfitGuard) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.guard = &guard
+	}
+}
+
+// WithDryRun record state-changing requests (raw transactions, bloXroute transactions and bundles) and return synthetic
+// results instead of sending them, see DryRunRequests
+func WithDryRun(enabled bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		if enabled {
+			rpc.recorder = &dryRunRecorder{}
+		} else {
+			rpc.recorder = nil
+		}
+	}
+}
+
+// WithHedging race read calls against a second endpoint, the same request is sent to url after delay unless the
+// primary endpoint answered by then and the first successful response is returned. State-changing calls are never
+// hedged.
+func WithHedging(url string, delay time.Duration) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.hedge = &hedge{url: url, delay: delay}
+	}
+}
+
+// WithClockCheck remember the clock drift measured by CheckClock, drift beyond threshold is logged and, if adjust is
+// set, bundle min/max timestamps are shifted by it before submission
+func WithClockCheck(threshold time.Duration, adjust bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.clock = &clock{threshold: threshold, adjust: adjust}
+	}
+}
+
+// WithAuth authenticate every request with the provider, e.g. BearerToken, HMACAuth or RefreshingToken. Headers it
+// sets take precedence over Headers and the bloXroute Authorization header.
+func WithAuth(auth AuthProvider) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.auth = auth
+	}
+}
+
+// WithCompression gzip request bodies of at least minSize bytes (big bundles, batches) and accept gzipped responses.
+// If the endpoint answers a compressed request with 415 Unsupported Media Type it is resent plain and compression
+// is turned off.
+func WithCompression(minSize int) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.compress = &compression{minSize: minSize}
+	}
+}
+
+// WithIPC send json-rpc calls over the unix domain socket of a local node instead of http, lower latency for the
+// read-heavy side of a searcher colocated with its node
+func WithIPC(path string) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.ipc = &ipcClient{path: path}
+	}
+}
+
+// WithTransport send every request, bloXroute calls included, through the given transport, e.g. to add tracing or
+// serve calls in-process
+func WithTransport(transport http.RoundTripper) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.transport = transport
+	}
+}
+
+// WithMethodConfig set timeouts and retries by method, keys are method names or prefix patterns like "trace_*" and
+// "blxr_*", "*" matches every method. Exact names win over patterns, longer patterns over shorter ones.
+func WithMethodConfig(configs map[string]MethodConfig) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.methods = make(map[string]MethodConfig, len(configs))
+		for method, config := range configs {
+			rpc.methods[method] = config
+		}
+	}
+}
+
+// WithHooks set callbacks observing every request and response
+func WithHooks(hooks Hooks) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.hooks = hooks
+	}
+}
+
+// WithJournal record every bundle submission in journal, see ResolveJournal
+func WithJournal(journal Journal) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.journal = journal
+	}
+}
+
+// WithNotifier add notifier receiving bundle submitted, included, expired and simulation failed events, e.g. the
+// Notify method of a WebhookNotifier
+func WithNotifier(notifier BundleNotifier) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.notifiers = append(rpc.notifiers[:len(rpc.notifiers):len(rpc.notifiers)], notifier)
+	}
+}
+
+// WithQuotaThrottle delay every bloXroute request by delay once threshold (e.g. 0.9) of the daily quota is used, the
+// usage is fetched with BloxrouteQuotaUsage at most once per refresh
+func WithQuotaThrottle(threshold float64, delay, refresh time.Duration) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.quota = &quotaThrottle{threshold: threshold, delay: delay, refresh: refresh}
+	}
+}
+
+// WithValidation check address and hash parameters of the eth_* methods before sending, so malformed values and
+// EIP-55 checksum typos fail locally with ErrInvalidAddress, ErrInvalidChecksum or ErrInvalidHash
+func WithValidation(enabled bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.validate = enabled
+	}
+}
+
+// WithStrictDecoding reject null, "" and "0x" quantities and unquoted JSON numbers in results, which are read as zero
+// and as decimal numbers by default, so only hex strings are accepted
+func WithStrictDecoding(enabled bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.strict = enabled
+	}
+}
+
+// WithArchive route calls at old blocks to the archive node at url: calls at "earliest" or at a block number more
+// than recentBlocks behind the head, which is polled at most every 12s, and calls the full node refuses for missing
+// historical state. Other calls go to the full node.
+func WithArchive(url string, recentBlocks int) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.archive = &archive{url: url, recent: recentBlocks}
+	}
+}
+
+// WithChainIDCheck verify before signing that transactions are signed for the chain of the endpoint, so a client
+// pointed at the wrong network fails with ErrChainIDMismatch instead of submitting replayable transactions
+func WithChainIDCheck(enabled bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.checkChain = enabled
+	}
+}
+
+// WithCache cache up to size blocks, transactions and receipts fetched by hash, shared by the clients derived with
+// With unless they change the url. Blocks by hash never change, transactions and receipts are only cached once their
+// block is depth blocks behind the head (default: 64, about finalized), so reorgs can't serve stale ones. Cached
+// objects are shared, callers must not modify them.
+func WithCache(size, depth int) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		if depth <= 0 {
+			depth = 64
+		}
+		rpc.cache = newObjectCache(size, depth)
+	}
+}
+
+// WithStats keep the latency and outcome of the last window calls (default: 1000) to every endpoint for GetStats,
+// shared by the clients derived with With
+func WithStats(window int) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		if window <= 0 {
+			window = 1000
+		}
+		rpc.stats = newLatencyStats(window)
+	}
+}
+
+// WithDeduplication skip bundles submitted again to the same relay for the same block within window, keyed by
+// BundleIdempotencyKey and shared by the clients derived with With. A duplicate gets the result of the earlier
+// submission without being sent, or fails with ErrDuplicateBundle when refuse is set or the earlier one is still in
+// flight. Duplicates aren't journaled and are notified as BundleDuplicate. Failed submissions and cancellations are
+// never deduplicated.
+func WithDeduplication(window time.Duration, refuse bool) func(rpc *FlashXRoute) {
+	return func(rpc *FlashXRoute) {
+		rpc.dedup = &dedup{window: window, refuse: refuse, submissions: map[string]submission{}}
+	}
+}
@@ -0,0 +1,94 @@
+package flashxroute
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBlockNotFound is returned when the node does not know the requested block
+var ErrBlockNotFound = errors.New("block not found")
+
+// BlockPayments - how the builder of a landed block was paid and how it paid the proposer
+type BlockPayments struct {
+	Number          int
+	Builder         string  // fee recipient of the block, the builder for MEV-boost blocks
+	Proposer        string  // recipient of the proposer payment, empty if the block has none
+	ProposerPayment big.Int // value of the last transaction if it is sent by the builder
+	// BuilderProfit is the builder balance change over the block, i.e. priority fees and direct transfers
+	// after paying the proposer
+	BuilderProfit big.Int
+	// CoinbaseTransfers are the direct transfers to the builder by transaction hash, nil if the node has no trace
+	// namespace
+	CoinbaseTransfers map[string]*big.Int
+}
+
+// Outbid returns how much more than bid the winning builder paid the proposer, negative if bid was higher
+func (p *BlockPayments) Outbid(bid *big.Int) *big.Int {
+	return new(big.Int).Sub(&p.ProposerPayment, bid)
+}
+
+// AnalyzeBlockPayments identifies the proposer payment of a block, the builder balance change and the direct
+// coinbase transfers of its transactions (using trace_block where the node supports it)
+func (rpc *FlashXRoute) AnalyzeBlockPayments(number int) (*BlockPayments, error) {
+	block, err := rpc.EthGetBlockByNumber(number, true)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+
+	payments := &BlockPayments{Number: number, Builder: block.Miner}
+	if n := len(block.Transactions); n > 0 {
+		last := block.Transactions[n-1]
+		if strings.EqualFold(last.From, block.Miner) {
+			payments.Proposer = last.To
+			payments.ProposerPayment = last.Value
+		}
+	}
+
+	after, err := rpc.EthGetBalance(block.Miner, IntToHex(number))
+	if err != nil {
+		return nil, err
+	}
+	before, err := rpc.EthGetBalance(block.Miner, IntToHex(number-1))
+	if err != nil {
+		return nil, err
+	}
+	payments.BuilderProfit.Sub(&after, &before)
+
+	traces, err := rpc.TraceBlock(number)
+	if isMethodNotFound(err) {
+		// no trace namespace
+		return payments, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	payments.CoinbaseTransfers = make(map[string]*big.Int)
+	for _, transfer := range CoinbaseTransfers(traces, block.Miner) {
+		total, ok := payments.CoinbaseTransfers[transfer.TransactionHash]
+		if !ok {
+			total = new(big.Int)
+			payments.CoinbaseTransfers[transfer.TransactionHash] = total
+		}
+		total.Add(total, &transfer.Value)
+	}
+
+	return payments, nil
+}
+
+// isMethodNotFound reports whether err is a node refusing a method it doesn't serve, e.g. trace_block without the
+// trace namespace
+func isMethodNotFound(err error) bool {
+	rpcErr, ok := err.(RpcError)
+	if !ok {
+		return false
+	}
+	message := strings.ToLower(rpcErr.Message)
+
+	return rpcErr.Code == -32601 || strings.Contains(message, "does not exist") || strings.Contains(message, "is not available")
+}
@@ -0,0 +1,44 @@
+package flashxroute
+
+import (
+	"math/big"
+)
+
+func (s *FlashXRouteTestSuite) TestAnalyzeBlockPayments() {
+	results := map[string]string{
+		"eth_getBlockByNumber": `{"number": "0x10", "miner": "0xc0ffee", "transactions": [
+			{"hash": "0xt1", "from": "0xa", "to": "0xb", "value": "0x0"},
+			{"hash": "0xt3", "from": "0xC0FFEE", "to": "0xfee", "value": "0x6f05b59d3b20000"}
+		]}`,
+		`eth_getBalance ["0xc0ffee","0x10"]`: `"0x2386f26fc10000"`,
+		`eth_getBalance ["0xc0ffee","0xf"]`:  `"0x0"`,
+		"trace_block":                        blockTraces,
+	}
+	s.registerMethods(results)
+
+	payments, err := s.rpc.AnalyzeBlockPayments(16)
+	s.Require().Nil(err)
+	s.Require().Equal("0xc0ffee", payments.Builder)
+	s.Require().Equal("0xfee", payments.Proposer)
+	s.Require().Equal(*big.NewInt(500000000000000000), payments.ProposerPayment)
+	s.Require().Equal(*big.NewInt(10000000000000000), payments.BuilderProfit)
+	s.Require().Equal(map[string]*big.Int{"0xt1": big.NewInt(10000000000000000)}, payments.CoinbaseTransfers)
+	s.Require().Equal(big.NewInt(100000000000000000), payments.Outbid(big.NewInt(400000000000000000)))
+
+	// nodes without the trace namespace still get the balance based analysis
+	results["trace_block"] = `error:{"code": -32601, "message": "the method trace_block does not exist/is not available"}`
+	s.registerMethods(results)
+	payments, err = s.rpc.AnalyzeBlockPayments(16)
+	s.Require().Nil(err)
+	s.Require().Nil(payments.CoinbaseTransfers)
+
+	// other trace failures aren't mistaken for a missing namespace
+	results["trace_block"] = `error:{"code": -32005, "message": "rate limit exceeded"}`
+	s.registerMethods(results)
+	_, err = s.rpc.AnalyzeBlockPayments(16)
+	s.Require().ErrorAs(err, &RpcError{})
+
+	s.registerMethods(map[string]string{"eth_getBlockByNumber": "null"})
+	_, err = s.rpc.AnalyzeBlockPayments(16)
+	s.Require().ErrorIs(err, ErrBlockNotFound)
+}
@@ -0,0 +1,38 @@
+package flashxroute
+
+import "github.com/pkg/errors"
+
+// ErrPersonalAPIDisabled is returned by personal_* methods unless the client was created WithPersonalAPI(true)
+var ErrPersonalAPIDisabled = errors.New("personal api disabled, enable it with WithPersonalAPI")
+
+func (rpc *FlashXRoute) callPersonal(method string, target interface{}, params ...interface{}) error {
+	if !rpc.personal {
+		return ErrPersonalAPIDisabled
+	}
+
+	return rpc.call(method, target, params...)
+}
+
+// PersonalSign calculates an ethereum specific signature of data with the node managed account unlocked by password.
+func (rpc *FlashXRoute) PersonalSign(data, address, password string) (string, error) {
+	var signature string
+
+	err := rpc.callPersonal("personal_sign", &signature, data, address, password)
+	return signature, err
+}
+
+// PersonalSendTransaction signs the transaction with the node managed account of transaction.From and sends it.
+func (rpc *FlashXRoute) PersonalSendTransaction(transaction T, password string) (string, error) {
+	var hash string
+
+	err := rpc.callPersonal("personal_sendTransaction", &hash, transaction, password)
+	return hash, err
+}
+
+// PersonalUnlockAccount unlocks the node managed account for duration seconds, 0 keeps it unlocked until the node exits.
+func (rpc *FlashXRoute) PersonalUnlockAccount(address, password string, duration int) (bool, error) {
+	var unlocked bool
+
+	err := rpc.callPersonal("personal_unlockAccount", &unlocked, address, password, duration)
+	return unlocked, err
+}
@@ -0,0 +1,33 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestPersonalAPIDisabled() {
+	_, err := s.rpc.PersonalSign("0xdeadbeef", "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "pass")
+	s.Require().ErrorIs(err, ErrPersonalAPIDisabled)
+
+	_, err = s.rpc.PersonalUnlockAccount("0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "pass", 0)
+	s.Require().ErrorIs(err, ErrPersonalAPIDisabled)
+}
+
+func (s *FlashXRouteTestSuite) TestPersonalUnlockAccount() {
+	rpc := s.rpc.With(WithPersonalAPI(true))
+	s.registerResponse(`true`, func(body []byte) {
+		s.methodEqual(body, "personal_unlockAccount")
+		s.paramsEqual(body, `["0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "pass", 300]`)
+	})
+
+	unlocked, err := rpc.PersonalUnlockAccount("0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "pass", 300)
+	s.Require().Nil(err)
+	s.Require().True(unlocked)
+}
+
+func (s *FlashXRouteTestSuite) TestPersonalSendTransaction() {
+	rpc := s.rpc.With(WithPersonalAPI(true))
+	s.registerResponse(`"0xabc"`, func(body []byte) {
+		s.methodEqual(body, "personal_sendTransaction")
+		s.paramsEqual(body, `[{"from": "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", "to": "0x1bf21cb1dc384d019a885a06973f7308"}, "pass"]`)
+	})
+
+	hash, err := rpc.PersonalSendTransaction(T{From: "0x9b2055d370f73ec7d8a03e965129118dc8f5bf83", To: "0x1bf21cb1dc384d019a885a06973f7308"}, "pass")
+	s.Require().Nil(err)
+	s.Require().Equal("0xabc", hash)
+}
@@ -0,0 +1,197 @@
+package flashxroute
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PipelineStage - step of a Pipeline
+type PipelineStage int
+
+// Pipeline stages in execution order
+const (
+	StageSimulate PipelineStage = iota
+	StagePrice
+	StageSign
+	StageSubmit
+)
+
+func (stage PipelineStage) String() string {
+	switch stage {
+	case StageSimulate:
+		return "simulate"
+	case StagePrice:
+		return "price"
+	case StageSign:
+		return "sign"
+	case StageSubmit:
+		return "submit"
+	}
+
+	return fmt.Sprintf("PipelineStage(%d)", int(stage))
+}
+
+// StageTiming - how a stage of a Pipeline run went
+type StageTiming struct {
+	Stage    PipelineStage
+	Duration time.Duration
+	Skipped  bool  // not run, no time was left for it
+	Degraded bool  // timed out or skipped, the result of a previous run was used
+	Err      error // error of the stage, the run stops at failed stages
+}
+
+// PipelineRun - outcome of Pipeline.Run
+type PipelineRun struct {
+	Simulation BloxrouteSimulateBundleResponse
+	Bid        *big.Int
+	Signed     []string // signed raw transactions submitted
+	BundleHash string
+	Timings    []StageTiming
+	Err        error
+}
+
+// Pipeline - chains simulate, price, sign and submit of a bundle for blockNumber under a single deadline budget.
+// Simulate and Price are optional and degradable: when they can't finish before the time reserved for signing and
+// submitting, the simulation and bid of the previous successful run are reused. Stages are abandoned, not
+// cancelled, when their context is done, so their results must not be shared with later runs.
+type Pipeline struct {
+	Deadline time.Duration // budget of a run (default: 800ms)
+	Reserve  time.Duration // time kept for Sign and Submit (default: a quarter of Deadline)
+
+	Simulate func(ctx context.Context, blockNumber int) (BloxrouteSimulateBundleResponse, error)
+	Price    func(ctx context.Context, simulation BloxrouteSimulateBundleResponse) (*big.Int, error)
+	Sign     func(ctx context.Context, bid *big.Int) ([]string, error)
+	Submit   func(ctx context.Context, blockNumber int, signed []string) (string, error)
+
+	mu         sync.Mutex
+	simulation *BloxrouteSimulateBundleResponse
+	bid        *big.Int
+}
+
+// runStage runs fn until it returns or ctx is done
+func runStage[R any](ctx context.Context, fn func(ctx context.Context) (R, error)) (R, error) {
+	type outcome struct {
+		result R
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := fn(ctx)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		var zero R
+		return zero, ctx.Err()
+	}
+}
+
+// Run runs the pipeline once for blockNumber
+func (p *Pipeline) Run(ctx context.Context, blockNumber int) PipelineRun {
+	if p.Sign == nil || p.Submit == nil {
+		return PipelineRun{Err: errors.New("pipeline needs Sign and Submit")}
+	}
+	budget := p.Deadline
+	if budget <= 0 {
+		budget = 800 * time.Millisecond
+	}
+	reserve := p.Reserve
+	if reserve <= 0 {
+		reserve = budget / 4
+	}
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	degradable, cancelDegradable := context.WithDeadline(ctx, deadline.Add(-reserve))
+	defer cancelDegradable()
+
+	p.mu.Lock()
+	var run PipelineRun
+	if p.simulation != nil {
+		run.Simulation = *p.simulation
+	}
+	run.Bid = p.bid
+	hasSimulation := p.simulation != nil
+	p.mu.Unlock()
+
+	stage := func(s PipelineStage, ctx context.Context, degrade bool, fn func(ctx context.Context) error) bool {
+		timing := StageTiming{Stage: s}
+		if ctx.Err() != nil {
+			timing.Skipped = true
+		} else {
+			started := time.Now()
+			timing.Err = fn(ctx)
+			timing.Duration = time.Since(started)
+		}
+		if degrade && (timing.Skipped || errors.Is(timing.Err, context.DeadlineExceeded) && ctx.Err() != nil) {
+			timing.Degraded, timing.Err = true, nil
+		}
+		if timing.Skipped && !timing.Degraded {
+			timing.Err = errors.Wrapf(context.DeadlineExceeded, "%s skipped", s)
+		}
+		run.Timings = append(run.Timings, timing)
+		if timing.Err != nil {
+			run.Err = errors.Wrap(timing.Err, s.String())
+			return false
+		}
+		return true
+	}
+
+	if p.Simulate != nil {
+		ok := stage(StageSimulate, degradable, hasSimulation, func(ctx context.Context) error {
+			simulation, err := runStage(ctx, func(ctx context.Context) (BloxrouteSimulateBundleResponse, error) {
+				return p.Simulate(ctx, blockNumber)
+			})
+			if err == nil {
+				run.Simulation, hasSimulation = simulation, true
+				p.mu.Lock()
+				p.simulation = &simulation
+				p.mu.Unlock()
+			}
+			return err
+		})
+		if !ok {
+			return run
+		}
+	}
+	if p.Price != nil {
+		ok := stage(StagePrice, degradable, run.Bid != nil, func(ctx context.Context) error {
+			bid, err := runStage(ctx, func(ctx context.Context) (*big.Int, error) {
+				return p.Price(ctx, run.Simulation)
+			})
+			if err == nil {
+				run.Bid = bid
+				p.mu.Lock()
+				p.bid = bid
+				p.mu.Unlock()
+			}
+			return err
+		})
+		if !ok {
+			return run
+		}
+	}
+	ok := stage(StageSign, ctx, false, func(ctx context.Context) error {
+		signed, err := runStage(ctx, func(ctx context.Context) ([]string, error) { return p.Sign(ctx, run.Bid) })
+		run.Signed = signed
+		return err
+	})
+	if !ok {
+		return run
+	}
+	stage(StageSubmit, ctx, false, func(ctx context.Context) error {
+		bundleHash, err := runStage(ctx, func(ctx context.Context) (string, error) { return p.Submit(ctx, blockNumber, run.Signed) })
+		run.BundleHash = bundleHash
+		return err
+	})
+
+	return run
+}
@@ -0,0 +1,61 @@
+package flashxroute
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	slow := false
+	pipeline := &Pipeline{
+		Deadline: 200 * time.Millisecond,
+		Reserve:  100 * time.Millisecond,
+		Simulate: func(ctx context.Context, blockNumber int) (BloxrouteSimulateBundleResponse, error) {
+			if slow {
+				<-ctx.Done()
+				return BloxrouteSimulateBundleResponse{}, ctx.Err()
+			}
+			return BloxrouteSimulateBundleResponse{BundleHash: "0xsim", StateBlockNumber: int64(blockNumber - 1)}, nil
+		},
+		Price: func(ctx context.Context, simulation BloxrouteSimulateBundleResponse) (*big.Int, error) {
+			return big.NewInt(1000), nil
+		},
+		Sign: func(ctx context.Context, bid *big.Int) ([]string, error) {
+			return []string{"0x" + bid.Text(16)}, nil
+		},
+		Submit: func(ctx context.Context, blockNumber int, signed []string) (string, error) {
+			return "0xbundle", nil
+		},
+	}
+
+	run := pipeline.Run(context.Background(), 100)
+	require.NoError(t, run.Err)
+	require.Equal(t, "0xsim", run.Simulation.BundleHash)
+	require.Equal(t, []string{"0x3e8"}, run.Signed)
+	require.Equal(t, "0xbundle", run.BundleHash)
+	require.Len(t, run.Timings, 4)
+	for i, timing := range run.Timings {
+		require.Equal(t, PipelineStage(i), timing.Stage)
+		require.False(t, timing.Degraded)
+	}
+
+	// simulation times out, the previous one is reused
+	slow = true
+	run = pipeline.Run(context.Background(), 101)
+	require.NoError(t, run.Err)
+	require.True(t, run.Timings[0].Degraded)
+	require.Equal(t, int64(99), run.Simulation.StateBlockNumber)
+	require.Equal(t, "0xbundle", run.BundleHash)
+
+	// no previous simulation to fall back on
+	fresh := &Pipeline{Deadline: pipeline.Deadline, Simulate: pipeline.Simulate, Sign: pipeline.Sign, Submit: pipeline.Submit}
+	run = fresh.Run(context.Background(), 101)
+	require.True(t, errors.Is(run.Err, context.DeadlineExceeded))
+	require.Len(t, run.Timings, 1)
+	require.Empty(t, run.BundleHash)
+}
@@ -0,0 +1,156 @@
+package flashxroute
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrNoBuilderSelected is returned when the policy selects no builder for a bundle, rather than submitting it to none
+var ErrNoBuilderSelected = errors.New("policy selects no builder")
+
+// ErrBuilderExcluded is returned for direct submissions to a builder the policy doesn't select
+var ErrBuilderExcluded = errors.New("builder excluded by policy")
+
+// Builder - MEV block builder reachable through bloXroute mev_builders and, when URL is set, directly with
+// eth_sendBundle, see NewBuilderClient
+type Builder struct {
+	Name              string            `json:"name"`                        // name used in mev_builders
+	Frontrunning      bool              `json:"frontrunning,omitempty"`      // accepts bundles flagged as frontrunning
+	Censoring         bool              `json:"censoring,omitempty"`         // filters transactions of sanctioned addresses
+	Networks          []string          `json:"networks,omitempty"`          // bloXroute network names the builder produces blocks for
+	URL               string            `json:"url"`                         // public eth_sendBundle endpoint
+	Headers           map[string]string `json:"headers,omitempty"`           // additional headers of direct submissions
+	Fields            []string          `json:"fields,omitempty"`            // optional eth_sendBundle fields accepted, nil accepts all, see BundleField*
+	SignatureRequired bool              `json:"signatureRequired,omitempty"` // direct submissions need X-Flashbots-Signature
+	BlobTransactions  bool              `json:"blobTransactions,omitempty"`  // accepts EIP-4844 blob transactions in bundles, with sidecars
+}
+
+// KnownBuilders - builders supported by bloXroute mev_builders. Attributes are indicative and change over time,
+// pass your own list to Policy.Select when they matter.
+var KnownBuilders = []Builder{
+	{Name: "bloxroute", Frontrunning: true, Censoring: false, Networks: []string{NetworkMainnet, NetworkBSCMainnet}},
+	{
+		Name: "flashbots", Frontrunning: true, Censoring: true, Networks: []string{NetworkMainnet},
+		URL:               FlashbotsRelayURL,
+		Fields:            []string{BundleFieldMinTimestamp, BundleFieldMaxTimestamp, BundleFieldRevertingTxHashes, BundleFieldReplacementUUID},
+		SignatureRequired: true,
+		BlobTransactions:  true,
+	},
+	{
+		Name: "builder0x69", Frontrunning: true, Censoring: false, Networks: []string{NetworkMainnet},
+		URL:    "https://builder0x69.io",
+		Fields: []string{BundleFieldMinTimestamp, BundleFieldMaxTimestamp, BundleFieldRevertingTxHashes, BundleFieldReplacementUUID},
+	},
+	{
+		Name: "beaverbuild", Frontrunning: true, Censoring: true, Networks: []string{NetworkMainnet},
+		URL: "https://rpc.beaverbuild.org",
+		Fields: []string{BundleFieldMinTimestamp, BundleFieldMaxTimestamp, BundleFieldRevertingTxHashes, BundleFieldReplacementUUID,
+			BundleFieldRefundPercent, BundleFieldRefundRecipient, BundleFieldRefundTxHashes},
+		BlobTransactions: true,
+	},
+	{
+		Name: "titan", Frontrunning: true, Censoring: false, Networks: []string{NetworkMainnet},
+		URL: "https://rpc.titanbuilder.xyz",
+		Fields: []string{BundleFieldMinTimestamp, BundleFieldMaxTimestamp, BundleFieldRevertingTxHashes, BundleFieldReplacementUUID,
+			BundleFieldRefundPercent, BundleFieldRefundRecipient, BundleFieldRefundTxHashes},
+		BlobTransactions: true,
+	},
+	{
+		Name: "rsync-builder", Frontrunning: true, Censoring: true, Networks: []string{NetworkMainnet},
+		URL:    "https://rsync-builder.xyz",
+		Fields: []string{BundleFieldMinTimestamp, BundleFieldMaxTimestamp, BundleFieldRevertingTxHashes, BundleFieldReplacementUUID},
+	},
+}
+
+// KnownBuilder returns builder of KnownBuilders with name
+func KnownBuilder(name string) (Builder, bool) {
+	for _, builder := range KnownBuilders {
+		if builder.Name == name {
+			return builder, true
+		}
+	}
+
+	return Builder{}, false
+}
+
+// Policy - builder selection rules consulted when a bundle submission doesn't name its mev_builders
+type Policy struct {
+	Include          []string            // only these builders, all known builders when empty
+	Exclude          []string            // never these builders
+	ExcludeByNetwork map[string][]string // never these builders on the given network
+	NonCensoringOnly bool                // skip builders filtering sanctioned transactions
+	Builders         []Builder           // builder attributes, KnownBuilders when empty
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Select returns names of builders allowed on network for a bundle, frontrunning bundles are only sent to builders accepting them
+func (p Policy) Select(network string, frontrunning bool) []string {
+	names := []string{}
+	for _, builder := range p.SelectBuilders(network, frontrunning) {
+		names = append(names, builder.Name)
+	}
+
+	return names
+}
+
+// SelectBuilders is like Select but returns the builders, e.g. for SendBundleToBuilders
+func (p Policy) SelectBuilders(network string, frontrunning bool) []Builder {
+	builders := p.Builders
+	if len(builders) == 0 {
+		builders = KnownBuilders
+	}
+
+	selected := []Builder{}
+	for _, builder := range builders {
+		if p.Allows(network, frontrunning, builder) {
+			selected = append(selected, builder)
+		}
+	}
+
+	return selected
+}
+
+// Allows tells whether the policy allows builder on network for a bundle, e.g. for a direct submission to a builder
+// missing from Builders
+func (p Policy) Allows(network string, frontrunning bool, builder Builder) bool {
+	if network == "" {
+		network = NetworkMainnet
+	}
+
+	switch {
+	case len(p.Include) > 0 && !containsString(p.Include, builder.Name):
+	case containsString(p.Exclude, builder.Name):
+	case containsString(p.ExcludeByNetwork[network], builder.Name):
+	case len(builder.Networks) > 0 && !containsString(builder.Networks, network):
+	case p.NonCensoringOnly && builder.Censoring:
+	case frontrunning && !builder.Frontrunning:
+	default:
+		return true
+	}
+
+	return false
+}
+
+// Apply fills params.MevBuilders from the policy unless the request already names its builders, it fails with
+// ErrNoBuilderSelected when the policy selects none
+func (p Policy) Apply(network string, params *BloxrouteSubmitBundleRequest) error {
+	if params.MevBuilders != nil {
+		return nil
+	}
+
+	builders := p.Select(network, params.Frontrunning)
+	if len(builders) == 0 {
+		return errors.Wrapf(ErrNoBuilderSelected, "network %s", network)
+	}
+	params.MevBuilders = &builders
+
+	return nil
+}
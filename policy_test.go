@@ -0,0 +1,80 @@
+package flashxroute
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestPolicySelect(t *testing.T) {
+	builders := []Builder{
+		{Name: "a", Frontrunning: true, Censoring: false},
+		{Name: "b", Frontrunning: false, Censoring: false},
+		{Name: "c", Frontrunning: true, Censoring: true, Networks: []string{NetworkMainnet}},
+		{Name: "d", Frontrunning: true, Censoring: false, Networks: []string{NetworkBSCMainnet}},
+	}
+
+	require.Equal(t, []string{"a", "b", "c"}, Policy{Builders: builders}.Select("", false))
+	require.Equal(t, []string{"a", "c"}, Policy{Builders: builders}.Select(NetworkMainnet, true))
+	require.Equal(t, []string{"a", "b"}, Policy{Builders: builders, NonCensoringOnly: true}.Select(NetworkMainnet, false))
+	require.Equal(t, []string{"a", "d"}, Policy{Builders: builders}.Select(NetworkBSCMainnet, true))
+	require.Equal(t, []string{"b"}, Policy{Builders: builders, Include: []string{"b", "c"}, Exclude: []string{"c"}}.Select(NetworkMainnet, false))
+	require.Equal(t, []string{"a", "b"}, Policy{Builders: builders, ExcludeByNetwork: map[string][]string{NetworkBSCMainnet: {"d"}}}.Select(NetworkBSCMainnet, false))
+}
+
+func TestPolicyApply(t *testing.T) {
+	policy := Policy{Include: []string{"flashbots", "titan"}}
+
+	params := BloxrouteSubmitBundleRequest{}
+	require.Nil(t, policy.Apply(NetworkMainnet, &params))
+	require.Equal(t, []string{"flashbots", "titan"}, *params.MevBuilders)
+
+	explicit := []string{"all"}
+	params = BloxrouteSubmitBundleRequest{MevBuilders: &explicit}
+	require.Nil(t, policy.Apply(NetworkMainnet, &params))
+	require.Equal(t, []string{"all"}, *params.MevBuilders)
+
+	params = BloxrouteSubmitBundleRequest{}
+	err := Policy{Include: []string{"flashbots"}, NonCensoringOnly: true}.Apply(NetworkMainnet, &params)
+	require.ErrorIs(t, err, ErrNoBuilderSelected)
+	require.Nil(t, params.MevBuilders)
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy := Policy{Exclude: []string{"b"}, NonCensoringOnly: true}
+	require.True(t, policy.Allows("", false, Builder{Name: "a"}))
+	require.False(t, policy.Allows("", false, Builder{Name: "b"}))
+	require.False(t, policy.Allows("", false, Builder{Name: "c", Censoring: true}))
+	require.False(t, policy.Allows(NetworkMainnet, false, Builder{Name: "d", Networks: []string{NetworkBSCMainnet}}))
+}
+
+func (s *FlashXRouteTestSuite) TestPolicyEnforced() {
+	var methods []string
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, gjson.GetBytes(s.getBody(r), "method").String())
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	})
+	defer server.Close()
+
+	rpc := New(server.URL, WithPolicy(Policy{Include: []string{"flashbots"}, NonCensoringOnly: true}))
+	_, err := rpc.BloxrouteSubmitBundle("auth", BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"})
+	s.Require().ErrorIs(err, ErrNoBuilderSelected)
+	s.Require().Empty(methods)
+
+	builders := []Builder{{Name: "allowed", URL: server.URL}, {Name: "excluded", URL: server.URL}}
+	submissions := SendBundleToBuilders(builders, SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"}, WithPolicy(Policy{Exclude: []string{"excluded"}}))
+	s.Require().Nil(submissions[0].Err)
+	s.Require().ErrorIs(submissions[1].Err, ErrBuilderExcluded)
+	s.Require().Equal([]string{"eth_sendBundle"}, methods)
+
+	methods = nil
+	excluded, err := NewBuilderClient(builders[1])
+	s.Require().Nil(err)
+	manager := NewBundleManager(New(server.URL, WithPolicy(Policy{Exclude: []string{"excluded"}})), "auth", excluded)
+	_, submissions = manager.Submit(SendBundleRequest{Txs: []string{"0x01"}, BlockNumber: "0x10"})
+	s.Require().Nil(submissions[0].Err)
+	s.Require().ErrorIs(submissions[1].Err, ErrBuilderExcluded)
+	s.Require().Equal([]string{"blxr_submit_bundle"}, methods)
+}
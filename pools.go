@@ -0,0 +1,181 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Uniswap pool function selectors
+const (
+	uniswapV2GetReserves = "0x0902f1ac" // getReserves()
+	uniswapV3Slot0       = "0x3850c7bd" // slot0()
+	uniswapV3Liquidity   = "0x1a686502" // liquidity()
+)
+
+// V2Reserves - getReserves() of a Uniswap V2 style pair
+type V2Reserves struct {
+	Reserve0           big.Int
+	Reserve1           big.Int
+	BlockTimestampLast uint32
+}
+
+// V3Slot0 - slot0() of a Uniswap V3 style pool
+type V3Slot0 struct {
+	SqrtPriceX96               big.Int
+	Tick                       int
+	ObservationIndex           int
+	ObservationCardinality     int
+	ObservationCardinalityNext int
+	FeeProtocol                int
+	Unlocked                   bool
+}
+
+// V3State - slot0 and in range liquidity of a Uniswap V3 style pool
+type V3State struct {
+	Slot0     V3Slot0
+	Liquidity big.Int
+}
+
+// abiWords splits 0x prefixed call result into its first n 32 bytes words
+func abiWords(result string, n int) ([][]byte, error) {
+	data, err := ParseBytes(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 32*n {
+		return nil, errors.Wrapf(ErrInvalidABIResult, "%d bytes, expected %d words", len(data), n)
+	}
+
+	words := make([][]byte, n)
+	for i := range words {
+		words[i] = data[32*i : 32*i+32]
+	}
+
+	return words, nil
+}
+
+// abiInt returns signed integer word as int, it must fit in 64 bits
+func abiInt(word []byte) int {
+	value := new(big.Int).SetBytes(word)
+	if word[0]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return int(value.Int64())
+}
+
+func decodeV2Reserves(result string) (V2Reserves, error) {
+	var reserves V2Reserves
+	words, err := abiWords(result, 3)
+	if err != nil {
+		return reserves, err
+	}
+	reserves.Reserve0.SetBytes(words[0])
+	reserves.Reserve1.SetBytes(words[1])
+	reserves.BlockTimestampLast = uint32(new(big.Int).SetBytes(words[2]).Uint64())
+
+	return reserves, nil
+}
+
+func decodeV3Slot0(result string) (V3Slot0, error) {
+	var slot0 V3Slot0
+	words, err := abiWords(result, 7)
+	if err != nil {
+		return slot0, err
+	}
+	slot0.SqrtPriceX96.SetBytes(words[0])
+	slot0.Tick = abiInt(words[1])
+	slot0.ObservationIndex = abiInt(words[2])
+	slot0.ObservationCardinality = abiInt(words[3])
+	slot0.ObservationCardinalityNext = abiInt(words[4])
+	slot0.FeeProtocol = abiInt(words[5])
+	slot0.Unlocked = words[6][31] != 0
+
+	return slot0, nil
+}
+
+// UniswapV2Reserves returns the reserves of a Uniswap V2 style pair, e.g. of SushiSwap, at block
+func (rpc *FlashXRoute) UniswapV2Reserves(pair, block string) (V2Reserves, error) {
+	result, err := rpc.EthCall(T{To: pair, Data: uniswapV2GetReserves}, block)
+	if err != nil {
+		return V2Reserves{}, err
+	}
+
+	return decodeV2Reserves(result)
+}
+
+// UniswapV2ReservesOf returns the reserves of pairs at block read in one batch request, endpoints refusing batches
+// are queried call by call
+func (rpc *FlashXRoute) UniswapV2ReservesOf(pairs []string, block string) ([]V2Reserves, error) {
+	results := make([]string, len(pairs))
+	requests := make([]BatchRequest, len(pairs))
+	for i, pair := range pairs {
+		requests[i] = BatchRequest{Method: "eth_call", Params: []interface{}{T{To: pair, Data: uniswapV2GetReserves}, block}, Result: &results[i]}
+	}
+
+	reserves := make([]V2Reserves, len(pairs))
+	for i, res := range rpc.batchOrEach(requests...) {
+		if res.Err != nil {
+			return nil, errors.Wrapf(res.Err, "reserves of %s", pairs[i])
+		}
+		var err error
+		if reserves[i], err = decodeV2Reserves(results[i]); err != nil {
+			return nil, errors.Wrapf(err, "reserves of %s", pairs[i])
+		}
+	}
+
+	return reserves, nil
+}
+
+// UniswapV3Slot0 returns slot0 of a Uniswap V3 style pool at block
+func (rpc *FlashXRoute) UniswapV3Slot0(pool, block string) (V3Slot0, error) {
+	result, err := rpc.EthCall(T{To: pool, Data: uniswapV3Slot0}, block)
+	if err != nil {
+		return V3Slot0{}, err
+	}
+
+	return decodeV3Slot0(result)
+}
+
+// UniswapV3Liquidity returns the in range liquidity of a Uniswap V3 style pool at block
+func (rpc *FlashXRoute) UniswapV3Liquidity(pool, block string) (big.Int, error) {
+	result, err := rpc.EthCall(T{To: pool, Data: uniswapV3Liquidity}, block)
+	if err != nil {
+		return big.Int{}, err
+	}
+	words, err := abiWords(result, 1)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	return *new(big.Int).SetBytes(words[0]), nil
+}
+
+// UniswapV3State returns slot0 and liquidity of a Uniswap V3 style pool at block read in one batch request, pass a
+// block number so both come from the same state
+func (rpc *FlashXRoute) UniswapV3State(pool, block string) (V3State, error) {
+	var slot0, liquidity string
+	results := rpc.batchOrEach(
+		BatchRequest{Method: "eth_call", Params: []interface{}{T{To: pool, Data: uniswapV3Slot0}, block}, Result: &slot0},
+		BatchRequest{Method: "eth_call", Params: []interface{}{T{To: pool, Data: uniswapV3Liquidity}, block}, Result: &liquidity},
+	)
+	for _, result := range results {
+		if result.Err != nil {
+			return V3State{}, errors.Wrapf(result.Err, "state of %s", pool)
+		}
+	}
+
+	var state V3State
+	var err error
+	if state.Slot0, err = decodeV3Slot0(slot0); err != nil {
+		return state, err
+	}
+	words, err := abiWords(liquidity, 1)
+	if err != nil {
+		return state, err
+	}
+	state.Liquidity.SetBytes(words[0])
+
+	return state, nil
+}
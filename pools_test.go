@@ -0,0 +1,42 @@
+package flashxroute
+
+import (
+	"math/big"
+	"strings"
+)
+
+func (s *FlashXRouteTestSuite) TestUniswapPools() {
+	pair, pool := "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"
+	word := func(value string) string { return strings.Repeat("0", 64-len(value)) + value }
+	s.registerMethods(map[string]string{
+		`eth_call [{"data":"0x0902f1ac","from":"","to":"` + pair + `"},"0x10"]`: `"0x` + word("64") + word("c8") + word("65f0a8e0") + `"`,
+		`eth_call [{"data":"0x3850c7bd","from":"","to":"` + pool + `"},"0x10"]`: `"0x` + word("1000000") + strings.Repeat("f", 59) + "b1d00" +
+			word("2") + word("3") + word("4") + word("0") + word("1") + `"`,
+		`eth_call [{"data":"0x1a686502","from":"","to":"` + pool + `"},"0x10"]`: `"0x` + word("de0b6b3a7640000") + `"`,
+	})
+
+	reserves, err := s.rpc.UniswapV2Reserves(pair, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(V2Reserves{Reserve0: *big.NewInt(100), Reserve1: *big.NewInt(200), BlockTimestampLast: 1710270688}, reserves)
+
+	all, err := s.rpc.UniswapV2ReservesOf([]string{pair, pair}, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal([]V2Reserves{reserves, reserves}, all)
+
+	state, err := s.rpc.UniswapV3State(pool, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(V3Slot0{SqrtPriceX96: *big.NewInt(0x1000000), Tick: -320256, ObservationIndex: 2, ObservationCardinality: 3,
+		ObservationCardinalityNext: 4, Unlocked: true}, state.Slot0)
+	s.Require().Equal(*big.NewInt(1000000000000000000), state.Liquidity)
+
+	slot0, err := s.rpc.UniswapV3Slot0(pool, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(state.Slot0, slot0)
+	liquidity, err := s.rpc.UniswapV3Liquidity(pool, "0x10")
+	s.Require().Nil(err)
+	s.Require().Equal(state.Liquidity, liquidity)
+
+	s.registerMethods(map[string]string{"eth_call": `"0x` + word("1") + `"`})
+	_, err = s.rpc.UniswapV2Reserves(pair, "0x10")
+	s.Require().ErrorIs(err, ErrInvalidABIResult)
+}
@@ -0,0 +1,74 @@
+package flashxroute
+
+import "fmt"
+
+// Known relay endpoints
+const (
+	FlashbotsRelayURL        = "https://relay.flashbots.net"
+	FlashbotsGoerliRelayURL  = "https://relay-goerli.flashbots.net"
+	FlashbotsSepoliaRelayURL = "https://relay-sepolia.flashbots.net"
+	BloxrouteCloudURL        = "https://api.blxrbdn.com"
+	FlashbotsProtectURL      = "https://rpc.flashbots.net"
+)
+
+// bloXroute blockchain network names
+const (
+	NetworkMainnet        = "Mainnet"
+	NetworkBSCMainnet     = "BSC-Mainnet"
+	NetworkPolygonMainnet = "Polygon-Mainnet"
+)
+
+// BloxrouteRegion - bloXroute cloud API region
+type BloxrouteRegion string
+
+// bloXroute cloud API regions, BloxrouteRegionGlobal routes to the nearest one
+const (
+	BloxrouteRegionGlobal    BloxrouteRegion = ""
+	BloxrouteRegionVirginia  BloxrouteRegion = "virginia"
+	BloxrouteRegionUK        BloxrouteRegion = "uk"
+	BloxrouteRegionSingapore BloxrouteRegion = "singapore"
+	BloxrouteRegionGermany   BloxrouteRegion = "germany"
+)
+
+// URL returns the ethereum cloud API url of the region
+func (region BloxrouteRegion) URL() string {
+	if region == BloxrouteRegionGlobal {
+		return BloxrouteCloudURL
+	}
+
+	return fmt.Sprintf("https://%s.eth.blxrbdn.com", region)
+}
+
+// NewBloxrouteCloud create rpc client for the bloXroute ethereum cloud API in given region.
+// authHeader is used for every bloXroute call that doesn't pass its own, see AuthorizationHeader.
+func NewBloxrouteCloud(region BloxrouteRegion, authHeader string, options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	options = append([]func(rpc *FlashXRoute){WithBloxrouteAuthHeader(authHeader), WithNetwork(NetworkMainnet)}, options...)
+	return New(region.URL(), options...)
+}
+
+// NewBSCBloxroute create rpc client for the bloXroute cloud API targeting BSC Mainnet
+func NewBSCBloxroute(authHeader string, options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	options = append([]func(rpc *FlashXRoute){WithBloxrouteAuthHeader(authHeader), WithNetwork(NetworkBSCMainnet)}, options...)
+	return New(BloxrouteCloudURL, options...)
+}
+
+// NewPolygonBloxroute create rpc client for the bloXroute cloud API targeting Polygon Mainnet
+func NewPolygonBloxroute(authHeader string, options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	options = append([]func(rpc *FlashXRoute){WithBloxrouteAuthHeader(authHeader), WithNetwork(NetworkPolygonMainnet)}, options...)
+	return New(BloxrouteCloudURL, options...)
+}
+
+// NewFlashbotsRelay create rpc client for the Flashbots mainnet relay
+func NewFlashbotsRelay(options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	return New(FlashbotsRelayURL, options...)
+}
+
+// NewFlashbotsGoerliRelay create rpc client for the Flashbots goerli relay
+func NewFlashbotsGoerliRelay(options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	return New(FlashbotsGoerliRelayURL, options...)
+}
+
+// NewFlashbotsSepoliaRelay create rpc client for the Flashbots sepolia relay
+func NewFlashbotsSepoliaRelay(options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	return New(FlashbotsSepoliaRelayURL, options...)
+}
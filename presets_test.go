@@ -0,0 +1,20 @@
+package flashxroute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloxrouteRegionURL(t *testing.T) {
+	require.Equal(t, "https://api.blxrbdn.com", BloxrouteRegionGlobal.URL())
+	require.Equal(t, "https://virginia.eth.blxrbdn.com", BloxrouteRegionVirginia.URL())
+	require.Equal(t, FlashbotsRelayURL, NewFlashbotsRelay().URL())
+}
+
+func TestNewBSCBloxroute(t *testing.T) {
+	rpc := NewBSCBloxroute("secret")
+	require.Equal(t, BloxrouteCloudURL, rpc.URL())
+	require.Equal(t, "secret", rpc.authHeader)
+	require.Equal(t, NetworkBSCMainnet, rpc.network)
+}
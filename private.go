@@ -0,0 +1,229 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoPrivateTxProvider is returned when the policy of a PrivateTxSender selects no provider
+var ErrNoPrivateTxProvider = errors.New("no private transaction provider selected")
+
+// PrivateTxProvider - endpoint accepting signed transactions without broadcasting them to the public mempool
+type PrivateTxProvider struct {
+	Name string
+	Send func(raw string) (txHash string, err error) // submits 0x prefixed raw transaction
+}
+
+// BloxroutePrivateTxProvider returns provider sending with blxr_private_tx on rpc, params are sent with every
+// transaction, e.g. for Timeout or MevBuilders
+func BloxroutePrivateTxProvider(rpc *FlashXRoute, authHeader string, params BloxrouteSendPrivateTransactionRequest) PrivateTxProvider {
+	return PrivateTxProvider{Name: "bloxroute", Send: func(raw string) (string, error) {
+		params.Transaction = raw
+		return rpc.BloxrouteSendPrivateTransaction(authHeader, params)
+	}}
+}
+
+// FlashbotsProtectProvider returns provider sending with eth_sendRawTransaction on rpc, a client of
+// FlashbotsProtectURL or another protect endpoint
+func FlashbotsProtectProvider(rpc *FlashXRoute) PrivateTxProvider {
+	return PrivateTxProvider{Name: "flashbots-protect", Send: rpc.EthSendRawTransaction}
+}
+
+// BuilderPrivateTxProvider returns provider sending with eth_sendPrivateRawTransaction directly to the builder
+func BuilderPrivateTxProvider(client *BuilderClient) PrivateTxProvider {
+	return PrivateTxProvider{Name: client.Builder.Name, Send: client.SendPrivateRawTransaction}
+}
+
+// SendPrivateRawTransaction submits raw transaction with eth_sendPrivateRawTransaction, signed like SendBundle
+func (c *BuilderClient) SendPrivateRawTransaction(raw string) (txHash string, err error) {
+	raw = AddHexPrefix(raw)
+	var result json.RawMessage
+	switch {
+	case c.signer != nil:
+		result, err = c.CallWithFlashbotsSigner("eth_sendPrivateRawTransaction", c.signer, raw)
+	case c.Builder.SignatureRequired:
+		return "", errors.Wrap(ErrNoSigner, c.Builder.Name)
+	default:
+		result, err = c.Call("eth_sendPrivateRawTransaction", raw)
+	}
+	if err != nil || string(result) == "null" {
+		return "", err
+	}
+
+	return txHash, json.Unmarshal(result, &txHash)
+}
+
+// PrivateTxPolicy - providers a PrivateTxSender submits to
+type PrivateTxPolicy struct {
+	Providers []string // names of the providers used, in order, all providers when empty
+	Exclude   []string // never these providers
+	Fallback  bool     // try the providers one at a time until one accepts instead of submitting to all at once
+}
+
+// selectProviders returns the providers allowed by the policy
+func (p PrivateTxPolicy) selectProviders(providers []PrivateTxProvider) []PrivateTxProvider {
+	var selected []PrivateTxProvider
+	if len(p.Providers) == 0 {
+		for _, provider := range providers {
+			if !containsString(p.Exclude, provider.Name) {
+				selected = append(selected, provider)
+			}
+		}
+		return selected
+	}
+
+	for _, name := range p.Providers {
+		for _, provider := range providers {
+			if provider.Name == name && !containsString(p.Exclude, name) {
+				selected = append(selected, provider)
+			}
+		}
+	}
+
+	return selected
+}
+
+// PrivateTxSubmission - outcome of a private submission to one provider
+type PrivateTxSubmission struct {
+	Provider string
+	TxHash   string
+	Err      error
+	SentAt   time.Time
+}
+
+// PrivateTx - private transaction sent by a PrivateTxSender and its submissions
+type PrivateTx struct {
+	Hash        string
+	Raw         string
+	Submissions []PrivateTxSubmission
+	Status      InclusionStatus // InclusionPending until included, InclusionFailed when every provider refused it
+	IncludedIn  int
+}
+
+// accepted reports whether a provider accepted the transaction
+func (tx *PrivateTx) accepted() bool {
+	for _, submission := range tx.Submissions {
+		if submission.Err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PrivateTxSender - submits the same signed transaction privately to several providers according to a policy and
+// tracks its inclusion with node
+type PrivateTxSender struct {
+	Policy    PrivateTxPolicy
+	node      *FlashXRoute
+	providers []PrivateTxProvider
+
+	mu  sync.Mutex
+	txs map[string]*PrivateTx
+}
+
+// NewPrivateTxSender create sender submitting to providers and checking receipts on node
+func NewPrivateTxSender(node *FlashXRoute, policy PrivateTxPolicy, providers ...PrivateTxProvider) *PrivateTxSender {
+	return &PrivateTxSender{Policy: policy, node: node, providers: providers, txs: map[string]*PrivateTx{}}
+}
+
+// Send submits raw transaction to the providers selected by the policy and returns a copy of its state, the error
+// is set when no provider accepted it
+func (s *PrivateTxSender) Send(raw string) (PrivateTx, error) {
+	raw = AddHexPrefix(raw)
+	hash, err := RawTxHash(raw)
+	if err != nil {
+		return PrivateTx{}, err
+	}
+	providers := s.Policy.selectProviders(s.providers)
+	if len(providers) == 0 {
+		return PrivateTx{}, ErrNoPrivateTxProvider
+	}
+
+	tx := &PrivateTx{Hash: hash, Raw: raw}
+	if s.Policy.Fallback {
+		for _, provider := range providers {
+			submission := sendPrivateTx(provider, raw)
+			tx.Submissions = append(tx.Submissions, submission)
+			if submission.Err == nil {
+				break
+			}
+		}
+	} else {
+		tx.Submissions = make([]PrivateTxSubmission, len(providers))
+		var wg sync.WaitGroup
+		for i, provider := range providers {
+			wg.Add(1)
+			go func(i int, provider PrivateTxProvider) {
+				defer wg.Done()
+				tx.Submissions[i] = sendPrivateTx(provider, raw)
+			}(i, provider)
+		}
+		wg.Wait()
+	}
+
+	if !tx.accepted() {
+		tx.Status = InclusionFailed
+		err = errors.Errorf("private transaction %s refused by %d providers: %v", hash, len(tx.Submissions), tx.Submissions[len(tx.Submissions)-1].Err)
+	}
+	s.mu.Lock()
+	s.txs[hash] = tx
+	snapshot := tx.copy()
+	s.mu.Unlock()
+
+	return snapshot, err
+}
+
+// sendPrivateTx submits raw to provider
+func sendPrivateTx(provider PrivateTxProvider, raw string) PrivateTxSubmission {
+	submission := PrivateTxSubmission{Provider: provider.Name, SentAt: time.Now()}
+	submission.TxHash, submission.Err = provider.Send(raw)
+	return submission
+}
+
+// copy returns a copy of tx safe to hand out while the sender updates tx
+func (tx *PrivateTx) copy() PrivateTx {
+	snapshot := *tx
+	snapshot.Submissions = append([]PrivateTxSubmission(nil), tx.Submissions...)
+	return snapshot
+}
+
+// Status returns the state of a transaction sent with Send, a pending one is checked for a receipt on the node
+func (s *PrivateTxSender) Status(hash string) (PrivateTx, error) {
+	s.mu.Lock()
+	tx, ok := s.txs[hash]
+	var status InclusionStatus
+	if ok {
+		status = tx.Status
+	}
+	s.mu.Unlock()
+	if !ok {
+		return PrivateTx{}, errors.Wrap(ErrTransactionNotFound, hash)
+	}
+
+	if status == InclusionPending && s.node != nil {
+		receipt, err := s.node.EthGetTransactionReceipt(hash)
+		if err != nil {
+			return PrivateTx{}, err
+		}
+		if receipt.BlockHash != "" {
+			s.mu.Lock()
+			tx.Status, tx.IncludedIn = InclusionIncluded, receipt.BlockNumber
+			s.mu.Unlock()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return tx.copy(), nil
+}
+
+// Forget stops tracking transaction hash
+func (s *PrivateTxSender) Forget(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txs, hash)
+}
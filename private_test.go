@@ -0,0 +1,58 @@
+package flashxroute
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestPrivateTxSender() {
+	hash := Keccak256([]byte{1})
+	s.registerMethods(map[string]string{
+		"eth_getTransactionReceipt": `{"transactionHash": "` + hash + `", "blockHash": "0xb", "blockNumber": "0x10", "status": "0x1"}`,
+	})
+	var body []byte
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body = s.getBody(r)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "` + hash + `"}`))
+	})
+	defer server.Close()
+
+	builder, _ := NewBuilderClient(Builder{Name: "titan", URL: server.URL}, WithHttpClient(http.DefaultClient))
+	var calls []string
+	refusing := PrivateTxProvider{Name: "refusing", Send: func(raw string) (string, error) {
+		calls = append(calls, "refusing")
+		return "", errors.New("refused")
+	}}
+	sender := NewPrivateTxSender(s.rpc, PrivateTxPolicy{Providers: []string{"refusing", "titan"}, Fallback: true}, BuilderPrivateTxProvider(builder), refusing)
+
+	tx, err := sender.Send("01")
+	s.Require().Nil(err)
+	s.Require().Equal(hash, tx.Hash)
+	s.Require().Equal(InclusionPending, tx.Status)
+	s.Require().Len(tx.Submissions, 2)
+	s.Require().Equal("refusing", tx.Submissions[0].Provider)
+	s.Require().Equal(PrivateTxSubmission{Provider: "titan", TxHash: hash, SentAt: tx.Submissions[1].SentAt}, tx.Submissions[1])
+	s.Require().Equal("eth_sendPrivateRawTransaction", gjson.GetBytes(body, "method").String())
+	s.Require().Equal("0x01", gjson.GetBytes(body, "params.0").String())
+
+	tx, err = sender.Status(hash)
+	s.Require().Nil(err)
+	s.Require().Equal(InclusionIncluded, tx.Status)
+	s.Require().Equal(16, tx.IncludedIn)
+
+	sender.Policy = PrivateTxPolicy{Exclude: []string{"titan"}}
+	tx, err = sender.Send("0x02")
+	s.Require().NotNil(err)
+	s.Require().Equal(InclusionFailed, tx.Status)
+	s.Require().Equal([]string{"refusing", "refusing"}, calls)
+
+	sender.Policy = PrivateTxPolicy{Providers: []string{"flashbots-protect"}}
+	_, err = sender.Send("0x04")
+	s.Require().ErrorIs(err, ErrNoPrivateTxProvider)
+
+	sender.Forget(hash)
+	_, err = sender.Status(hash)
+	s.Require().ErrorIs(err, ErrTransactionNotFound)
+}
@@ -0,0 +1,110 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BloxrouteQuotaUsage - daily quota of a bloXroute account, Tier and RateLimit are only set when the gateway reports them
+type BloxrouteQuotaUsage struct {
+	AccountID   string `json:"account_id"`
+	QuotaFilled int64  `json:"quota_filled"` // credits used today
+	QuotaLimit  int64  `json:"quota_limit"`  // daily credits of the account
+	Tier        string `json:"tier_name,omitempty"`
+	RateLimit   int64  `json:"rate_limit,omitempty"` // requests per second
+}
+
+// Remaining returns credits left today
+func (u BloxrouteQuotaUsage) Remaining() int64 {
+	if u.QuotaFilled >= u.QuotaLimit {
+		return 0
+	}
+
+	return u.QuotaLimit - u.QuotaFilled
+}
+
+// Used returns used share of the daily quota, 0 when the limit is unknown
+func (u BloxrouteQuotaUsage) Used() float64 {
+	if u.QuotaLimit <= 0 {
+		return 0
+	}
+
+	return float64(u.QuotaFilled) / float64(u.QuotaLimit)
+}
+
+// UnmarshalJSON accepts quota numbers sent as json numbers or decimal strings
+func (u *BloxrouteQuotaUsage) UnmarshalJSON(data []byte) error {
+	type usage BloxrouteQuotaUsage
+	proxy := struct {
+		*usage
+		QuotaFilled json.Number `json:"quota_filled"`
+		QuotaLimit  json.Number `json:"quota_limit"`
+		RateLimit   json.Number `json:"rate_limit,omitempty"`
+	}{usage: (*usage)(u)}
+	if err := json.Unmarshal(data, &proxy); err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		value  json.Number
+		target *int64
+	}{{proxy.QuotaFilled, &u.QuotaFilled}, {proxy.QuotaLimit, &u.QuotaLimit}, {proxy.RateLimit, &u.RateLimit}} {
+		if field.value == "" {
+			*field.target = 0
+			continue
+		}
+		value, err := field.value.Int64()
+		if err != nil {
+			return err
+		}
+		*field.target = value
+	}
+
+	return nil
+}
+
+// BloxrouteQuotaUsage returns daily quota usage of the account behind authHeader
+func (rpc *FlashXRoute) BloxrouteQuotaUsage(authHeader string) (res BloxrouteQuotaUsage, err error) {
+	rawMsg, err := rpc.CallWithBloxrouteAuthHeader("quota_usage", authHeader, struct{}{})
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(rawMsg, &res)
+	return res, err
+}
+
+// quotaThrottle - slows bloXroute requests down once the daily quota is nearly used
+type quotaThrottle struct {
+	threshold float64       // used share of the quota from which requests are delayed
+	delay     time.Duration // delay of every request past the threshold
+	refresh   time.Duration // how often the usage is fetched
+
+	mu      sync.Mutex
+	usage   BloxrouteQuotaUsage
+	fetched time.Time
+}
+
+// throttle delays the request when the cached quota usage reached the threshold, refreshing the usage first when
+// it is stale. Failing to fetch the usage never blocks the request.
+func (rpc *FlashXRoute) throttle(authHeader string) {
+	q := rpc.quota
+	q.mu.Lock()
+	if time.Since(q.fetched) >= q.refresh {
+		q.fetched = time.Now()
+		unthrottled := rpc.With(func(rpc *FlashXRoute) { rpc.quota = nil })
+		usage, err := unthrottled.BloxrouteQuotaUsage(authHeader)
+		if err == nil {
+			q.usage = usage
+		} else if rpc.log != nil {
+			rpc.log.Println(fmt.Sprintf("quota: can't fetch usage from %s: %s", rpc.url, err))
+		}
+	}
+	used := q.usage.Used()
+	q.mu.Unlock()
+
+	if used >= q.threshold {
+		time.Sleep(q.delay)
+	}
+}
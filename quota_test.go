@@ -0,0 +1,45 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestBloxrouteQuotaUsage() {
+	var filled, usageCalls int64 = 50, 0
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		result := `"0xhash"`
+		if gjson.GetBytes(s.getBody(r), "method").String() == "quota_usage" {
+			atomic.AddInt64(&usageCalls, 1)
+			result = fmt.Sprintf(`{"account_id": "a1", "quota_filled": "%d", "quota_limit": 100, "tier_name": "Professional"}`, atomic.LoadInt64(&filled))
+		}
+		w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": %s}`, result)))
+	})
+	defer server.Close()
+
+	usage, err := s.rpc.With(WithURL(server.URL)).BloxrouteQuotaUsage("auth")
+	s.Require().Nil(err)
+	s.Require().Equal(BloxrouteQuotaUsage{AccountID: "a1", QuotaFilled: 50, QuotaLimit: 100, Tier: "Professional"}, usage)
+	s.Require().Equal(int64(50), usage.Remaining())
+	s.Require().Equal(0.5, usage.Used())
+
+	rpc := s.rpc.With(WithURL(server.URL), WithQuotaThrottle(0.9, 100*time.Millisecond, time.Hour))
+	started := time.Now()
+	_, err = rpc.BloxrouteSendTransaction("auth", BloxrouteSendTransactionRequest{Transaction: "00"})
+	s.Require().Nil(err)
+	s.Require().Less(time.Since(started), 100*time.Millisecond)
+
+	atomic.StoreInt64(&filled, 95)
+	rpc = s.rpc.With(WithURL(server.URL), WithQuotaThrottle(0.9, 100*time.Millisecond, time.Hour))
+	for i := 0; i < 2; i++ {
+		started = time.Now()
+		_, err = rpc.BloxrouteSendTransaction("auth", BloxrouteSendTransactionRequest{Transaction: "00"})
+		s.Require().Nil(err)
+		s.Require().GreaterOrEqual(time.Since(started), 100*time.Millisecond)
+	}
+	s.Require().Equal(int64(3), atomic.LoadInt64(&usageCalls))
+}
@@ -0,0 +1,92 @@
+package flashxroute
+
+import (
+	"fmt"
+	"sync"
+)
+
+// GetTransactionReceipts fetches receipts of the given transactions using up to concurrency parallel requests.
+// Receipts are returned in the order of hashes, the first failure aborts remaining fetches and is returned.
+func (rpc *FlashXRoute) GetTransactionReceipts(hashes []string, concurrency int) ([]*TransactionReceipt, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(hashes) {
+		concurrency = len(hashes)
+	}
+
+	receipts := make([]*TransactionReceipt, len(hashes))
+	indexes := make(chan int)
+	done := make(chan struct{})
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				receipt, err := rpc.EthGetTransactionReceipt(hashes[i])
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("receipt %s: %w", hashes[i], err)
+						close(done)
+					})
+					continue
+				}
+				receipts[i] = receipt
+			}
+		}()
+	}
+
+feed:
+	for i := range hashes {
+		select {
+		case indexes <- i:
+		case <-done:
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return receipts, nil
+}
+
+// GetBlockReceipts returns receipts of all transactions in the block, using eth_getBlockReceipts when the node
+// supports it and falling back to fetching receipts of the block's transactions with bounded concurrency.
+func (rpc *FlashXRoute) GetBlockReceipts(number int, concurrency int) ([]*TransactionReceipt, error) {
+	receipts, err := rpc.EthGetBlockReceipts(IntToHex(number))
+	if err == nil {
+		result := make([]*TransactionReceipt, len(receipts))
+		for i := range receipts {
+			result[i] = &receipts[i]
+		}
+		return result, nil
+	}
+	if _, ok := err.(RpcError); !ok {
+		return nil, err
+	}
+
+	block, err := rpc.EthGetBlockByNumber(number, false)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	hashes := make([]string, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		hashes[i] = tx.Hash
+	}
+
+	return rpc.GetTransactionReceipts(hashes, concurrency)
+}
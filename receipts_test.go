@@ -0,0 +1,36 @@
+package flashxroute
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestGetTransactionReceipts() {
+	hashes := []string{"0x01", "0x02", "0x03", "0x04", "0x05"}
+
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		body := s.getBody(request)
+		s.methodEqual(body, "eth_getTransactionReceipt")
+		hash := gjson.GetBytes(body, "params.0").String()
+		if hash == "0x04" {
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "boom"}}`), nil
+		}
+
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": {"transactionHash": "%s", "gasUsed": "0x5208"}}`, hash)), nil
+	})
+
+	receipts, err := s.rpc.GetTransactionReceipts(hashes[:3], 2)
+	s.Require().Nil(err)
+	s.Require().Len(receipts, 3)
+	for i, receipt := range receipts {
+		s.Require().Equal(hashes[i], receipt.TransactionHash)
+		s.Require().Equal(21000, receipt.GasUsed)
+	}
+
+	_, err = s.rpc.GetTransactionReceipts(hashes, 3)
+	s.Require().NotNil(err)
+	s.Require().Contains(err.Error(), "0x04")
+}
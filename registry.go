@@ -0,0 +1,137 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownMethod is returned by CallMethod for methods not registered with Register
+var ErrUnknownMethod = errors.New("method not registered")
+
+// MethodCodec - typed params and result of a custom json-rpc method, e.g. of a specialized node or private relay
+type MethodCodec[P, R any] struct {
+	Encode    func(params P) (interface{}, error)     // json-rpc params of the call (default: params, see paramList)
+	Decode    func(result json.RawMessage) (R, error) // result of the call (default: json.Unmarshal)
+	Bloxroute bool                                    // sent like blxr_* methods, params as object with the Authorization header
+}
+
+// Method - typed wrapper of a method registered with Register
+type Method[P, R any] struct {
+	Name  string
+	codec MethodCodec[P, R]
+}
+
+// registeredMethod - Method with erased types, so the registry can hold methods of any types
+type registeredMethod interface {
+	call(rpc *FlashXRoute, params interface{}) (interface{}, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	methods map[string]registeredMethod
+}{methods: map[string]registeredMethod{}}
+
+// Register registers custom method name with its codec and returns its typed wrapper, e.g.
+//
+//	var suavexFoo = flashxroute.Register("suavex_foo", flashxroute.MethodCodec[FooParams, FooResult]{})
+//	result, err := suavexFoo.Call(rpc, FooParams{...})
+//
+// It panics when name is empty or already registered.
+func Register[P, R any](name string, codec MethodCodec[P, R]) Method[P, R] {
+	if name == "" {
+		panic("flashxroute: Register with empty method name")
+	}
+	method := Method[P, R]{Name: name, codec: codec}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.methods[name]; dup {
+		panic("flashxroute: Register called twice for method " + name)
+	}
+	registry.methods[name] = method
+
+	return method
+}
+
+// RegisteredMethods returns the sorted names of the methods registered with Register
+func RegisteredMethods() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.methods))
+	for name := range registry.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// CallMethod calls registered method name with params of its codec's params type and returns its decoded result,
+// for callers choosing the method at runtime
+func (rpc *FlashXRoute) CallMethod(name string, params interface{}) (interface{}, error) {
+	registry.RLock()
+	method, ok := registry.methods[name]
+	registry.RUnlock()
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownMethod, name)
+	}
+
+	return method.call(rpc, params)
+}
+
+// Call calls the method on rpc with params encoded and the result decoded by its codec
+func (m Method[P, R]) Call(rpc *FlashXRoute, params P) (R, error) {
+	var result R
+
+	var encoded interface{} = params
+	if m.codec.Encode != nil {
+		var err error
+		if encoded, err = m.codec.Encode(params); err != nil {
+			return result, errors.Wrapf(err, "encode %s params", m.Name)
+		}
+	}
+
+	var raw json.RawMessage
+	var err error
+	if m.codec.Bloxroute {
+		raw, err = rpc.CallWithBloxrouteAuthHeader(m.Name, "", encoded)
+	} else {
+		raw, err = rpc.Call(m.Name, paramList(encoded)...)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if m.codec.Decode != nil {
+		return m.codec.Decode(raw)
+	}
+	err = json.Unmarshal(raw, &result)
+	return result, err
+}
+
+func (m Method[P, R]) call(rpc *FlashXRoute, params interface{}) (interface{}, error) {
+	typed, ok := params.(P)
+	if !ok {
+		var zero P
+		return nil, errors.Errorf("%s params must be %T, got %T", m.Name, zero, params)
+	}
+
+	return m.Call(rpc, typed)
+}
+
+// paramList returns encoded params as positional params: a []interface{} as is, nil as no params and any other
+// value as the only param
+func paramList(encoded interface{}) []interface{} {
+	switch encoded := encoded.(type) {
+	case []interface{}:
+		return encoded
+	case nil:
+		return nil
+	}
+
+	return []interface{}{encoded}
+}
@@ -0,0 +1,54 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+type fooParams struct {
+	Block int    `json:"block"`
+	Tag   string `json:"tag"`
+}
+
+type fooResult struct {
+	Value big.Int
+}
+
+var (
+	testFoo = Register("suavex_foo", MethodCodec[fooParams, fooResult]{
+		Decode: func(result json.RawMessage) (fooResult, error) {
+			var value string
+			if err := json.Unmarshal(result, &value); err != nil {
+				return fooResult{}, err
+			}
+			number, err := ParseBigInt(value)
+			return fooResult{Value: number}, err
+		},
+	})
+	testBar = Register("suavex_bar", MethodCodec[[]string, []string]{
+		Encode: func(params []string) (interface{}, error) { return []interface{}{params[0], params[1]}, nil },
+	})
+)
+
+func (s *FlashXRouteTestSuite) TestRegisteredMethod() {
+	s.registerMethods(map[string]string{
+		`suavex_foo [{"block":1,"tag":"x"}]`: `"0x10"`,
+		`suavex_bar ["a","b"]`:               `["c"]`,
+	})
+
+	foo, err := testFoo.Call(s.rpc, fooParams{Block: 1, Tag: "x"})
+	s.Require().Nil(err)
+	s.Require().Equal(int64(16), foo.Value.Int64())
+
+	bar, err := s.rpc.CallMethod("suavex_bar", []string{"a", "b"})
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"c"}, bar)
+
+	_, err = s.rpc.CallMethod("suavex_bar", "a")
+	s.Require().EqualError(err, "suavex_bar params must be []string, got string")
+	_, err = s.rpc.CallMethod("suavex_baz", nil)
+	s.Require().ErrorIs(err, ErrUnknownMethod)
+
+	s.Require().Subset(RegisteredMethods(), []string{"suavex_bar", "suavex_foo"})
+	s.Require().Panics(func() { Register("suavex_foo", MethodCodec[int, int]{}) })
+}
@@ -0,0 +1,53 @@
+// Package relay narrows flashxroute to multi relay and builder submission: the builder registry, builder selection
+// policies, direct builder submission and relay sets. Its types are aliases of the root package ones.
+package relay
+
+import (
+	"github.com/saman-pasha/flashxroute"
+)
+
+// Option - client option, any flashxroute.With* option
+type Option = func(rpc *flashxroute.FlashXRoute)
+
+// Builder registry and submission types
+type (
+	Builder              = flashxroute.Builder
+	Policy               = flashxroute.Policy
+	BuilderClient        = flashxroute.BuilderClient
+	BuilderSubmission    = flashxroute.BuilderSubmission
+	SendBundleRequest    = flashxroute.SendBundleRequest
+	SimulationComparison = flashxroute.SimulationComparison
+)
+
+// Builders returns copy of the known builders
+func Builders() []Builder {
+	return append([]Builder(nil), flashxroute.KnownBuilders...)
+}
+
+// LookupBuilder returns known builder with name
+func LookupBuilder(name string) (Builder, bool) {
+	return flashxroute.KnownBuilder(name)
+}
+
+// SendBundle submits bundle directly to every builder with an endpoint
+func SendBundle(builders []Builder, params SendBundleRequest, options ...Option) []BuilderSubmission {
+	return flashxroute.SendBundleToBuilders(builders, params, options...)
+}
+
+// Set - relays receiving the same bundles
+type Set []*flashxroute.FlashXRoute
+
+// LoadSet create the clients of the named relay set of the config file at path, see flashxroute.LoadConfig
+func LoadSet(path, name string, options ...Option) (Set, error) {
+	config, err := flashxroute.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.RelaySet(name, options...)
+}
+
+// Simulate simulates bundle on every relay of the set and compares the results
+func (s Set) Simulate(authHeader string, bundle flashxroute.BloxrouteSimulateBundleRequest) SimulationComparison {
+	return flashxroute.SimulateAcrossRelays(authHeader, bundle, s...)
+}
@@ -0,0 +1,41 @@
+package relay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestBuilders(t *testing.T) {
+	builder, ok := LookupBuilder("flashbots")
+	require.True(t, ok)
+	require.True(t, builder.SignatureRequired)
+
+	_, ok = LookupBuilder("unknown")
+	require.False(t, ok)
+
+	builders := Builders()
+	builders[0].Name = "changed"
+	require.NotEqual(t, "changed", Builders()[0].Name)
+}
+
+func TestSendBundle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, "eth_sendBundle", gjson.GetBytes(body, "method").String())
+		require.Equal(t, "0x01", gjson.GetBytes(body, "params.0.txs.0").String())
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb"}}`))
+	}))
+	defer server.Close()
+
+	builders := []Builder{{Name: "direct", URL: server.URL}, {Name: "mev_builders only"}}
+	submissions := SendBundle(builders, SendBundleRequest{Txs: []string{"01"}, BlockNumber: "0x10"})
+	require.Len(t, submissions, 2)
+	require.Nil(t, submissions[0].Err)
+	require.Equal(t, "0xb", submissions[0].BundleHash)
+	require.NotNil(t, submissions[1].Err)
+}
@@ -0,0 +1,149 @@
+package flashxroute
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errorStringSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector       = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized function",
+}
+
+// DecodeRevertReason decodes Error(string) and Panic(uint256) revert data into a readable reason,
+// ok is false for custom errors and malformed data
+func DecodeRevertReason(data []byte) (reason string, ok bool) {
+	switch {
+	case len(data) >= 4 && bytes.Equal(data[:4], errorStringSelector):
+		args := data[4:]
+		if len(args) < 64 {
+			return "", false
+		}
+		offset := new(big.Int).SetBytes(args[:32])
+		if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(args)) {
+			return "", false
+		}
+		start := offset.Uint64() + 32
+		length := new(big.Int).SetBytes(args[start-32 : start])
+		if !length.IsUint64() || start+length.Uint64() > uint64(len(args)) {
+			return "", false
+		}
+		return string(args[start : start+length.Uint64()]), true
+	case len(data) == 36 && bytes.Equal(data[:4], panicSelector):
+		code := new(big.Int).SetBytes(data[4:])
+		reason, known := panicReasons[code.Uint64()]
+		if !code.IsUint64() || !known {
+			reason = "unknown panic"
+		}
+		return fmt.Sprintf("panic: %s (0x%x)", reason, code), true
+	}
+
+	return "", false
+}
+
+// RevertError - eth_call reverted, with the revert reason decoded from the error data
+type RevertError struct {
+	RpcError
+	Data   []byte // revert data of the contract, empty when the node didn't return it
+	Reason string // Error(string) message, Panic(uint256) description or custom error signature, empty when unknown
+	custom *eventSignature
+}
+
+func (err *RevertError) Error() string {
+	switch {
+	case err.Reason != "":
+		return "execution reverted: " + err.Reason
+	case len(err.Data) > 0:
+		return "execution reverted: " + BytesToHex(err.Data)
+	}
+
+	return "execution reverted"
+}
+
+// Unwrap returns the json-rpc error of the node
+func (err *RevertError) Unwrap() error {
+	return err.RpcError
+}
+
+// DecodeArgs decodes the arguments of the custom error into the exported fields of the struct out points to, in
+// argument order
+func (err *RevertError) DecodeArgs(out interface{}) error {
+	if err.custom == nil {
+		return errors.New("revert is not a known custom error")
+	}
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("out must be a pointer to struct, got %T", out)
+	}
+
+	return decodeEvent(*err.custom, nil, err.Data[4:], value.Elem())
+}
+
+// EthCallWithErrors is like EthCall, a revert matching one of the custom error declarations like
+// "InsufficientBalance(uint256 available, uint256 required)" has its signature as Reason and arguments decodable
+// with RevertError.DecodeArgs
+func (rpc *FlashXRoute) EthCallWithErrors(transaction T, tag string, customErrors ...string) (string, error) {
+	var data string
+	err := rpc.call("eth_call", &data, transaction, tag)
+	var revert *RevertError
+	switch {
+	case errors.As(err, &revert):
+		return "", newRevertError(revert.RpcError, revert.Data, customErrors)
+	case err != nil:
+		if rpcErr, ok := err.(RpcError); ok {
+			if revert := newRevertError(rpcErr, nil, customErrors); revert != nil {
+				return "", revert
+			}
+		}
+	}
+
+	return data, err
+}
+
+// newRevertError returns the revert of eth_call error rpcErr with revert data or nil for other errors, like an out
+// of gas call
+func newRevertError(rpcErr RpcError, data []byte, customErrors []string) *RevertError {
+	if len(data) == 0 && rpcErr.Code != 3 && !strings.Contains(strings.ToLower(rpcErr.Message), "revert") {
+		return nil
+	}
+
+	revert := &RevertError{RpcError: rpcErr, Data: data}
+	if reason, ok := DecodeRevertReason(data); ok {
+		revert.Reason = reason
+		return revert
+	}
+	if len(data) >= 4 {
+		for _, declaration := range customErrors {
+			sig, err := parseEventSignature(strings.TrimPrefix(strings.TrimSpace(declaration), "error "))
+			if err != nil || sig.topic()[:10] != BytesToHex(data[:4]) {
+				continue
+			}
+			revert.Reason, revert.custom = sig.canonical(), &sig
+			return revert
+		}
+	}
+	if len(data) == 0 {
+		// nodes without error data put the reason in the message
+		revert.Reason = strings.TrimPrefix(strings.TrimPrefix(rpcErr.Message, "execution reverted"), ": ")
+	}
+
+	return revert
+}
@@ -0,0 +1,46 @@
+package flashxroute
+
+import "fmt"
+
+func (s *FlashXRouteTestSuite) TestEthCallRevert() {
+	word := func(n int) string { return fmt.Sprintf("%064x", n) }
+	selector, err := MethodSelector("InsufficientBalance(uint256,uint256)")
+	s.Require().Nil(err)
+	s.registerMethods(map[string]string{
+		`eth_call [{"from":"","to":"0x1"},"latest"]`: `error:{"code": 3, "message": "execution reverted", "data": "` + revertInsufficientOutput + `"}`,
+		`eth_call [{"from":"","to":"0x2"},"latest"]`: `error:{"code": 3, "message": "execution reverted", "data": "` + selector + word(1) + word(2) + `"}`,
+		`eth_call [{"from":"","to":"0x3"},"latest"]`: `error:{"code": -32000, "message": "execution reverted: old node"}`,
+		`eth_call [{"from":"","to":"0x4"},"latest"]`: `error:{"code": -32000, "message": "out of gas"}`,
+	})
+
+	_, err = s.rpc.EthCall(T{To: "0x1"}, "latest")
+	var revert *RevertError
+	s.Require().ErrorAs(err, &revert)
+	s.Require().Equal("Insufficient output amount", revert.Reason)
+	s.Require().EqualError(err, "execution reverted: Insufficient output amount")
+	var rpcErr RpcError
+	s.Require().ErrorAs(err, &rpcErr)
+	s.Require().Equal(3, rpcErr.Code)
+
+	_, err = s.rpc.EthCallWithErrors(T{To: "0x2"}, "latest", "error Unauthorized()", "InsufficientBalance(uint256 available, uint256 required)")
+	s.Require().ErrorAs(err, &revert)
+	s.Require().Equal("InsufficientBalance(uint256,uint256)", revert.Reason)
+	var args struct {
+		Available, Required int
+	}
+	s.Require().Nil(revert.DecodeArgs(&args))
+	s.Require().Equal(1, args.Available)
+	s.Require().Equal(2, args.Required)
+
+	_, err = s.rpc.EthCall(T{To: "0x2"}, "latest")
+	s.Require().ErrorAs(err, &revert)
+	s.Require().Empty(revert.Reason)
+	s.Require().Error(revert.DecodeArgs(&args))
+
+	_, err = s.rpc.EthCall(T{To: "0x3"}, "latest")
+	s.Require().ErrorAs(err, &revert)
+	s.Require().Equal("old node", revert.Reason)
+
+	_, err = s.rpc.EthCall(T{To: "0x4"}, "latest")
+	s.Require().Equal(RpcError{Code: -32000, Message: "out of gas"}, err)
+}
@@ -0,0 +1,108 @@
+package flashxroute
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// errInvalidRLP is returned for malformed RLP data
+var errInvalidRLP = errors.New("invalid rlp")
+
+// rlpSplit splits the first item of data, returning whether it is a list, its content and the remaining data
+func rlpSplit(data []byte) (list bool, content, rest []byte, err error) {
+	if len(data) == 0 {
+		return false, nil, nil, errors.Wrap(errInvalidRLP, "empty data")
+	}
+
+	prefix := data[0]
+	var offset, size uint64
+	switch {
+	case prefix < 0x80:
+		return false, data[:1], data[1:], nil
+	case prefix < 0xb8:
+		offset, size = 1, uint64(prefix-0x80)
+	case prefix < 0xc0:
+		offset, size, err = rlpLongSize(data, prefix-0xb7)
+		if err != nil {
+			return false, nil, nil, err
+		}
+	case prefix < 0xf8:
+		list, offset, size = true, 1, uint64(prefix-0xc0)
+	default:
+		list = true
+		offset, size, err = rlpLongSize(data, prefix-0xf7)
+		if err != nil {
+			return false, nil, nil, err
+		}
+	}
+	if size > uint64(len(data))-offset {
+		return false, nil, nil, errors.Wrap(errInvalidRLP, "item exceeds data")
+	}
+
+	return list, data[offset : offset+size], data[offset+size:], nil
+}
+
+// rlpLongSize reads the size of a long item encoded in the lengthSize bytes following the prefix
+func rlpLongSize(data []byte, lengthSize byte) (offset, size uint64, err error) {
+	if int(lengthSize) >= len(data) || lengthSize > 8 {
+		return 0, 0, errors.Wrap(errInvalidRLP, "truncated length")
+	}
+	var buf [8]byte
+	copy(buf[8-lengthSize:], data[1:1+lengthSize])
+
+	return 1 + uint64(lengthSize), binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// rlpItems splits the content of a list into its encoded items
+func rlpItems(content []byte) ([][]byte, error) {
+	var items [][]byte
+	for len(content) > 0 {
+		_, _, rest, err := rlpSplit(content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, content[:len(content)-len(rest)])
+		content = rest
+	}
+
+	return items, nil
+}
+
+// rlpHeader returns the prefix of an item of size bytes, offset is 0x80 for strings and 0xc0 for lists
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(size))
+	i := 0
+	for buf[i] == 0 {
+		i++
+	}
+
+	return append([]byte{offset + 55 + byte(8-i)}, buf[i:]...)
+}
+
+// rlpBytes encodes data as RLP string
+func rlpBytes(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(rlpHeader(0x80, len(data)), data...)
+}
+
+// rlpList encodes already encoded items as RLP list
+func rlpList(items ...[]byte) []byte {
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+	encoded := rlpHeader(0xc0, size)
+	for _, item := range items {
+		encoded = append(encoded, item...)
+	}
+
+	return encoded
+}
@@ -0,0 +1,90 @@
+package flashxroute
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// scanJob - block of ScanBlocks to fetch and where its result goes
+type scanJob struct {
+	number int
+	result chan scanResult
+}
+
+type scanResult struct {
+	block *Block
+	err   error
+}
+
+// ScanBlocks fetches blocks from to to, inclusive, with their transactions using up to concurrency parallel requests
+// and calls fn with each block in ascending order from the calling goroutine, e.g. to backfill the Journal or
+// backtest a strategy. Fetches run at most 2*concurrency blocks ahead of fn. The scan stops at the first failed
+// fetch, error of fn or when ctx is done, and returns that error.
+func (rpc *FlashXRoute) ScanBlocks(ctx context.Context, from, to, concurrency int, fn func(block *Block) error) error {
+	if to < from {
+		return nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	jobs := make(chan scanJob)
+	ordered := make(chan chan scanResult, 2*concurrency)
+	go func() {
+		defer close(jobs)
+		defer close(ordered)
+		for number := from; number <= to; number++ {
+			job := scanJob{number: number, result: make(chan scanResult, 1)}
+			select {
+			case ordered <- job.result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				block, err := rpc.EthGetBlockByNumber(job.number, true)
+				switch {
+				case err != nil:
+					err = fmt.Errorf("block %d: %w", job.number, err)
+				case block == nil:
+					err = fmt.Errorf("block %d not found", job.number)
+				}
+				job.result <- scanResult{block: block, err: err}
+			}
+		}()
+	}
+
+	for result := range ordered {
+		select {
+		case res := <-result:
+			if res.err != nil {
+				return res.err
+			}
+			if err := fn(res.block); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,64 @@
+package flashxroute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+func (s *FlashXRouteTestSuite) TestScanBlocks() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		s.methodEqual(body, "eth_getBlockByNumber")
+		s.Require().True(gjson.GetBytes(body, "params.1").Bool())
+		number := gjson.GetBytes(body, "params.0").String()
+		switch number {
+		case "0x19":
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "boom"}}`))
+		case "0x1a":
+			w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": null}`))
+		default:
+			w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0", "id":1, "result": {"number": "%s", "transactions": []}}`, number)))
+		}
+	})
+	defer server.Close()
+	rpc := New(server.URL, WithHttpClient(http.DefaultClient))
+
+	var numbers []int
+	err := rpc.ScanBlocks(context.Background(), 1, 20, 4, func(block *Block) error {
+		numbers = append(numbers, block.Number)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Len(numbers, 20)
+	for i, number := range numbers {
+		s.Require().Equal(i+1, number)
+	}
+
+	numbers = nil
+	err = rpc.ScanBlocks(context.Background(), 20, 30, 3, func(block *Block) error {
+		numbers = append(numbers, block.Number)
+		return nil
+	})
+	s.Require().ErrorContains(err, "block 25")
+	s.Require().Equal([]int{20, 21, 22, 23, 24}, numbers)
+
+	err = rpc.ScanBlocks(context.Background(), 26, 26, 1, func(block *Block) error { return nil })
+	s.Require().ErrorContains(err, "block 26 not found")
+
+	stop := errors.New("stop")
+	err = rpc.ScanBlocks(context.Background(), 1, 100, 2, func(block *Block) error {
+		if block.Number == 3 {
+			return stop
+		}
+		return nil
+	})
+	s.Require().ErrorIs(err, stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Require().ErrorIs(rpc.ScanBlocks(ctx, 1, 100, 2, func(block *Block) error { return nil }), context.Canceled)
+}
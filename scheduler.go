@@ -0,0 +1,116 @@
+package flashxroute
+
+import (
+	"context"
+	"time"
+)
+
+// Beacon chain slot timing
+var (
+	MainnetGenesisTime = time.Unix(1606824023, 0)
+	SepoliaGenesisTime = time.Unix(1655733600, 0)
+	HoleskyGenesisTime = time.Unix(1695902400, 0)
+)
+
+// SlotDuration - duration of a beacon chain slot, one block per slot
+const SlotDuration = 12 * time.Second
+
+// Scheduler - runs callbacks aligned to beacon chain slot boundaries, e.g. simulate at slot start and submit bundles
+// in the last 2 seconds before the next block
+type Scheduler struct {
+	Genesis time.Time
+	Period  time.Duration // slot period, SlotDuration when not positive
+	now     func() time.Time
+}
+
+// NewScheduler create scheduler for a chain with given genesis time and slot period, SlotDuration when period is not
+// positive
+func NewScheduler(genesis time.Time, period time.Duration) *Scheduler {
+	if period <= 0 {
+		period = SlotDuration
+	}
+
+	return &Scheduler{
+		Genesis: genesis,
+		Period:  period,
+		now:     time.Now,
+	}
+}
+
+// NewMainnetScheduler create scheduler for ethereum mainnet 12 second slots
+func NewMainnetScheduler() *Scheduler {
+	return NewScheduler(MainnetGenesisTime, SlotDuration)
+}
+
+// Slot returns the slot number at given time, 0 before genesis
+func (s *Scheduler) Slot(at time.Time) uint64 {
+	if at.Before(s.Genesis) {
+		return 0
+	}
+
+	return uint64(at.Sub(s.Genesis) / s.period())
+}
+
+// period returns Period, SlotDuration when it isn't positive
+func (s *Scheduler) period() time.Duration {
+	if s.Period <= 0 {
+		return SlotDuration
+	}
+
+	return s.Period
+}
+
+// CurrentSlot returns the current slot number
+func (s *Scheduler) CurrentSlot() uint64 {
+	return s.Slot(s.now())
+}
+
+// SlotStart returns the start time of slot
+func (s *Scheduler) SlotStart(slot uint64) time.Time {
+	return s.Genesis.Add(time.Duration(slot) * s.period())
+}
+
+// UntilNextSlot returns time left until the next slot boundary
+func (s *Scheduler) UntilNextSlot() time.Duration {
+	now := s.now()
+	return s.SlotStart(s.Slot(now) + 1).Sub(now)
+}
+
+// next returns the first slot whose trigger time (slot start + offset) is not in the past
+func (s *Scheduler) next(offset time.Duration) (uint64, time.Time) {
+	now := s.now()
+	slot := s.Slot(now.Add(-offset))
+	at := s.SlotStart(slot).Add(offset)
+	for at.Before(now) {
+		slot++
+		at = s.SlotStart(slot).Add(offset)
+	}
+
+	return slot, at
+}
+
+// Run calls fn once per slot at slot start + offset until ctx is done, a negative offset runs before the boundary
+// of the slot passed to fn. fn runs synchronously, a slow fn makes the scheduler skip the slots it overran.
+func (s *Scheduler) Run(ctx context.Context, offset time.Duration, fn func(slot uint64)) error {
+	for {
+		slot, at := s.next(offset)
+		timer := time.NewTimer(at.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+			fn(slot)
+		}
+	}
+}
+
+// RunBefore calls fn the given duration before every slot boundary until ctx is done
+func (s *Scheduler) RunBefore(ctx context.Context, before time.Duration, fn func(slot uint64)) error {
+	return s.Run(ctx, -before, fn)
+}
+
+// RunAtSlotStart calls fn at the start of every slot until ctx is done
+func (s *Scheduler) RunAtSlotStart(ctx context.Context, fn func(slot uint64)) error {
+	return s.Run(ctx, 0, fn)
+}
@@ -0,0 +1,56 @@
+package flashxroute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerSlots(t *testing.T) {
+	scheduler := NewMainnetScheduler()
+	require.Equal(t, uint64(0), scheduler.Slot(MainnetGenesisTime.Add(-time.Hour)))
+	require.Equal(t, uint64(0), scheduler.Slot(MainnetGenesisTime.Add(11*time.Second)))
+	require.Equal(t, uint64(1), scheduler.Slot(MainnetGenesisTime.Add(12*time.Second)))
+	require.Equal(t, MainnetGenesisTime.Add(120*time.Second), scheduler.SlotStart(10))
+
+	scheduler.now = func() time.Time { return MainnetGenesisTime.Add(125 * time.Second) }
+	require.Equal(t, uint64(10), scheduler.CurrentSlot())
+	require.Equal(t, 7*time.Second, scheduler.UntilNextSlot())
+
+	slot, at := scheduler.next(-2 * time.Second)
+	require.Equal(t, uint64(11), slot)
+	require.Equal(t, MainnetGenesisTime.Add(130*time.Second), at)
+
+	slot, at = scheduler.next(-10 * time.Second)
+	require.Equal(t, uint64(12), slot)
+	require.Equal(t, MainnetGenesisTime.Add(134*time.Second), at)
+}
+
+func TestSchedulerPeriodAndGenesisEdges(t *testing.T) {
+	scheduler := NewScheduler(MainnetGenesisTime, 0)
+	require.Equal(t, SlotDuration, scheduler.Period)
+	require.Equal(t, uint64(2), scheduler.Slot(MainnetGenesisTime.Add(25*time.Second)))
+
+	scheduler.Period = -time.Second
+	require.Equal(t, uint64(2), scheduler.Slot(MainnetGenesisTime.Add(25*time.Second)))
+	require.Equal(t, MainnetGenesisTime.Add(24*time.Second), scheduler.SlotStart(2))
+
+	require.Equal(t, uint64(0), scheduler.Slot(MainnetGenesisTime.Add(-time.Nanosecond)))
+	require.Equal(t, uint64(0), scheduler.Slot(time.Time{}))
+}
+
+func TestSchedulerRunBefore(t *testing.T) {
+	scheduler := NewScheduler(time.Now(), 50*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 180*time.Millisecond)
+	defer cancel()
+
+	var slots []uint64
+	err := scheduler.RunBefore(ctx, 10*time.Millisecond, func(slot uint64) {
+		slots = append(slots, slot)
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.GreaterOrEqual(t, len(slots), 2)
+	require.Equal(t, []uint64{1, 2}, slots[:2])
+}
@@ -0,0 +1,51 @@
+package flashxroute
+
+import "github.com/pkg/errors"
+
+// ErrValidatorsOnlyUnsupported is returned when sending a validators only transaction on a network bloXroute
+// doesn't support them on
+var ErrValidatorsOnlyUnsupported = errors.New("validators only transactions not supported on network")
+
+// validatorsOnlyNetworks - networks supporting validators_only with where the transaction is delivered
+var validatorsOnlyNetworks = map[string]string{
+	NetworkMainnet:        "block builders, never the public mempool",
+	NetworkBSCMainnet:     "validators directly, skipping the public mempool",
+	NetworkPolygonMainnet: "validators directly, skipping the public mempool",
+}
+
+// ValidatorsOnlySupported reports whether bloXroute accepts validators only (semi-private) transactions on network,
+// the empty network stands for Mainnet
+func ValidatorsOnlySupported(network string) bool {
+	if network == "" {
+		network = NetworkMainnet
+	}
+	_, ok := validatorsOnlyNetworks[network]
+	return ok
+}
+
+// ValidatorsOnlyDelivery describes where bloXroute delivers validators only transactions on network, empty when
+// unsupported
+func ValidatorsOnlyDelivery(network string) string {
+	if network == "" {
+		network = NetworkMainnet
+	}
+	return validatorsOnlyNetworks[network]
+}
+
+// checkValidatorsOnly fails with ErrValidatorsOnlyUnsupported when params ask for validators only delivery on a
+// network not supporting it
+func checkValidatorsOnly(params BloxrouteSendTransactionRequest) error {
+	if params.ValidatorsOnly && !ValidatorsOnlySupported(params.BlockchainNetwork) {
+		return errors.Wrap(ErrValidatorsOnlyUnsupported, params.BlockchainNetwork)
+	}
+
+	return nil
+}
+
+// BloxrouteSendSemiPrivateTransaction sends raw transaction with blxr_tx to validators only on the client's network:
+// unlike a private transaction it lands without a bundle, unlike a public one it isn't exposed to the mempool, see
+// ValidatorsOnlyDelivery for the network specific delivery. Networks without support fail with
+// ErrValidatorsOnlyUnsupported before sending.
+func (rpc *FlashXRoute) BloxrouteSendSemiPrivateTransaction(authHeader, raw string) (string, error) {
+	return rpc.BloxrouteSendTransaction(authHeader, BloxrouteSendTransactionRequest{Transaction: raw, ValidatorsOnly: true})
+}
@@ -0,0 +1,34 @@
+package flashxroute
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorsOnlySupported(t *testing.T) {
+	require.True(t, ValidatorsOnlySupported(""))
+	require.True(t, ValidatorsOnlySupported(NetworkBSCMainnet))
+	require.False(t, ValidatorsOnlySupported("Goerli"))
+	require.Contains(t, ValidatorsOnlyDelivery(NetworkBSCMainnet), "validators")
+	require.Empty(t, ValidatorsOnlyDelivery("Goerli"))
+}
+
+func (s *FlashXRouteTestSuite) TestBloxrouteSendSemiPrivateTransaction() {
+	server := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		body := s.getBody(r)
+		s.methodEqual(body, "blxr_tx")
+		s.paramsEqual(body, `{"transaction": "f8", "blockchain_network": "BSC-Mainnet", "validators_only": true}`)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0xabc"}`))
+	})
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL), WithNetwork(NetworkBSCMainnet))
+	txHash, err := rpc.BloxrouteSendSemiPrivateTransaction("auth", "0xf8")
+	s.Require().Nil(err)
+	s.Require().Equal("0xabc", txHash)
+
+	_, err = s.rpc.With(WithNetwork("Goerli")).BloxrouteSendSemiPrivateTransaction("auth", "0xf8")
+	s.Require().ErrorIs(err, ErrValidatorsOnlyUnsupported)
+}
@@ -0,0 +1,133 @@
+package flashxroute
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ErrNoSigner is returned by methods which need a signer when the client was created without WithSigner
+var ErrNoSigner = errors.New("no signer configured, set one with WithSigner")
+
+// ErrTextSigningUnsupported is returned when signing a message, e.g. the X-Flashbots-Signature of a request, with a
+// signer that only signs transactions. The Ledger and Trezor drivers of go-ethereum can't sign personal messages.
+var ErrTextSigningUnsupported = errors.New("signer only signs transactions, Flashbots requests need a signer of personal messages")
+
+// Signer - signs transactions and messages on behalf of a single account
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignText(text []byte) ([]byte, error) // EIP-191 personal message signature
+}
+
+// PrivateKeySigner - Signer holding the private key in memory
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner create signer from private key
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// Address returns address of the key
+func (s *PrivateKeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// SignTx signs transaction with the latest signer for chainID
+func (s *PrivateKeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// SignText signs keccak256("\x19Ethereum Signed Message:\n" + len(text) + text)
+func (s *PrivateKeySigner) SignText(text []byte) ([]byte, error) {
+	return crypto.Sign(accounts.TextHash(text), s.key)
+}
+
+// WalletSigner - Signer backed by an account of a go-ethereum accounts.Wallet (hardware wallets, keystores)
+type WalletSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+	txOnly  bool // the wallet can't sign personal messages, see ErrTextSigningUnsupported
+}
+
+// NewWalletSigner create signer for account of an opened wallet
+func NewWalletSigner(wallet accounts.Wallet, account accounts.Account) *WalletSigner {
+	return &WalletSigner{wallet: wallet, account: account}
+}
+
+// Address returns address of the account
+func (s *WalletSigner) Address() common.Address {
+	return s.account.Address
+}
+
+// SignTx asks the wallet to sign transaction, hardware wallets wait for confirmation on the device
+func (s *WalletSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.wallet.SignTx(s.account, tx, chainID)
+}
+
+// SignText asks the wallet to sign a personal message, fails with ErrTextSigningUnsupported for hardware wallets
+func (s *WalletSigner) SignText(text []byte) ([]byte, error) {
+	if !s.SignsText() {
+		return nil, errors.Wrap(ErrTextSigningUnsupported, s.wallet.URL().String())
+	}
+	signature, err := s.wallet.SignText(s.account, text)
+	if errors.Is(err, accounts.ErrNotSupported) {
+		return nil, errors.Wrap(ErrTextSigningUnsupported, s.wallet.URL().String())
+	}
+
+	return signature, err
+}
+
+// SignsText reports whether the wallet can sign personal messages, hardware wallets of NewLedgerSigner and
+// NewTrezorSigner can't
+func (s *WalletSigner) SignsText() bool {
+	return !s.txOnly
+}
+
+// checkTextSigner fails with ErrTextSigningUnsupported for signers known to only sign transactions, before a
+// request is built for them
+func checkTextSigner(signer Signer) error {
+	if s, ok := signer.(*WalletSigner); ok && !s.SignsText() {
+		return errors.Wrap(ErrTextSigningUnsupported, s.wallet.URL().String())
+	}
+
+	return nil
+}
+
+// Signer returns signer set with WithSigner or nil
+func (rpc *FlashXRoute) Signer() Signer {
+	return rpc.signer
+}
+
+// SignTransaction signs transaction with the configured signer, chainID is checked against the endpoint's with
+// WithChainIDCheck
+func (rpc *FlashXRoute) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if rpc.signer == nil {
+		return nil, ErrNoSigner
+	}
+	if rpc.checkChain {
+		if err := rpc.CheckChainID(chainID); err != nil {
+			return nil, err
+		}
+	}
+
+	return rpc.signer.SignTx(tx, chainID)
+}
+
+// FlashbotsSignature returns X-Flashbots-Signature header value for request body: address:signature of keccak256(body) hex
+func FlashbotsSignature(signer Signer, body []byte) (string, error) {
+	hashedBody := crypto.Keccak256Hash(body).Hex()
+	signature, err := signer.SignText([]byte(hashedBody))
+	if err != nil {
+		return "", err
+	}
+
+	return signer.Address().Hex() + ":" + BytesToHex(signature), nil
+}
@@ -0,0 +1,80 @@
+package flashxroute
+
+import (
+	"crypto/ecdsa"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlashbotsSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := NewPrivateKeySigner(key)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	header, err := FlashbotsSignature(signer, []byte(`{"method":"eth_sendBundle"}`))
+	require.Nil(t, err)
+
+	parts := strings.Split(header, ":")
+	require.Len(t, parts, 2)
+	require.Equal(t, signer.Address().Hex(), parts[0])
+	require.Len(t, parts[1], 2+2*crypto.SignatureLength)
+}
+
+func TestSignTransactionWithoutSigner(t *testing.T) {
+	rpc := New("http://127.0.0.1:8545")
+	require.Nil(t, rpc.Signer())
+
+	_, err := rpc.SignTransaction(nil, nil)
+	require.ErrorIs(t, err, ErrNoSigner)
+}
+
+// testWallet - accounts.Wallet holding key, methods not overridden panic
+type testWallet struct {
+	accounts.Wallet
+	key       *ecdsa.PrivateKey
+	openErr   error
+	deriveErr error
+	closed    bool
+}
+
+func (w *testWallet) URL() accounts.URL {
+	return accounts.URL{Scheme: "test", Path: "wallet"}
+}
+
+func (w *testWallet) Open(passphrase string) error {
+	return w.openErr
+}
+
+func (w *testWallet) Close() error {
+	w.closed = true
+	return nil
+}
+
+func (w *testWallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	if w.deriveErr != nil {
+		return accounts.Account{}, w.deriveErr
+	}
+	return accounts.Account{Address: crypto.PubkeyToAddress(w.key.PublicKey)}, nil
+}
+
+func (w *testWallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return crypto.Sign(accounts.TextHash(text), w.key)
+}
+
+func TestWalletSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	wallet := &testWallet{key: key}
+	account := accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey)}
+	signer := NewWalletSigner(wallet, account)
+	require.Equal(t, account.Address, signer.Address())
+
+	header, err := FlashbotsSignature(signer, []byte(`{"method":"eth_sendBundle"}`))
+	require.Nil(t, err)
+	expected, err := FlashbotsSignature(NewPrivateKeySigner(key), []byte(`{"method":"eth_sendBundle"}`))
+	require.Nil(t, err)
+	require.Equal(t, expected, header)
+}
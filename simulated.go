@@ -0,0 +1,394 @@
+package flashxroute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SimulatedBackend - the methods of go-ethereum's backends.SimulatedBackend (an in-process chain) used by
+// NewSimulated
+type SimulatedBackend interface {
+	Commit()
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	StorageAt(ctx context.Context, contract common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// SimulatedChainID is the chain id of go-ethereum's simulated backend
+const SimulatedChainID = 1337
+
+// NewSimulated create rpc client served in-process by a simulated backend, e.g.
+// backends.NewSimulatedBackend(alloc, gasLimit), to test strategy code end-to-end without a node or relay.
+//
+// Transactions and bundles (eth_sendRawTransaction, blxr_tx, blxr_submit_bundle) are mined into a new block right
+// away. Methods the backend can't serve fail with a -32601 RpcError.
+func NewSimulated(backend SimulatedBackend, options ...func(rpc *FlashXRoute)) *FlashXRoute {
+	transport := &simulatedTransport{backend: backend}
+	return New("http://simulated", append([]func(rpc *FlashXRoute){WithTransport(transport)}, options...)...)
+}
+
+// simulatedTransport - answers json-rpc requests from a simulated backend
+type simulatedTransport struct {
+	backend SimulatedBackend
+	mu      sync.Mutex // the backend mines on Commit, keep submissions atomic
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *simulatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": 1}
+	request := struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		response["error"] = RpcError{Code: -32700, Message: err.Error()}
+	} else if result, err := t.serve(req.Context(), request.Method, request.Params); err != nil {
+		if rpcErr, ok := err.(RpcError); ok {
+			response["error"] = rpcErr
+		} else {
+			response["error"] = RpcError{Code: -32000, Message: err.Error()}
+		}
+	} else {
+		response["result"] = result
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+func (t *simulatedTransport) serve(ctx context.Context, method string, raw json.RawMessage) (interface{}, error) {
+	var params []json.RawMessage
+	if method == "blxr_tx" || method == "blxr_submit_bundle" {
+		params = []json.RawMessage{raw}
+	} else if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, RpcError{Code: -32602, Message: err.Error()}
+	}
+	param := func(i int, target interface{}) error {
+		if i >= len(params) {
+			return RpcError{Code: -32602, Message: fmt.Sprintf("missing param %d", i)}
+		}
+		return json.Unmarshal(params[i], target)
+	}
+	var address, block string
+	blockParam := func(i int) (*big.Int, error) {
+		if err := param(i, &block); err != nil || block == "latest" || block == "pending" {
+			return nil, nil
+		}
+		number, err := ParseBigInt(block)
+		return &number, err
+	}
+
+	switch method {
+	case "eth_chainId", "net_version":
+		if method == "net_version" {
+			return fmt.Sprint(SimulatedChainID), nil
+		}
+		return IntToHex(SimulatedChainID), nil
+
+	case "eth_blockNumber":
+		header, err := t.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return BigToHex(*header.Number), nil
+
+	case "eth_gasPrice":
+		price, err := t.backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return BigToHex(*price), nil
+
+	case "eth_getBalance", "eth_getCode", "eth_getTransactionCount":
+		if err := param(0, &address); err != nil {
+			return nil, err
+		}
+		number, err := blockParam(1)
+		if err != nil {
+			return nil, err
+		}
+		account := common.HexToAddress(address)
+		switch method {
+		case "eth_getBalance":
+			balance, err := t.backend.BalanceAt(ctx, account, number)
+			if err != nil {
+				return nil, err
+			}
+			return BigToHex(*balance), nil
+		case "eth_getCode":
+			code, err := t.backend.CodeAt(ctx, account, number)
+			return BytesToHex(code), err
+		default:
+			nonce, err := t.backend.NonceAt(ctx, account, number)
+			return Uint64ToHex(nonce), err
+		}
+
+	case "eth_getStorageAt":
+		var position string
+		if err := param(0, &address); err != nil {
+			return nil, err
+		}
+		if err := param(1, &position); err != nil {
+			return nil, err
+		}
+		number, err := blockParam(2)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseBigInt(position)
+		if err != nil {
+			return nil, err
+		}
+		value, err := t.backend.StorageAt(ctx, common.HexToAddress(address), common.BigToHash(&key), number)
+		return BytesToHex(value), err
+
+	case "eth_call", "eth_estimateGas":
+		var call simulatedCall
+		if err := param(0, &call); err != nil {
+			return nil, err
+		}
+		msg, err := call.toCallMsg()
+		if err != nil {
+			return nil, err
+		}
+		if method == "eth_estimateGas" {
+			gas, err := t.backend.EstimateGas(ctx, msg)
+			return Uint64ToHex(gas), err
+		}
+		number, err := blockParam(1)
+		if err != nil {
+			return nil, err
+		}
+		output, err := t.backend.CallContract(ctx, msg, number)
+		return BytesToHex(output), err
+
+	case "eth_getBlockByNumber":
+		number, err := blockParam(0)
+		if err != nil {
+			return nil, err
+		}
+		block, err := t.backend.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		return simulatedBlock(block), nil
+
+	case "eth_getTransactionReceipt":
+		var hash string
+		if err := param(0, &hash); err != nil {
+			return nil, err
+		}
+		receipt, err := t.backend.TransactionReceipt(ctx, common.HexToHash(hash))
+		if err != nil || receipt == nil {
+			// unknown transactions have no receipt yet
+			return nil, nil
+		}
+		return simulatedReceipt(receipt), nil
+
+	case "eth_sendRawTransaction":
+		var raw string
+		if err := param(0, &raw); err != nil {
+			return nil, err
+		}
+		hashes, err := t.mine(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+		return hashes[0], nil
+
+	case "blxr_tx":
+		var request BloxrouteSendTransactionRequest
+		if err := param(0, &request); err != nil {
+			return nil, err
+		}
+		hashes, err := t.mine(ctx, request.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		return hashes[0], nil
+
+	case "blxr_submit_bundle":
+		var request BloxrouteSubmitBundleRequest
+		if err := param(0, &request); err != nil {
+			return nil, err
+		}
+		hashes, err := t.mine(ctx, request.Transaction...)
+		if err != nil {
+			return nil, err
+		}
+		concatenated := []byte{}
+		for _, hash := range hashes {
+			concatenated = append(concatenated, common.HexToHash(hash).Bytes()...)
+		}
+		return BloxrouteSubmitBundleResponse{BundleHash: Keccak256(concatenated)}, nil
+	}
+
+	return nil, RpcError{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", method)}
+}
+
+// mine sends the raw transactions and commits them in a new block
+func (t *simulatedTransport) mine(ctx context.Context, raws ...string) ([]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hashes := make([]string, len(raws))
+	for i, raw := range raws {
+		data, err := ParseBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		if err := t.backend.SendTransaction(ctx, tx); err != nil {
+			return nil, err
+		}
+		hashes[i] = tx.Hash().Hex()
+	}
+	t.backend.Commit()
+
+	return hashes, nil
+}
+
+// simulatedCall - call object as sent by T.MarshalJSON
+type simulatedCall struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Gas      string `json:"gas"`
+	GasPrice string `json:"gasPrice"`
+	Value    string `json:"value"`
+	Data     string `json:"data"`
+}
+
+func (call simulatedCall) toCallMsg() (ethereum.CallMsg, error) {
+	msg := ethereum.CallMsg{From: common.HexToAddress(call.From)}
+	if call.To != "" {
+		to := common.HexToAddress(call.To)
+		msg.To = &to
+	}
+	if call.Gas != "" {
+		gas, err := ParseUint64(call.Gas)
+		if err != nil {
+			return msg, err
+		}
+		msg.Gas = gas
+	}
+	for _, field := range []struct {
+		value  string
+		target **big.Int
+	}{{call.GasPrice, &msg.GasPrice}, {call.Value, &msg.Value}} {
+		if field.value == "" {
+			continue
+		}
+		value, err := ParseBigInt(field.value)
+		if err != nil {
+			return msg, err
+		}
+		*field.target = &value
+	}
+	if call.Data != "" {
+		data, err := ParseBytes(call.Data)
+		if err != nil {
+			return msg, err
+		}
+		msg.Data = data
+	}
+
+	return msg, nil
+}
+
+func simulatedBlock(block *types.Block) map[string]interface{} {
+	header := block.Header()
+	hashes := make([]string, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		hashes = append(hashes, tx.Hash().Hex())
+	}
+
+	result := map[string]interface{}{
+		"number":       BigToHex(*header.Number),
+		"hash":         block.Hash().Hex(),
+		"parentHash":   header.ParentHash.Hex(),
+		"miner":        header.Coinbase.Hex(),
+		"gasLimit":     Uint64ToHex(header.GasLimit),
+		"gasUsed":      Uint64ToHex(header.GasUsed),
+		"timestamp":    Uint64ToHex(header.Time),
+		"transactions": hashes,
+		"uncles":       []string{},
+	}
+	if header.BaseFee != nil {
+		result["baseFeePerGas"] = BigToHex(*header.BaseFee)
+	}
+
+	return result
+}
+
+func simulatedReceipt(receipt *types.Receipt) map[string]interface{} {
+	logs := make([]map[string]interface{}, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		topics := make([]string, len(log.Topics))
+		for j, topic := range log.Topics {
+			topics[j] = topic.Hex()
+		}
+		logs[i] = map[string]interface{}{
+			"address":          log.Address.Hex(),
+			"topics":           topics,
+			"data":             BytesToHex(log.Data),
+			"blockNumber":      Uint64ToHex(log.BlockNumber),
+			"transactionHash":  log.TxHash.Hex(),
+			"transactionIndex": Uint64ToHex(uint64(log.TxIndex)),
+			"blockHash":        log.BlockHash.Hex(),
+			"logIndex":         Uint64ToHex(uint64(log.Index)),
+			"removed":          log.Removed,
+		}
+	}
+
+	result := map[string]interface{}{
+		"transactionHash":   receipt.TxHash.Hex(),
+		"transactionIndex":  Uint64ToHex(uint64(receipt.TransactionIndex)),
+		"blockHash":         receipt.BlockHash.Hex(),
+		"blockNumber":       BigToHex(*receipt.BlockNumber),
+		"cumulativeGasUsed": Uint64ToHex(receipt.CumulativeGasUsed),
+		"gasUsed":           Uint64ToHex(receipt.GasUsed),
+		"status":            Uint64ToHex(receipt.Status),
+		"logs":              logs,
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		result["contractAddress"] = receipt.ContractAddress.Hex()
+	}
+
+	return result
+}
@@ -0,0 +1,131 @@
+package flashxroute
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend - minimal in-memory chain standing in for backends.SimulatedBackend
+type fakeBackend struct {
+	number   int64
+	pending  []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	balances map[common.Address]*big.Int
+	calls    []ethereum.CallMsg
+}
+
+func (b *fakeBackend) Commit() {
+	b.number++
+	for range b.pending {
+		b.receipts[common.Hash{}] = &types.Receipt{Status: 1, GasUsed: 21000, CumulativeGasUsed: 21000, BlockNumber: big.NewInt(b.number)}
+	}
+	b.pending = nil
+}
+
+func (b *fakeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(b.number), GasLimit: 30000000, BaseFee: big.NewInt(GWei)}, nil
+}
+
+func (b *fakeBackend) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	header, _ := b.HeaderByNumber(ctx, number)
+	if number != nil {
+		header.Number = number
+	}
+	return types.NewBlockWithHeader(header), nil
+}
+
+func (b *fakeBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if balance, ok := b.balances[account]; ok {
+		return balance, nil
+	}
+	return new(big.Int), nil
+}
+
+func (b *fakeBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x60, 0x80}, nil
+}
+
+func (b *fakeBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 7, nil
+}
+
+func (b *fakeBackend) StorageAt(ctx context.Context, contract common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return key.Bytes(), nil
+}
+
+func (b *fakeBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.calls = append(b.calls, call)
+	return []byte{0x01}, nil
+}
+
+func (b *fakeBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (b *fakeBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(GWei), nil
+}
+
+func (b *fakeBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.pending = append(b.pending, tx)
+	return nil
+}
+
+func (b *fakeBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func TestSimulated(t *testing.T) {
+	account := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	backend := &fakeBackend{receipts: map[common.Hash]*types.Receipt{}, balances: map[common.Address]*big.Int{account: big.NewInt(Ether)}}
+	rpc := NewSimulated(backend, WithLogger(nil))
+
+	number, err := rpc.EthBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 0, number)
+
+	balance, err := rpc.EthGetBalance(account.Hex(), "latest")
+	require.Nil(t, err)
+	require.Equal(t, *big.NewInt(Ether), balance)
+
+	nonce, err := rpc.EthGetTransactionCount(account.Hex(), "latest")
+	require.Nil(t, err)
+	require.Equal(t, 7, nonce)
+
+	output, err := rpc.EthCall(T{From: account.Hex(), To: account.Hex(), Value: big.NewInt(1), Data: "0x1234"}, "latest")
+	require.Nil(t, err)
+	require.Equal(t, "0x01", output)
+	require.Equal(t, []byte{0x12, 0x34}, backend.calls[0].Data)
+	require.Equal(t, big.NewInt(1), backend.calls[0].Value)
+
+	// bloXroute bundles are mined right away
+	res, err := rpc.BloxrouteSubmitBundle("", BloxrouteSubmitBundleRequest{Transaction: []string{"02f8", "02f9"}})
+	require.Nil(t, err)
+	require.NotEmpty(t, res.BundleHash)
+	number, err = rpc.EthBlockNumber()
+	require.Nil(t, err)
+	require.Equal(t, 1, number)
+
+	receipt, err := rpc.EthGetTransactionReceipt(common.Hash{}.Hex())
+	require.Nil(t, err)
+	require.Equal(t, 1, receipt.BlockNumber)
+	require.Equal(t, 21000, receipt.GasUsed)
+
+	block, err := rpc.EthGetBlockByNumber(1, false)
+	require.Nil(t, err)
+	require.Equal(t, 1, block.Number)
+	require.Equal(t, *big.NewInt(GWei), block.BaseFeePerGas)
+
+	_, err = rpc.Call("debug_traceTransaction", "0x1")
+	require.Equal(t, -32601, err.(RpcError).Code)
+}
@@ -0,0 +1,137 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reverted reports whether the transaction failed in simulation
+func (r BloxrouteSimulateBundleResult) Reverted() bool {
+	return r.Error != ""
+}
+
+// FillRevertingHashes simulates the bundle on its target block and adds the hashes of transactions that reverted and
+// are accepted by allowRevert to params.RevertingHashes. Reverting transactions not accepted are left out, so a relay
+// will still reject the bundle; inspect the returned simulation to find them.
+func (rpc *FlashXRoute) FillRevertingHashes(authHeader string, params *BloxrouteSubmitBundleRequest, allowRevert func(result BloxrouteSimulateBundleResult) bool) (BloxrouteSimulateBundleResponse, error) {
+	simulation := BloxrouteSimulateBundleRequest{
+		Transaction: params.Transaction,
+		BlockNumber: params.BlockNumber,
+	}
+	if params.MinTimestamp != nil {
+		simulation.Timestamp = int64(*params.MinTimestamp)
+	}
+
+	res, err := rpc.BloxrouteSimulateBundle(authHeader, simulation)
+	if err != nil {
+		return res, err
+	}
+
+	revertingHashes := []string{}
+	if params.RevertingHashes != nil {
+		revertingHashes = append(revertingHashes, *params.RevertingHashes...)
+	}
+	for _, result := range res.Results {
+		if result.Reverted() && allowRevert(result) && !containsString(revertingHashes, result.TxHash) {
+			revertingHashes = append(revertingHashes, result.TxHash)
+		}
+	}
+	if len(revertingHashes) > 0 {
+		params.RevertingHashes = &revertingHashes
+	}
+
+	return res, nil
+}
+
+// SimulationErrorKind - category of a transaction failure in bundle simulation
+type SimulationErrorKind int
+
+// Simulation error categories
+const (
+	SimulationErrorUnknown SimulationErrorKind = iota
+	SimulationErrorReverted
+	SimulationErrorOutOfGas
+	SimulationErrorInvalidNonce
+	SimulationErrorInsufficientFunds
+	SimulationErrorIntrinsicGas
+)
+
+func (kind SimulationErrorKind) String() string {
+	switch kind {
+	case SimulationErrorReverted:
+		return "reverted"
+	case SimulationErrorOutOfGas:
+		return "out of gas"
+	case SimulationErrorInvalidNonce:
+		return "invalid nonce"
+	case SimulationErrorInsufficientFunds:
+		return "insufficient funds"
+	case SimulationErrorIntrinsicGas:
+		return "intrinsic gas too low"
+	}
+
+	return "unknown"
+}
+
+// SimulationError - classified failure of a transaction in bundle simulation
+type SimulationError struct {
+	Kind    SimulationErrorKind
+	TxHash  string
+	Message string // error reported by the simulator
+	Reason  string // decoded revert reason, empty when the revert data couldn't be decoded
+	Data    []byte // raw revert data
+}
+
+func (err *SimulationError) Error() string {
+	if err.Reason != "" {
+		return fmt.Sprintf("tx %s %s: %s", err.TxHash, err.Kind, err.Reason)
+	}
+
+	return fmt.Sprintf("tx %s %s: %s", err.TxHash, err.Kind, err.Message)
+}
+
+// Classify returns the categorized failure of the transaction, nil if it succeeded
+func (r BloxrouteSimulateBundleResult) Classify() *SimulationError {
+	if !r.Reverted() {
+		return nil
+	}
+
+	return ClassifySimulationError(r.TxHash, r.Error, r.Value)
+}
+
+// ClassifySimulationError categorizes a simulator error message, value is the hex encoded revert data if any
+func ClassifySimulationError(txHash, message, value string) *SimulationError {
+	err := &SimulationError{TxHash: txHash, Message: message}
+	if data, decodeErr := ParseBytes(value); decodeErr == nil && len(data) > 0 {
+		err.Data = data
+		err.Reason, _ = DecodeRevertReason(data)
+	}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "out of gas"):
+		err.Kind = SimulationErrorOutOfGas
+	case strings.Contains(lower, "nonce too"), strings.Contains(lower, "invalid nonce"):
+		err.Kind = SimulationErrorInvalidNonce
+	case strings.Contains(lower, "insufficient funds"), strings.Contains(lower, "insufficient balance"):
+		err.Kind = SimulationErrorInsufficientFunds
+	case strings.Contains(lower, "intrinsic gas"):
+		err.Kind = SimulationErrorIntrinsicGas
+	case strings.Contains(lower, "revert"), len(err.Data) > 0:
+		err.Kind = SimulationErrorReverted
+	}
+
+	return err
+}
+
+// Errors returns classified failures of all transactions which failed in the simulation
+func (res BloxrouteSimulateBundleResponse) Errors() []*SimulationError {
+	errs := []*SimulationError{}
+	for _, result := range res.Results {
+		if err := result.Classify(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,134 @@
+package flashxroute
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Error("Insufficient output amount")
+const revertInsufficientOutput = "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001a496e73756666696369656e74206f757470757420616d6f756e74000000000000"
+
+func TestDecodeRevertReason(t *testing.T) {
+	data, _ := ParseBytes(revertInsufficientOutput)
+	reason, ok := DecodeRevertReason(data)
+	require.True(t, ok)
+	require.Equal(t, "Insufficient output amount", reason)
+
+	data, _ = ParseBytes("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	reason, ok = DecodeRevertReason(data)
+	require.True(t, ok)
+	require.Equal(t, "panic: arithmetic overflow or underflow (0x11)", reason)
+
+	for _, value := range []string{"0x", "0x08c379a0", "0x08c379a000000000000000000000000000000000000000000000000000000000000000ff", "0xdeadbeef"} {
+		data, _ = ParseBytes(value)
+		_, ok = DecodeRevertReason(data)
+		require.False(t, ok, value)
+	}
+}
+
+func TestClassifySimulationError(t *testing.T) {
+	result := BloxrouteSimulateBundleResult{TxHash: "0x1", Error: "execution reverted", Value: revertInsufficientOutput}
+	err := result.Classify()
+	require.Equal(t, SimulationErrorReverted, err.Kind)
+	require.Equal(t, "Insufficient output amount", err.Reason)
+	require.Equal(t, "tx 0x1 reverted: Insufficient output amount", err.Error())
+
+	require.Nil(t, BloxrouteSimulateBundleResult{TxHash: "0x1", Value: "0x"}.Classify())
+	require.Equal(t, SimulationErrorOutOfGas, ClassifySimulationError("0x2", "out of gas", "0x").Kind)
+	require.Equal(t, SimulationErrorInvalidNonce, ClassifySimulationError("0x2", "nonce too low: address 0x1, tx: 1 state: 2", "").Kind)
+	require.Equal(t, SimulationErrorInsufficientFunds, ClassifySimulationError("0x2", "insufficient funds for gas * price + value", "").Kind)
+	require.Equal(t, SimulationErrorUnknown, ClassifySimulationError("0x2", "something else", "").Kind)
+
+	response := BloxrouteSimulateBundleResponse{Results: []BloxrouteSimulateBundleResult{
+		{TxHash: "0x1"},
+		{TxHash: "0x2", Error: "out of gas"},
+	}}
+	errs := response.Errors()
+	require.Len(t, errs, 1)
+	require.Equal(t, "0x2", errs[0].TxHash)
+}
+
+func (s *FlashXRouteTestSuite) TestBloxrouteSimulateRawBlock() {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = s.getBody(r)
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb", "results": []}}`))
+	}))
+	defer server.Close()
+
+	rpc := s.rpc.With(WithURL(server.URL))
+	res, err := rpc.BloxrouteSimulateRawBlock("auth", 16, []string{"0x01", "0x02", "0x03"}, SimulateBlockOptions{MaxTx: 2, StateBlock: "latest", Timestamp: 1700000000})
+	s.Require().Nil(err)
+	s.Require().Equal("0xb", res.BundleHash)
+	s.methodEqual(body, "blxr_simulate_bundle")
+	s.paramsEqual(body, `{"transaction": ["01", "02"], "block_number": "0x10", "state_block_number": "latest", "timestamp": 1700000000}`)
+}
+
+func (s *FlashXRouteTestSuite) TestFillRevertingHashes() {
+	var body []byte
+	response := `{"jsonrpc":"2.0", "id":1, "result": {"bundleHash": "0xb", "results": [
+		{"txHash": "0x1"},
+		{"txHash": "0x2", "error": "execution reverted", "value": "` + revertInsufficientOutput + `"},
+		{"txHash": "0x3", "error": "out of gas"},
+		{"txHash": "0x4", "error": "nonce too low"},
+		{"txHash": "0x5", "error": "insufficient funds for gas * price + value"},
+		{"txHash": "0x6", "error": "intrinsic gas too low"},
+		{"txHash": "0x7", "error": "invalid opcode"},
+		{"txHash": "0x8", "error": "execution reverted"}
+	]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = s.getBody(r)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+	rpc := s.rpc.With(WithURL(server.URL))
+
+	kinds := map[string]SimulationErrorKind{}
+	allowReverted := func(result BloxrouteSimulateBundleResult) bool {
+		kinds[result.TxHash] = result.Classify().Kind
+		return kinds[result.TxHash] == SimulationErrorReverted
+	}
+
+	timestamp := uint64(1700000000)
+	existing := []string{"0x8", "0x9"}
+	params := BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10", MinTimestamp: &timestamp, RevertingHashes: &existing}
+	res, err := rpc.FillRevertingHashes("auth", &params, allowReverted)
+	s.Require().Nil(err)
+	s.Require().Equal("0xb", res.BundleHash)
+	s.methodEqual(body, "blxr_simulate_bundle")
+	s.paramsEqual(body, `{"transaction": ["01"], "block_number": "0x10", "timestamp": 1700000000}`)
+	s.Require().Equal(map[string]SimulationErrorKind{
+		"0x2": SimulationErrorReverted,
+		"0x3": SimulationErrorOutOfGas,
+		"0x4": SimulationErrorInvalidNonce,
+		"0x5": SimulationErrorInsufficientFunds,
+		"0x6": SimulationErrorIntrinsicGas,
+		"0x7": SimulationErrorUnknown,
+		"0x8": SimulationErrorReverted,
+	}, kinds)
+	s.Require().Equal([]string{"0x8", "0x9", "0x2"}, *params.RevertingHashes)
+	s.Require().Equal([]string{"0x8", "0x9"}, existing)
+
+	// accepting every failure
+	params = BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"}
+	_, err = rpc.FillRevertingHashes("auth", &params, func(BloxrouteSimulateBundleResult) bool { return true })
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"0x2", "0x3", "0x4", "0x5", "0x6", "0x7", "0x8"}, *params.RevertingHashes)
+
+	// nothing reverted
+	response = `{"jsonrpc":"2.0", "id":1, "result": {"results": [{"txHash": "0x1"}]}}`
+	params = BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10"}
+	_, err = rpc.FillRevertingHashes("auth", &params, func(BloxrouteSimulateBundleResult) bool { return true })
+	s.Require().Nil(err)
+	s.Require().Nil(params.RevertingHashes)
+
+	// simulation failure leaves params alone
+	response = `{"jsonrpc":"2.0", "id":1, "error": {"code": -32000, "message": "simulation failed"}}`
+	params = BloxrouteSubmitBundleRequest{Transaction: []string{"01"}, BlockNumber: "0x10", RevertingHashes: &existing}
+	_, err = rpc.FillRevertingHashes("auth", &params, func(BloxrouteSimulateBundleResult) bool { return true })
+	s.Require().NotNil(err)
+	s.Require().Equal([]string{"0x8", "0x9"}, *params.RevertingHashes)
+}
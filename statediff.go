@@ -0,0 +1,88 @@
+package flashxroute
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// State fields compared by StateDiff besides storage slots
+const (
+	StateBalance = "balance"
+	StateNonce   = "nonce"
+	StateCode    = "code"
+)
+
+// StateQuery - account and storage slots compared by StateDiff
+type StateQuery struct {
+	Address string
+	Slots   []string // 0x prefixed storage slot keys
+}
+
+// StateChange - state field of an account differing between two blocks
+type StateChange struct {
+	Address string
+	Field   string // StateBalance, StateNonce, StateCode or the storage slot key
+	From    string // hex value at the first block
+	To      string // hex value at the second block
+}
+
+// StateDiff returns the balances, nonces, code and storage slots of queries which differ between fromBlock and
+// toBlock, e.g. to check that the state a simulation assumed still holds at submission time. All values are read
+// in one batch request.
+func (rpc *FlashXRoute) StateDiff(queries []StateQuery, fromBlock, toBlock string) ([]StateChange, error) {
+	type field struct {
+		address, name string
+	}
+	var fields []field
+	var requests []BatchRequest
+	for _, block := range []string{fromBlock, toBlock} {
+		fields = fields[:0]
+		for _, query := range queries {
+			if err := rpc.checkAddress(query.Address); err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{query.Address, StateBalance}, field{query.Address, StateNonce}, field{query.Address, StateCode})
+			requests = append(requests,
+				BatchRequest{Method: "eth_getBalance", Params: []interface{}{query.Address, block}},
+				BatchRequest{Method: "eth_getTransactionCount", Params: []interface{}{query.Address, block}},
+				BatchRequest{Method: "eth_getCode", Params: []interface{}{query.Address, block}},
+			)
+			for _, slot := range query.Slots {
+				fields = append(fields, field{query.Address, slot})
+				requests = append(requests, BatchRequest{Method: "eth_getStorageAt", Params: []interface{}{query.Address, slot, block}})
+			}
+		}
+	}
+
+	results := rpc.batchOrEach(requests...)
+	values := make([]string, len(results))
+	for i, result := range results {
+		if err := result.Decode(&values[i]); err != nil {
+			return nil, errors.Wrapf(err, "%s %s", result.Method, fields[i%len(fields)].address)
+		}
+	}
+
+	var changes []StateChange
+	for i, f := range fields {
+		from, to := values[i], values[len(fields)+i]
+		if !sameStateValue(f.name, from, to) {
+			changes = append(changes, StateChange{Address: f.address, Field: f.name, From: from, To: to})
+		}
+	}
+
+	return changes, nil
+}
+
+// sameStateValue compares hex values of field ignoring case, and leading zeros of numbers
+func sameStateValue(field, a, b string) bool {
+	trim := func(value string) string {
+		value = strings.ToLower(trimHexPrefix(value))
+		if field == StateCode {
+			return value
+		}
+		return strings.TrimLeft(value, "0")
+	}
+
+	return trim(a) == trim(b)
+}
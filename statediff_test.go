@@ -0,0 +1,30 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestStateDiff() {
+	pool := "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc"
+	searcher := "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf"
+	slot := "0x0000000000000000000000000000000000000000000000000000000000000008"
+	s.registerMethods(map[string]string{
+		`eth_getBalance ["` + pool + `","0x10"]`:                    `"0x0"`,
+		`eth_getBalance ["` + pool + `","latest"]`:                  `"0x0"`,
+		`eth_getTransactionCount ["` + pool + `","0x10"]`:           `"0x1"`,
+		`eth_getTransactionCount ["` + pool + `","latest"]`:         `"0x1"`,
+		`eth_getCode ["` + pool + `","0x10"]`:                       `"0x6080"`,
+		`eth_getCode ["` + pool + `","latest"]`:                     `"0x6080"`,
+		`eth_getStorageAt ["` + pool + `","` + slot + `","0x10"]`:   `"0x00000000000000000000000000000000000000000000000000000000000000aa"`,
+		`eth_getStorageAt ["` + pool + `","` + slot + `","latest"]`: `"0x00000000000000000000000000000000000000000000000000000000000000bb"`,
+		`eth_getBalance ["` + searcher + `","0x10"]`:                `"0x10"`,
+		`eth_getBalance ["` + searcher + `","latest"]`:              `"0x010"`,
+		`eth_getTransactionCount ["` + searcher + `","0x10"]`:       `"0x4"`,
+		`eth_getTransactionCount ["` + searcher + `","latest"]`:     `"0x5"`,
+		`eth_getCode ["` + searcher + `","0x10"]`:                   `"0x"`,
+		`eth_getCode ["` + searcher + `","latest"]`:                 `"0x"`,
+	})
+
+	changes, err := s.rpc.StateDiff([]StateQuery{{Address: pool, Slots: []string{slot}}, {Address: searcher}}, "0x10", "latest")
+	s.Require().Nil(err)
+	s.Require().Equal([]StateChange{
+		{Address: pool, Field: slot, From: "0x00000000000000000000000000000000000000000000000000000000000000aa", To: "0x00000000000000000000000000000000000000000000000000000000000000bb"},
+		{Address: searcher, Field: StateNonce, From: "0x4", To: "0x5"},
+	}, changes)
+}
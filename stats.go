@@ -0,0 +1,137 @@
+package flashxroute
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EndpointStats - latency and errors of the recent calls to an endpoint, see WithStats
+type EndpointStats struct {
+	Endpoint  string // scheme and host of the endpoint, the socket path for ipc
+	Calls     int    // calls in the window
+	Errors    int    // calls failing in the transport or answered with an http error status
+	ErrorRate float64
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+}
+
+// latencySample - outcome of a call
+type latencySample struct {
+	duration time.Duration
+	failed   bool
+}
+
+// latencyWindow - ring of the last samples of an endpoint
+type latencyWindow struct {
+	samples []latencySample
+	next    int
+	full    bool
+}
+
+// latencyStats - rolling windows of calls by endpoint, see WithStats
+type latencyStats struct {
+	size int
+
+	mu        sync.Mutex
+	endpoints map[string]*latencyWindow
+}
+
+func newLatencyStats(size int) *latencyStats {
+	return &latencyStats{size: size, endpoints: map[string]*latencyWindow{}}
+}
+
+func (s *latencyStats) record(endpoint string, duration time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, ok := s.endpoints[endpoint]
+	if !ok {
+		window = &latencyWindow{samples: make([]latencySample, s.size)}
+		s.endpoints[endpoint] = window
+	}
+	window.samples[window.next] = latencySample{duration: duration, failed: failed}
+	if window.next++; window.next == len(window.samples) {
+		window.next, window.full = 0, true
+	}
+}
+
+// snapshot returns the stats of every endpoint sorted by endpoint
+func (s *latencyStats) snapshot() []EndpointStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]EndpointStats, 0, len(s.endpoints))
+	for endpoint, window := range s.endpoints {
+		samples := window.samples[:window.next]
+		if window.full {
+			samples = window.samples
+		}
+		durations := make([]time.Duration, len(samples))
+		stat := EndpointStats{Endpoint: endpoint, Calls: len(samples)}
+		for i, sample := range samples {
+			durations[i] = sample.duration
+			if sample.failed {
+				stat.Errors++
+			}
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		stat.ErrorRate = float64(stat.Errors) / float64(stat.Calls)
+		stat.P50, stat.P95, stat.P99 = percentile(durations, 50), percentile(durations, 95), percentile(durations, 99)
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Endpoint < stats[j].Endpoint })
+
+	return stats
+}
+
+// percentile returns the nearest rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+
+	return sorted[rank-1]
+}
+
+// recordStats records the call to the endpoint of req, or to the ipc socket when req is nil
+func (rpc *FlashXRoute) recordStats(req *http.Request, status int, duration time.Duration, err error) {
+	if rpc.stats == nil {
+		return
+	}
+	endpoint := rpc.url
+	if req != nil {
+		endpoint = req.URL.Scheme + "://" + req.URL.Host
+	}
+	rpc.stats.record(endpoint, duration, err != nil || status >= 400)
+}
+
+// GetStats returns the latency percentiles and error rates of the recent calls by endpoint, including the hedging
+// and archive endpoints, none without WithStats
+func (rpc *FlashXRoute) GetStats() []EndpointStats {
+	if rpc.stats == nil {
+		return nil
+	}
+
+	return rpc.stats.snapshot()
+}
+
+// RelayStats returns the stats of the endpoints of clients, e.g. of a Config.RelaySet, clients sharing their stats
+// are reported once
+func RelayStats(clients []*FlashXRoute) []EndpointStats {
+	seen := map[*latencyStats]bool{}
+	var stats []EndpointStats
+	for _, client := range clients {
+		if client.stats == nil || seen[client.stats] {
+			continue
+		}
+		seen[client.stats] = true
+		stats = append(stats, client.stats.snapshot()...)
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].Endpoint < stats[j].Endpoint })
+
+	return stats
+}
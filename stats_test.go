@@ -0,0 +1,61 @@
+package flashxroute
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	durations := make([]time.Duration, 200)
+	for i := range durations {
+		durations[i] = time.Duration(i+1) * time.Millisecond
+	}
+	require.Equal(t, 100*time.Millisecond, percentile(durations, 50))
+	require.Equal(t, 190*time.Millisecond, percentile(durations, 95))
+	require.Equal(t, 198*time.Millisecond, percentile(durations, 99))
+	require.Equal(t, 7*time.Millisecond, percentile(durations[6:7], 99))
+	require.Zero(t, percentile(nil, 50))
+}
+
+func (s *FlashXRouteTestSuite) TestGetStats() {
+	up := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0", "id":1, "result": "0x1"}`))
+	})
+	defer up.Close()
+	down := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer down.Close()
+
+	rpc := New(up.URL, WithHttpClient(http.DefaultClient), WithStats(3))
+	s.Require().Nil(New(up.URL).GetStats())
+	for i := 0; i < 5; i++ {
+		_, err := rpc.EthBlockNumber()
+		s.Require().Nil(err)
+	}
+	failing := rpc.With(WithURL(down.URL + "/v3/secret"))
+	_, err := failing.EthBlockNumber()
+	s.Require().NotNil(err)
+
+	stats := rpc.GetStats()
+	s.Require().Len(stats, 2)
+	for _, stat := range stats {
+		s.Require().False(strings.Contains(stat.Endpoint, "secret"))
+		if stat.Endpoint == up.URL {
+			s.Require().Equal(3, stat.Calls)
+			s.Require().Zero(stat.ErrorRate)
+			s.Require().True(stat.P50 > 0 && stat.P50 <= stat.P95 && stat.P95 <= stat.P99)
+			continue
+		}
+		s.Require().Equal(down.URL, stat.Endpoint)
+		s.Require().Equal(EndpointStats{Endpoint: down.URL, Calls: 1, Errors: 1, ErrorRate: 1, P50: stat.P50, P95: stat.P50, P99: stat.P50}, stat)
+	}
+
+	other := New(down.URL, WithHttpClient(http.DefaultClient), WithStats(0))
+	other.EthBlockNumber()
+	s.Require().Len(RelayStats([]*FlashXRoute{rpc, failing, other, New(up.URL)}), 3)
+}
@@ -0,0 +1,85 @@
+package flashxroute
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidSlot is returned for storage slots and keys longer than 32 bytes
+var ErrInvalidSlot = errors.New("invalid storage slot")
+
+// padSlot parses hex value and left pads it to 32 bytes
+func padSlot(value string) ([]byte, error) {
+	digits := trimHexPrefix(value)
+	if len(digits)%2 != 0 {
+		digits = "0" + digits
+	}
+	data, err := ParseBytes(digits)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 32 {
+		return nil, errors.Wrapf(ErrInvalidSlot, "%d bytes", len(data))
+	}
+
+	return append(make([]byte, 32-len(data)), data...), nil
+}
+
+// StorageSlot returns the 32-byte key of the storage slot of a state variable declared at position
+func StorageSlot(position int) string {
+	key, _ := padSlot(IntToHex(position))
+	return BytesToHex(key)
+}
+
+// MappingSlot returns the storage slot of mapping[key] for a mapping declared at slot, key is a value type like an
+// address, uint or bytes32 in hex: keccak256(pad32(key) . pad32(slot)). Nested mappings chain the calls.
+func MappingSlot(key, slot string) (string, error) {
+	paddedKey, err := padSlot(key)
+	if err != nil {
+		return "", errors.Wrap(err, "key")
+	}
+	paddedSlot, err := padSlot(slot)
+	if err != nil {
+		return "", err
+	}
+
+	return Keccak256(paddedKey, paddedSlot), nil
+}
+
+// MappingSlotBytes returns the storage slot of mapping[key] for a string or bytes keyed mapping declared at slot:
+// keccak256(key . pad32(slot))
+func MappingSlotBytes(key []byte, slot string) (string, error) {
+	paddedSlot, err := padSlot(slot)
+	if err != nil {
+		return "", err
+	}
+
+	return Keccak256(key, paddedSlot), nil
+}
+
+// ArraySlot returns the storage slot of array[index] for a dynamic array declared at slot with elements spanning
+// elementSlots slots each: keccak256(pad32(slot)) + index * elementSlots. Fields of struct elements are at the
+// following slots, see SlotOffset.
+func ArraySlot(slot string, index, elementSlots int) (string, error) {
+	paddedSlot, err := padSlot(slot)
+	if err != nil {
+		return "", err
+	}
+
+	return SlotOffset(Keccak256(paddedSlot), index*elementSlots)
+}
+
+// SlotOffset returns the slot offset slots after slot, e.g. a field of a struct stored at slot
+func SlotOffset(slot string, offset int) (string, error) {
+	paddedSlot, err := padSlot(slot)
+	if err != nil {
+		return "", err
+	}
+
+	key := new(big.Int).SetBytes(paddedSlot)
+	key.Add(key, big.NewInt(int64(offset)))
+	key.Mod(key, new(big.Int).Lsh(big.NewInt(1), 256))
+
+	return BytesToHex(key.FillBytes(make([]byte, 32))), nil
+}
@@ -0,0 +1,48 @@
+package flashxroute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageSlots(t *testing.T) {
+	require.Equal(t, "0x"+strings.Repeat("0", 63)+"3", StorageSlot(3))
+
+	slot, err := ArraySlot("0x0", 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563", slot)
+	slot, err = ArraySlot("0", 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, "0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e567", slot)
+	slot, err = ArraySlot("0x1", 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6", slot)
+
+	slot, err = SlotOffset("0x"+strings.Repeat("f", 64), 1)
+	require.NoError(t, err)
+	require.Equal(t, StorageSlot(0), slot)
+
+	holder := "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf"
+	slot, err = MappingSlot(holder, "0x3")
+	require.NoError(t, err)
+	require.Equal(t, Keccak256(mustParseBytes("0x000000000000000000000000"+holder[2:]), mustParseBytes(StorageSlot(3))), slot)
+	slot, err = MappingSlotBytes([]byte("key"), "0x3")
+	require.NoError(t, err)
+	require.Equal(t, Keccak256([]byte("key"), mustParseBytes(StorageSlot(3))), slot)
+
+	_, err = MappingSlot("0x"+strings.Repeat("00", 33), "0x3")
+	require.ErrorIs(t, err, ErrInvalidSlot)
+}
+
+func (s *FlashXRouteTestSuite) TestEthGetStorageAtSlot() {
+	address := "0xd10e3be2bc8f959bc8c41cf65f60de721cf89adf"
+	s.registerMethods(map[string]string{
+		`eth_getStorageAt ["` + address + `","` + StorageSlot(8) + `","latest"]`: `"0x01"`,
+	})
+
+	value, err := s.rpc.EthGetStorageAtSlot(address, "0x8", "latest")
+	s.Require().Nil(err)
+	s.Require().Equal("0x01", value)
+}
@@ -0,0 +1,424 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// ErrStreamClosed is returned when subscribing on a closed Stream
+var ErrStreamClosed = errors.New("stream closed")
+
+// StreamEvent - notification of a Stream subscription, or a gap marker when the connection was lost and
+// re-established, notifications sent in between may have been missed
+type StreamEvent struct {
+	Data json.RawMessage // notification result, nil for gap markers
+	Gap  bool
+	Err  error // why the connection was lost, set for gap markers
+}
+
+// BufferPolicy - what a subscription does with a notification when its consumer is behind and the buffer is full
+type BufferPolicy int
+
+// Buffer policies
+const (
+	BufferBlock      BufferPolicy = iota // wait for the consumer, a slow consumer stalls its subscription (default)
+	BufferDropOldest                     // evict the oldest buffered notification
+	BufferDropNewest                     // discard the incoming notification
+	BufferCoalesce                       // keep only the latest notification, e.g. for newHeads
+)
+
+// SubscriptionOptions - delivery parameters of a Stream subscription
+type SubscriptionOptions struct {
+	Policy BufferPolicy
+	Buffer int                     // events buffered (default: StreamOptions.Buffer, 1 for BufferCoalesce)
+	OnDrop func(event StreamEvent) // called with every notification discarded by the policy
+}
+
+// StreamOptions - parameters of DialStream
+type StreamOptions struct {
+	MinBackoff  time.Duration                // first reconnection delay, doubled after every failed attempt (default: 500ms)
+	MaxBackoff  time.Duration                // maximum reconnection delay (default: 30s)
+	Buffer      int                          // default events buffered per subscription (default: 64)
+	OnReconnect func(attempt int, err error) // called before every reconnection attempt with the error that caused it
+	Logger      logger                       // debug log of the stream, nil logs nothing, see FlashXRoute.DialStream
+	LogLevel    LogLevel                     // verbosity of the debug log, LogBodies logs every notification
+}
+
+// streamConn - websocket connection of a Stream, an interface for tests
+type streamConn interface {
+	subscribe(ctx context.Context, namespace string, channel chan json.RawMessage, args []interface{}) (streamSubscription, error)
+	Close()
+}
+
+// gethStreamConn - streamConn of a go-ethereum rpc client
+type gethStreamConn struct {
+	*rpc.Client
+}
+
+func (c gethStreamConn) subscribe(ctx context.Context, namespace string, channel chan json.RawMessage, args []interface{}) (streamSubscription, error) {
+	return c.Subscribe(ctx, namespace, channel, args...)
+}
+
+// streamSubscription - upstream subscription on a streamConn
+type streamSubscription interface {
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// Stream - websocket connection to a node or bloXroute gateway keeping its subscriptions alive, the connection is
+// re-established with exponential backoff when it drops and every active subscription is replayed on the new
+// connection, its consumer receives a gap marker first
+type Stream struct {
+	url     string
+	options StreamOptions
+	dial    func(ctx context.Context, url string) (streamConn, error)
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	dropped chan streamDrop
+
+	mu         sync.Mutex
+	conn       streamConn
+	generation int
+	subs       map[*StreamSubscription]struct{}
+}
+
+// streamDrop - connection of generation reported lost by one of its subscriptions
+type streamDrop struct {
+	generation int
+	err        error
+}
+
+// StreamSubscription - subscription of a Stream surviving reconnections
+type StreamSubscription struct {
+	Events <-chan StreamEvent // notifications and gap markers, not closed, select on Done too
+
+	stream    *Stream
+	namespace string
+	args      []interface{}
+	options   SubscriptionOptions
+	events    chan StreamEvent
+	dropped   uint64
+	done      chan struct{}
+	once      sync.Once
+}
+
+// DialStream connects to the websocket endpoint url, e.g. wss://mainnet.infura.io/ws/v3/KEY, Close releases the
+// connection and stops reconnecting
+func DialStream(ctx context.Context, url string, options StreamOptions) (*Stream, error) {
+	return newStream(ctx, url, options, func(ctx context.Context, url string) (streamConn, error) {
+		client, err := rpc.DialContext(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		return gethStreamConn{client}, nil
+	})
+}
+
+// DialStream is like the DialStream function, unless options has a Logger the stream logs to the client's logger
+// at the level of SubsystemStreams
+func (rpc *FlashXRoute) DialStream(ctx context.Context, url string, options StreamOptions) (*Stream, error) {
+	return DialStream(ctx, url, rpc.streamOptions(options))
+}
+
+// streamOptions returns options logging with the client's logger when they have none
+func (rpc *FlashXRoute) streamOptions(options StreamOptions) StreamOptions {
+	if options.Logger == nil {
+		options.Logger, options.LogLevel = rpc.log, rpc.logLevel(SubsystemStreams)
+	}
+
+	return options
+}
+
+func newStream(ctx context.Context, url string, options StreamOptions, dial func(ctx context.Context, url string) (streamConn, error)) (*Stream, error) {
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = 500 * time.Millisecond
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = 30 * time.Second
+	}
+	if options.MaxBackoff < options.MinBackoff {
+		options.MaxBackoff = options.MinBackoff
+	}
+	if options.Buffer <= 0 {
+		options.Buffer = 64
+	}
+
+	conn, err := dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stream{
+		url:     url,
+		options: options,
+		dial:    dial,
+		dropped: make(chan streamDrop, 1),
+		conn:    conn,
+		subs:    map[*StreamSubscription]struct{}{},
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.run()
+
+	return s, nil
+}
+
+// Subscribe subscribes with <namespace>_subscribe, e.g. Subscribe(ctx, "eth", "newHeads") or
+// Subscribe(ctx, "eth", "logs", filter), the subscription is replayed with the same arguments after reconnections.
+// Notifications wait for the consumer, use SubscribeWithOptions for other buffer policies.
+func (s *Stream) Subscribe(ctx context.Context, namespace string, args ...interface{}) (*StreamSubscription, error) {
+	return s.SubscribeWithOptions(ctx, SubscriptionOptions{}, namespace, args...)
+}
+
+// SubscribeWithOptions subscribes like Subscribe delivering notifications according to options, so a slow consumer
+// of a firehose like newTxs neither stalls the connection nor buffers without bound. Gap markers are never dropped.
+func (s *Stream) SubscribeWithOptions(ctx context.Context, options SubscriptionOptions, namespace string, args ...interface{}) (*StreamSubscription, error) {
+	switch {
+	case options.Policy == BufferCoalesce:
+		options.Buffer = 1
+	case options.Buffer <= 0:
+		options.Buffer = s.options.Buffer
+	}
+	sub := &StreamSubscription{
+		stream:    s,
+		namespace: namespace,
+		args:      args,
+		options:   options,
+		events:    make(chan StreamEvent, options.Buffer),
+		done:      make(chan struct{}),
+	}
+	sub.Events = sub.events
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return nil, ErrStreamClosed
+	}
+	if err := s.start(ctx, sub, nil); err != nil {
+		s.debugf(LogErrors, "%s_subscribe %v failed: %s", namespace, args, err)
+		return nil, err
+	}
+	s.subs[sub] = struct{}{}
+	s.debugf(LogRequests, "%s_subscribe %v", namespace, args)
+
+	return sub, nil
+}
+
+// start subscribes sub on the current connection and forwards its notifications, gap is sent first when set.
+// Called with s.mu held.
+func (s *Stream) start(ctx context.Context, sub *StreamSubscription, gap error) error {
+	channel := make(chan json.RawMessage, s.options.Buffer)
+	upstream, err := s.conn.subscribe(ctx, sub.namespace, channel, sub.args)
+	if err != nil {
+		return err
+	}
+
+	go s.forward(sub, channel, upstream, s.generation, gap)
+	return nil
+}
+
+// forward delivers the notifications of upstream to sub until it fails, which reports the connection of generation
+// lost, or sub is unsubscribed
+func (s *Stream) forward(sub *StreamSubscription, channel chan json.RawMessage, upstream streamSubscription, generation int, gap error) {
+	if gap != nil && !sub.send(StreamEvent{Gap: true, Err: gap}) {
+		upstream.Unsubscribe()
+		return
+	}
+
+	for {
+		select {
+		case data := <-channel:
+			s.debugf(LogBodies, "%s notification: %s", sub.namespace, data)
+			if !sub.send(StreamEvent{Data: data}) {
+				upstream.Unsubscribe()
+				return
+			}
+		case err := <-upstream.Err():
+			if err != nil {
+				select {
+				case s.dropped <- streamDrop{generation: generation, err: err}:
+				default:
+				}
+			}
+			return
+		case <-sub.done:
+			upstream.Unsubscribe()
+			return
+		}
+	}
+}
+
+// debugf logs the message to the stream logger when the stream is at least at level
+func (s *Stream) debugf(level LogLevel, format string, args ...interface{}) {
+	if s.options.Logger != nil && s.options.LogLevel >= level {
+		s.options.Logger.Println(fmt.Sprintf(format, args...))
+	}
+}
+
+// run reconnects every time a subscription reports the current connection lost
+func (s *Stream) run() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case drop := <-s.dropped:
+			s.mu.Lock()
+			stale := drop.generation != s.generation
+			s.mu.Unlock()
+			if !stale {
+				s.reconnect(drop.err)
+			}
+		}
+	}
+}
+
+// reconnect dials until a connection replaying all subscriptions succeeds or the stream is closed, dropped is the
+// error that lost the connection
+func (s *Stream) reconnect(dropped error) {
+	backoff, cause := s.options.MinBackoff, dropped
+	for attempt := 1; ; attempt++ {
+		s.debugf(LogErrors, "stream %s lost, reconnection attempt %d: %s", s.url, attempt, cause)
+		if s.options.OnReconnect != nil {
+			s.options.OnReconnect(attempt, cause)
+		}
+
+		conn, err := s.dial(s.ctx, s.url)
+		if err == nil {
+			if err = s.replay(conn, dropped); err == nil {
+				return
+			}
+		}
+		cause = err
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > s.options.MaxBackoff {
+			backoff = s.options.MaxBackoff
+		}
+	}
+}
+
+// replay switches to conn and subscribes the active subscriptions on it, conn is closed when one fails
+func (s *Stream) replay(conn streamConn, dropped error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		conn.Close()
+		return s.ctx.Err()
+	}
+
+	s.conn.Close()
+	s.conn = conn
+	s.generation++
+	for sub := range s.subs {
+		if err := s.start(s.ctx, sub, dropped); err != nil {
+			// subscriptions started on conn report the drop of this generation, the next attempt replays them all
+			s.generation++
+			conn.Close()
+			return errors.Wrapf(err, "resubscribe %s %v", sub.namespace, sub.args)
+		}
+	}
+
+	return nil
+}
+
+// Close unsubscribes all subscriptions and closes the connection
+func (s *Stream) Close() error {
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		sub.close()
+	}
+	s.subs = map[*StreamSubscription]struct{}{}
+	s.conn.Close()
+
+	return nil
+}
+
+// send delivers event according to the buffer policy, false when the subscription or stream is closed
+func (sub *StreamSubscription) send(event StreamEvent) bool {
+	if event.Gap || sub.options.Policy == BufferBlock {
+		select {
+		case sub.events <- event:
+			return true
+		case <-sub.done:
+			return false
+		case <-sub.stream.ctx.Done():
+			return false
+		}
+	}
+
+	select {
+	case <-sub.done:
+		return false
+	case <-sub.stream.ctx.Done():
+		return false
+	default:
+	}
+	for {
+		select {
+		case sub.events <- event:
+			return true
+		default:
+		}
+		if sub.options.Policy == BufferDropNewest {
+			sub.drop(event)
+			return true
+		}
+		select {
+		case oldest := <-sub.events:
+			if oldest.Gap {
+				// keep the consumer aware of the gap, the incoming notification is discarded instead
+				sub.events <- oldest
+				sub.drop(event)
+				return true
+			}
+			sub.drop(oldest)
+		default:
+		}
+	}
+}
+
+// drop accounts a notification discarded by the buffer policy
+func (sub *StreamSubscription) drop(event StreamEvent) {
+	atomic.AddUint64(&sub.dropped, 1)
+	if sub.options.OnDrop != nil {
+		sub.options.OnDrop(event)
+	}
+}
+
+// Dropped returns the number of notifications discarded by the buffer policy
+func (sub *StreamSubscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+func (sub *StreamSubscription) close() {
+	sub.once.Do(func() { close(sub.done) })
+}
+
+// Done returns a channel closed once the subscription is unsubscribed or its stream closed
+func (sub *StreamSubscription) Done() <-chan struct{} {
+	return sub.done
+}
+
+// Unsubscribe stops the subscription, it isn't replayed anymore
+func (sub *StreamSubscription) Unsubscribe() {
+	sub.stream.mu.Lock()
+	delete(sub.stream.subs, sub)
+	sub.stream.mu.Unlock()
+	sub.close()
+}
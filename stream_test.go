@@ -0,0 +1,155 @@
+package flashxroute
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpstream struct {
+	args    []interface{}
+	channel chan json.RawMessage
+	err     chan error
+}
+
+func (u *fakeUpstream) Err() <-chan error { return u.err }
+func (u *fakeUpstream) Unsubscribe()      {}
+
+type fakeStreamConn struct {
+	mu        sync.Mutex
+	upstreams []*fakeUpstream
+}
+
+func (c *fakeStreamConn) subscribe(ctx context.Context, namespace string, channel chan json.RawMessage, args []interface{}) (streamSubscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	upstream := &fakeUpstream{args: args, channel: channel, err: make(chan error, 1)}
+	c.upstreams = append(c.upstreams, upstream)
+	return upstream, nil
+}
+
+// drop fails the subscriptions of the connection as a lost websocket does
+func (c *fakeStreamConn) drop(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, upstream := range c.upstreams {
+		upstream.err <- err
+	}
+}
+
+func (c *fakeStreamConn) Close() {}
+
+func TestStreamReconnect(t *testing.T) {
+	var mu sync.Mutex
+	var conns []*fakeStreamConn
+	dials := 0
+	dial := func(ctx context.Context, url string) (streamConn, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dials++
+		if dials == 2 {
+			return nil, errors.New("refused")
+		}
+		conn := new(fakeStreamConn)
+		conns = append(conns, conn)
+		return conn, nil
+	}
+	var attempts []int
+	stream, err := newStream(context.Background(), "ws://node", StreamOptions{
+		MinBackoff:  time.Millisecond,
+		OnReconnect: func(attempt int, err error) { attempts = append(attempts, attempt) },
+	}, dial)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	heads, err := stream.Subscribe(context.Background(), "eth", "newHeads")
+	require.NoError(t, err)
+	logs, err := stream.Subscribe(context.Background(), "eth", "logs", map[string]string{"address": "0x1"})
+	require.NoError(t, err)
+
+	conns[0].upstreams[0].channel <- json.RawMessage(`"0x1"`)
+	require.Equal(t, StreamEvent{Data: json.RawMessage(`"0x1"`)}, <-heads.Events)
+
+	dropped := errors.New("connection reset")
+	conns[0].drop(dropped)
+	for _, sub := range []*StreamSubscription{heads, logs} {
+		select {
+		case event := <-sub.Events:
+			require.True(t, event.Gap)
+			require.Equal(t, dropped, event.Err)
+		case <-time.After(time.Second):
+			t.Fatal("no gap marker")
+		}
+	}
+	require.Equal(t, []int{1, 2}, attempts)
+
+	mu.Lock()
+	replayed := conns[1]
+	mu.Unlock()
+	replayed.mu.Lock()
+	require.Len(t, replayed.upstreams, 2)
+	replayed.mu.Unlock()
+	for _, upstream := range replayed.upstreams {
+		if len(upstream.args) == 2 {
+			upstream.channel <- json.RawMessage(`{"logIndex":"0x0"}`)
+		}
+	}
+	require.Equal(t, StreamEvent{Data: json.RawMessage(`{"logIndex":"0x0"}`)}, <-logs.Events)
+
+	heads.Unsubscribe()
+	<-heads.Done()
+	require.NoError(t, stream.Close())
+	<-logs.Done()
+	_, err = stream.Subscribe(context.Background(), "eth", "newHeads")
+	require.ErrorIs(t, err, ErrStreamClosed)
+}
+
+func TestStreamBufferPolicy(t *testing.T) {
+	stream, err := newStream(context.Background(), "ws://node", StreamOptions{}, func(ctx context.Context, url string) (streamConn, error) {
+		return new(fakeStreamConn), nil
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	event := func(i int) StreamEvent { return StreamEvent{Data: json.RawMessage(IntToHex(i))} }
+	received := func(sub *StreamSubscription) (events []StreamEvent) {
+		for len(sub.Events) > 0 {
+			events = append(events, <-sub.Events)
+		}
+		return events
+	}
+
+	var discarded []StreamEvent
+	newest, err := stream.SubscribeWithOptions(context.Background(), SubscriptionOptions{
+		Policy: BufferDropNewest, Buffer: 2, OnDrop: func(event StreamEvent) { discarded = append(discarded, event) },
+	}, "eth", "newPendingTransactions")
+	require.NoError(t, err)
+	oldest, err := stream.SubscribeWithOptions(context.Background(), SubscriptionOptions{Policy: BufferDropOldest, Buffer: 2}, "eth", "newPendingTransactions")
+	require.NoError(t, err)
+	coalesce, err := stream.SubscribeWithOptions(context.Background(), SubscriptionOptions{Policy: BufferCoalesce, Buffer: 5}, "eth", "newHeads")
+	require.NoError(t, err)
+	for i := 1; i <= 3; i++ {
+		for _, sub := range []*StreamSubscription{newest, oldest, coalesce} {
+			require.True(t, sub.send(event(i)))
+		}
+	}
+
+	require.Equal(t, []StreamEvent{event(1), event(2)}, received(newest))
+	require.Equal(t, []StreamEvent{event(3)}, discarded)
+	require.Equal(t, []StreamEvent{event(2), event(3)}, received(oldest))
+	require.Equal(t, []StreamEvent{event(3)}, received(coalesce))
+	require.Equal(t, uint64(2), coalesce.Dropped())
+
+	gap := StreamEvent{Gap: true, Err: errors.New("reset")}
+	require.True(t, coalesce.send(gap))
+	require.True(t, coalesce.send(event(4)))
+	require.Equal(t, []StreamEvent{gap}, received(coalesce))
+
+	coalesce.Unsubscribe()
+	require.False(t, coalesce.send(event(5)))
+}
@@ -0,0 +1,106 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// strictProxy - type decoded through a proxy, strict decoding checks the quantities of the proxy
+type strictProxy interface {
+	strictProxy() interface{}
+}
+
+var (
+	strictProxyType = reflect.TypeOf((*strictProxy)(nil)).Elem()
+	quantityTypes   = map[reflect.Type]bool{reflect.TypeOf(hexInt(0)): true, reflect.TypeOf(hexBig{}): true}
+)
+
+func (s *Syncing) strictProxy() interface{}            { return new(proxySyncing) }
+func (t *Transaction) strictProxy() interface{}        { return new(proxyTransaction) }
+func (log *Log) strictProxy() interface{}              { return new(proxyLog) }
+func (t *TransactionReceipt) strictProxy() interface{} { return new(proxyTransactionReceipt) }
+func (h *FeeHistory) strictProxy() interface{}         { return new(proxyFeeHistory) }
+func (t *Trace) strictProxy() interface{}              { return new(proxyTrace) }
+
+// decode unmarshals a json-rpc result into target, with WithStrictDecoding its quantities must be hex strings
+func (rpc *FlashXRoute) decode(data []byte, target interface{}) error {
+	if rpc.strict {
+		if err := checkQuantities(data, reflect.TypeOf(target)); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+// checkQuantities returns the error of the first quantity of data decoded into t which isn't a hex string, values
+// not matching t are left for json.Unmarshal to report
+func checkQuantities(data json.RawMessage, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		if t.Kind() == reflect.Interface || string(data) == "null" {
+			return nil
+		}
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(strictProxyType) {
+		t = reflect.TypeOf(reflect.New(t).Interface().(strictProxy).strictProxy()).Elem()
+	}
+	if quantityTypes[t] {
+		_, err := quantity(data, true)
+		return err
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := map[string]json.RawMessage{}
+		if json.Unmarshal(data, &fields) != nil {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && field.Tag.Get("json") == "" {
+				if err := checkQuantities(data, field.Type); err != nil {
+					return err
+				}
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			for key, value := range fields {
+				if strings.EqualFold(key, name) {
+					if err := checkQuantities(value, field.Type); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var values []json.RawMessage
+		if json.Unmarshal(data, &values) != nil {
+			return nil
+		}
+		for _, value := range values {
+			if err := checkQuantities(value, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		values := map[string]json.RawMessage{}
+		if json.Unmarshal(data, &values) != nil {
+			return nil
+		}
+		for _, value := range values {
+			if err := checkQuantities(value, t.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package flashxroute
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WaitUntilSynced polls eth_syncing every pollInterval (default: 1s) until the node reports it isn't syncing, e.g.
+// to hold a bot back at startup. Failed polls are retried since the node may still be starting, the last failure is
+// returned with ctx.Err() when ctx is done first.
+func (rpc *FlashXRoute) WaitUntilSynced(ctx context.Context, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		syncing, err := rpc.EthSyncing()
+		if err == nil && !syncing.IsSyncing {
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return errors.Wrap(ctx.Err(), lastErr.Error())
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package flashxroute
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func (s *FlashXRouteTestSuite) TestWaitUntilSynced() {
+	responses := []string{
+		`{"startingBlock": "0x0", "currentBlock": "0x10", "highestBlock": "0x20"}`,
+		`false`,
+	}
+	calls := 0
+	httpmock.RegisterResponder("POST", s.rpc.url, func(request *http.Request) (*http.Response, error) {
+		result := responses[calls]
+		calls++
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0", "id":1, "result": `+result+`}`), nil
+	})
+
+	err := s.rpc.WaitUntilSynced(context.Background(), time.Millisecond)
+	s.Require().Nil(err)
+	s.Require().Equal(2, calls)
+
+	httpmock.Reset()
+	s.registerMethods(map[string]string{"eth_syncing": `error:{"code": -32000, "message": "starting"}`})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = s.rpc.WaitUntilSynced(ctx, time.Millisecond)
+	s.Require().ErrorIs(err, context.DeadlineExceeded)
+	s.Require().ErrorContains(err, "starting")
+}
@@ -0,0 +1,259 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+)
+
+// Trace types of trace_call* methods
+const (
+	TraceTypeTrace     = "trace"
+	TraceTypeStateDiff = "stateDiff"
+	TraceTypeVMTrace   = "vmTrace"
+)
+
+// TraceAction - action of a trace, the fields set depend on the trace type (call, create, suicide or reward)
+type TraceAction struct {
+	CallType      string // call, delegatecall, staticcall or callcode
+	From          string
+	To            string
+	Gas           int
+	Input         string
+	Init          string // creation code of create traces
+	Value         big.Int
+	Address       string // self-destructed contract of suicide traces
+	RefundAddress string
+	Balance       big.Int
+	Author        string // beneficiary of reward traces
+	RewardType    string
+}
+
+// TraceResult - result of a successful trace
+type TraceResult struct {
+	GasUsed int
+	Output  string
+	Address string // created contract of create traces
+	Code    string
+}
+
+// Trace - a single call frame in parity trace format, block and transaction fields are only set by trace_block,
+// trace_transaction and trace_filter
+type Trace struct {
+	Type                string
+	Action              TraceAction
+	Result              *TraceResult // nil if the frame failed
+	Error               string
+	Subtraces           int
+	TraceAddress        []int
+	BlockHash           string
+	BlockNumber         int
+	TransactionHash     string
+	TransactionPosition int
+}
+
+type proxyTraceAction struct {
+	CallType      string `json:"callType"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Gas           hexInt `json:"gas"`
+	Input         string `json:"input"`
+	Init          string `json:"init"`
+	Value         hexBig `json:"value"`
+	Address       string `json:"address"`
+	RefundAddress string `json:"refundAddress"`
+	Balance       hexBig `json:"balance"`
+	Author        string `json:"author"`
+	RewardType    string `json:"rewardType"`
+}
+
+type proxyTraceResult struct {
+	GasUsed hexInt `json:"gasUsed"`
+	Output  string `json:"output"`
+	Address string `json:"address"`
+	Code    string `json:"code"`
+}
+
+type proxyTrace struct {
+	Type                string            `json:"type"`
+	Action              proxyTraceAction  `json:"action"`
+	Result              *proxyTraceResult `json:"result"`
+	Error               string            `json:"error"`
+	Subtraces           int               `json:"subtraces"`
+	TraceAddress        []int             `json:"traceAddress"`
+	BlockHash           string            `json:"blockHash"`
+	BlockNumber         int               `json:"blockNumber"`
+	TransactionHash     string            `json:"transactionHash"`
+	TransactionPosition int               `json:"transactionPosition"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Trace) UnmarshalJSON(data []byte) error {
+	proxy := new(proxyTrace)
+	if err := json.Unmarshal(data, proxy); err != nil {
+		return err
+	}
+
+	*t = Trace{
+		Type: proxy.Type,
+		Action: TraceAction{
+			CallType:      proxy.Action.CallType,
+			From:          proxy.Action.From,
+			To:            proxy.Action.To,
+			Gas:           int(proxy.Action.Gas),
+			Input:         proxy.Action.Input,
+			Init:          proxy.Action.Init,
+			Value:         big.Int(proxy.Action.Value),
+			Address:       proxy.Action.Address,
+			RefundAddress: proxy.Action.RefundAddress,
+			Balance:       big.Int(proxy.Action.Balance),
+			Author:        proxy.Action.Author,
+			RewardType:    proxy.Action.RewardType,
+		},
+		Error:               proxy.Error,
+		Subtraces:           proxy.Subtraces,
+		TraceAddress:        proxy.TraceAddress,
+		BlockHash:           proxy.BlockHash,
+		BlockNumber:         proxy.BlockNumber,
+		TransactionHash:     proxy.TransactionHash,
+		TransactionPosition: proxy.TransactionPosition,
+	}
+	if proxy.Result != nil {
+		t.Result = &TraceResult{
+			GasUsed: int(proxy.Result.GasUsed),
+			Output:  proxy.Result.Output,
+			Address: proxy.Result.Address,
+			Code:    proxy.Result.Code,
+		}
+	}
+
+	return nil
+}
+
+// TraceCallResult - result of one call of trace_callMany, only the requested trace types are set
+type TraceCallResult struct {
+	Output    string          `json:"output"`
+	Trace     []Trace         `json:"trace"`
+	StateDiff json.RawMessage `json:"stateDiff"`
+	VMTrace   json.RawMessage `json:"vmTrace"`
+}
+
+// TraceCallMany traces calls executed one after another on top of block, each seeing the state changes of the
+// previous ones (Erigon, Reth, OpenEthereum). traceTypes defaults to TraceTypeTrace.
+// A local, free alternative to blxr_simulate_bundle while iterating on a bundle.
+func (rpc *FlashXRoute) TraceCallMany(calls []T, block string, traceTypes ...string) ([]TraceCallResult, error) {
+	if len(traceTypes) == 0 {
+		traceTypes = []string{TraceTypeTrace}
+	}
+	params := make([][]interface{}, len(calls))
+	for i, call := range calls {
+		params[i] = []interface{}{call, traceTypes}
+	}
+
+	var results []TraceCallResult
+	err := rpc.call("trace_callMany", &results, params, block)
+	return results, err
+}
+
+// DebugTraceCallMany executes calls one after another on top of block with the given tracer (e.g. callTracer,
+// prestateTracer, empty for the default struct logger) and returns the raw tracer output of each call (Geth, Erigon)
+func (rpc *FlashXRoute) DebugTraceCallMany(calls []T, block string, tracer string) ([]json.RawMessage, error) {
+	bundles := []map[string]interface{}{{"transactions": calls}}
+	stateContext := map[string]interface{}{"blockNumber": block}
+	config := map[string]interface{}{}
+	if tracer != "" {
+		config["tracer"] = tracer
+	}
+
+	var results [][]json.RawMessage
+	if err := rpc.call("debug_traceCallMany", &results, bundles, stateContext, config); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return results[0], nil
+}
+
+// TraceFilter - trace_filter parameters, empty fields are omitted
+type TraceFilter struct {
+	FromBlock   string   `json:"fromBlock,omitempty"`
+	ToBlock     string   `json:"toBlock,omitempty"`
+	FromAddress []string `json:"fromAddress,omitempty"`
+	ToAddress   []string `json:"toAddress,omitempty"`
+	After       int      `json:"after,omitempty"`
+	Count       int      `json:"count,omitempty"`
+}
+
+// TraceBlock returns the traces of all transactions and rewards of a block.
+func (rpc *FlashXRoute) TraceBlock(number int) ([]Trace, error) {
+	var traces []Trace
+
+	err := rpc.call("trace_block", &traces, IntToHex(number))
+	return traces, err
+}
+
+// TraceTransaction returns the traces of a transaction.
+func (rpc *FlashXRoute) TraceTransaction(hash string) ([]Trace, error) {
+	var traces []Trace
+
+	err := rpc.call("trace_transaction", &traces, hash)
+	return traces, err
+}
+
+// TraceFilter returns the traces matching the filter.
+func (rpc *FlashXRoute) TraceFilter(filter TraceFilter) ([]Trace, error) {
+	var traces []Trace
+
+	err := rpc.call("trace_filter", &traces, filter)
+	return traces, err
+}
+
+// ValueTransfer - ETH moved by a call frame or self-destruct
+type ValueTransfer struct {
+	TransactionHash string
+	From            string
+	To              string
+	Value           big.Int
+	TraceAddress    []int
+}
+
+// InternalTransfers returns the ETH transfers made by contracts, i.e. value carrying frames below the top-level call
+// of their transaction and self-destruct refunds. Failed frames are skipped.
+func InternalTransfers(traces []Trace) []ValueTransfer {
+	transfers := []ValueTransfer{}
+	for _, trace := range traces {
+		if len(trace.TraceAddress) == 0 || trace.Error != "" {
+			continue
+		}
+
+		transfer := ValueTransfer{TransactionHash: trace.TransactionHash, TraceAddress: trace.TraceAddress}
+		switch {
+		case trace.Type == "call" && trace.Action.CallType == "call":
+			transfer.From, transfer.To, transfer.Value = trace.Action.From, trace.Action.To, trace.Action.Value
+		case trace.Type == "suicide":
+			transfer.From, transfer.To, transfer.Value = trace.Action.Address, trace.Action.RefundAddress, trace.Action.Balance
+		default:
+			continue
+		}
+		if transfer.Value.Sign() > 0 {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers
+}
+
+// CoinbaseTransfers returns the internal transfers to coinbase, e.g. the direct builder payments of competing
+// bundles in a block traced with TraceBlock
+func CoinbaseTransfers(traces []Trace, coinbase string) []ValueTransfer {
+	transfers := []ValueTransfer{}
+	for _, transfer := range InternalTransfers(traces) {
+		if strings.EqualFold(transfer.To, coinbase) {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers
+}
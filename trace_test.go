@@ -0,0 +1,112 @@
+package flashxroute
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+func (s *FlashXRouteTestSuite) TestTraceCallMany() {
+	s.registerResponse(`[
+		{"output": "0x", "stateDiff": null, "vmTrace": null, "trace": [
+			{"action": {"callType": "call", "from": "0x1", "gas": "0x5208", "input": "0x", "to": "0x2", "value": "0xde0b6b3a7640000"},
+			 "result": {"gasUsed": "0x0", "output": "0x"}, "subtraces": 0, "traceAddress": [], "type": "call"}
+		]},
+		{"output": "0x", "trace": [
+			{"action": {"callType": "call", "from": "0x1", "gas": "0x5208", "input": "0xa9059cbb", "to": "0x3", "value": "0x0"},
+			 "error": "Reverted", "subtraces": 0, "traceAddress": [], "type": "call"}
+		]}
+	]`, func(body []byte) {
+		s.methodEqual(body, "trace_callMany")
+		s.paramsEqual(body, `[[[{"from": "0x1", "to": "0x2", "value": "0xde0b6b3a7640000"}, ["trace"]], [{"from": "0x1", "to": "0x3", "data": "0xa9059cbb"}, ["trace"]]], "latest"]`)
+	})
+
+	results, err := s.rpc.TraceCallMany([]T{
+		{From: "0x1", To: "0x2", Value: big.NewInt(Ether)},
+		{From: "0x1", To: "0x3", Data: "0xa9059cbb"},
+	}, "latest")
+	s.Require().Nil(err)
+	s.Require().Len(results, 2)
+	s.Require().Equal(*big.NewInt(Ether), results[0].Trace[0].Action.Value)
+	s.Require().Equal(21000, results[0].Trace[0].Action.Gas)
+	s.Require().NotNil(results[0].Trace[0].Result)
+	s.Require().Nil(results[1].Trace[0].Result)
+	s.Require().Equal("Reverted", results[1].Trace[0].Error)
+}
+
+func (s *FlashXRouteTestSuite) TestDebugTraceCallMany() {
+	s.registerResponse(`[[{"type": "CALL", "gasUsed": "0x5208"}, {"type": "CALL", "error": "execution reverted"}]]`, func(body []byte) {
+		s.methodEqual(body, "debug_traceCallMany")
+		s.paramsEqual(body, `[[{"transactions": [{"from": "0x1", "to": "0x2"}, {"from": "0x1", "to": "0x3"}]}], {"blockNumber": "0x10"}, {"tracer": "callTracer"}]`)
+	})
+
+	results, err := s.rpc.DebugTraceCallMany([]T{{From: "0x1", To: "0x2"}, {From: "0x1", To: "0x3"}}, "0x10", "callTracer")
+	s.Require().Nil(err)
+	s.Require().Equal([]json.RawMessage{
+		json.RawMessage(`{"type": "CALL", "gasUsed": "0x5208"}`),
+		json.RawMessage(`{"type": "CALL", "error": "execution reverted"}`),
+	}, results)
+}
+
+const blockTraces = `[
+	{"action": {"callType": "call", "from": "0xa", "gas": "0x30000", "input": "0x1234", "to": "0xb", "value": "0x0"},
+	 "blockHash": "0xbh", "blockNumber": 16, "result": {"gasUsed": "0x10000", "output": "0x"}, "subtraces": 2, "traceAddress": [],
+	 "transactionHash": "0xt1", "transactionPosition": 0, "type": "call"},
+	{"action": {"callType": "call", "from": "0xb", "gas": "0x8fc", "input": "0x", "to": "0xC0FFEE", "value": "0x2386f26fc10000"},
+	 "blockHash": "0xbh", "blockNumber": 16, "result": {"gasUsed": "0x0", "output": "0x"}, "subtraces": 0, "traceAddress": [0],
+	 "transactionHash": "0xt1", "transactionPosition": 0, "type": "call"},
+	{"action": {"callType": "call", "from": "0xb", "gas": "0x8fc", "input": "0x", "to": "0xd", "value": "0x1"},
+	 "blockHash": "0xbh", "blockNumber": 16, "error": "Reverted", "subtraces": 0, "traceAddress": [1],
+	 "transactionHash": "0xt1", "transactionPosition": 0, "type": "call"},
+	{"action": {"address": "0xe", "balance": "0x5", "refundAddress": "0xf"},
+	 "blockHash": "0xbh", "blockNumber": 16, "result": null, "subtraces": 0, "traceAddress": [0],
+	 "transactionHash": "0xt2", "transactionPosition": 1, "type": "suicide"},
+	{"action": {"author": "0xc0ffee", "rewardType": "block", "value": "0x1bc16d674ec80000"},
+	 "blockHash": "0xbh", "blockNumber": 16, "result": null, "subtraces": 0, "traceAddress": [], "type": "reward"}
+]`
+
+func (s *FlashXRouteTestSuite) TestTraceBlock() {
+	s.registerResponse(blockTraces, func(body []byte) {
+		s.methodEqual(body, "trace_block")
+		s.paramsEqual(body, `["0x10"]`)
+	})
+
+	traces, err := s.rpc.TraceBlock(16)
+	s.Require().Nil(err)
+	s.Require().Len(traces, 5)
+	s.Require().Equal(16, traces[0].BlockNumber)
+	s.Require().Equal("0xt1", traces[0].TransactionHash)
+	s.Require().Equal(0x10000, traces[0].Result.GasUsed)
+	s.Require().Equal("block", traces[4].Action.RewardType)
+
+	transfers := InternalTransfers(traces)
+	s.Require().Len(transfers, 2)
+	s.Require().Equal("0xf", transfers[1].To)
+	s.Require().Equal(*big.NewInt(5), transfers[1].Value)
+
+	payments := CoinbaseTransfers(traces, "0xc0ffee")
+	s.Require().Len(payments, 1)
+	s.Require().Equal(ValueTransfer{
+		TransactionHash: "0xt1",
+		From:            "0xb",
+		To:              "0xC0FFEE",
+		Value:           *big.NewInt(10000000000000000),
+		TraceAddress:    []int{0},
+	}, payments[0])
+}
+
+func (s *FlashXRouteTestSuite) TestTraceTransactionAndFilter() {
+	s.registerResponse(`[]`, func(body []byte) {
+		s.methodEqual(body, "trace_transaction")
+		s.paramsEqual(body, `["0xt1"]`)
+	})
+	traces, err := s.rpc.TraceTransaction("0xt1")
+	s.Require().Nil(err)
+	s.Require().Empty(traces)
+
+	s.registerResponse(`[]`, func(body []byte) {
+		s.methodEqual(body, "trace_filter")
+		s.paramsEqual(body, `[{"fromBlock": "0x10", "toBlock": "latest", "toAddress": ["0xc0ffee"], "count": 10}]`)
+	})
+	_, err = s.rpc.TraceFilter(TraceFilter{FromBlock: "0x10", ToBlock: "latest", ToAddress: []string{"0xc0ffee"}, Count: 10})
+	s.Require().Nil(err)
+}
@@ -0,0 +1,76 @@
+package flashxroute
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TxPoolState - where a transaction is, see TransactionPoolState
+type TxPoolState int
+
+// Transaction pool states
+const (
+	TxUnknown TxPoolState = iota // not known to the node, e.g. dropped or not propagated yet
+	TxPending                    // executable, waiting to be mined
+	TxQueued                     // not executable yet, e.g. behind a nonce gap
+	TxMined
+)
+
+// String returns name of the state
+func (s TxPoolState) String() string {
+	switch s {
+	case TxUnknown:
+		return "unknown"
+	case TxPending:
+		return "pending"
+	case TxQueued:
+		return "queued"
+	case TxMined:
+		return "mined"
+	}
+
+	return fmt.Sprintf("TxPoolState(%d)", int(s))
+}
+
+// TxPoolContent - txpool_contentFrom result: transactions of an account by nonce
+type TxPoolContent struct {
+	Pending map[string]Transaction `json:"pending"`
+	Queued  map[string]Transaction `json:"queued"`
+}
+
+// TxPoolContentFrom returns the pending and queued transactions of address with txpool_contentFrom
+func (rpc *FlashXRoute) TxPoolContentFrom(address string) (TxPoolContent, error) {
+	var content TxPoolContent
+	if err := rpc.checkAddress(address); err != nil {
+		return content, err
+	}
+
+	err := rpc.call("txpool_contentFrom", &content, address)
+	return content, err
+}
+
+// TransactionPoolState returns the transaction of hash with its state. eth_getTransactionByHash doesn't tell pending
+// from queued transactions, so the txpool of the sender is checked, nodes without the txpool API report TxPending.
+func (rpc *FlashXRoute) TransactionPoolState(hash string) (TxPoolState, *Transaction, error) {
+	tx, err := rpc.EthGetTransactionByHash(hash)
+	switch {
+	case err != nil:
+		return TxUnknown, nil, err
+	case tx == nil || tx.Hash == "":
+		return TxUnknown, nil, nil
+	case !tx.IsPending():
+		return TxMined, tx, nil
+	}
+
+	content, err := rpc.TxPoolContentFrom(tx.From)
+	if err != nil {
+		return TxPending, tx, nil
+	}
+	nonce := strconv.Itoa(tx.Nonce)
+	if queued, ok := content.Queued[nonce]; ok && strings.EqualFold(queued.Hash, tx.Hash) {
+		return TxQueued, tx, nil
+	}
+
+	return TxPending, tx, nil
+}
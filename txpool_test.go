@@ -0,0 +1,44 @@
+package flashxroute
+
+func (s *FlashXRouteTestSuite) TestTransactionPoolState() {
+	from := "0x201354729f8d0f8b64e9a0c353c672c6a66b3857"
+	pending := "0xfc7dcd42eb0b7898af2f52f7c5af3bd03cdf71ab8b3ed5b3d3a3ff0d91343cbe"
+	queued := "0x1c7dcd42eb0b7898af2f52f7c5af3bd03cdf71ab8b3ed5b3d3a3ff0d91343cbe"
+	mined := "0x2c7dcd42eb0b7898af2f52f7c5af3bd03cdf71ab8b3ed5b3d3a3ff0d91343cbe"
+	unknown := "0x3c7dcd42eb0b7898af2f52f7c5af3bd03cdf71ab8b3ed5b3d3a3ff0d91343cbe"
+	s.registerMethods(map[string]string{
+		`eth_getTransactionByHash ["` + pending + `"]`: `{"hash": "` + pending + `", "from": "` + from + `", "nonce": "0x5", "blockHash": null,
+			"blockNumber": null, "transactionIndex": null}`,
+		`eth_getTransactionByHash ["` + queued + `"]`: `{"hash": "` + queued + `", "from": "` + from + `", "nonce": "0x7",
+			"blockHash": "0x0000000000000000000000000000000000000000000000000000000000000000", "blockNumber": "", "transactionIndex": "0x"}`,
+		`eth_getTransactionByHash ["` + mined + `"]`: `{"hash": "` + mined + `", "from": "` + from + `", "nonce": "0x4",
+			"blockHash": "0x3003694478c108eaec173afcb55eafbb754a0b204567329f623438727ffa90d8", "blockNumber": "0x83319", "transactionIndex": "0x3"}`,
+		`eth_getTransactionByHash ["` + unknown + `"]`: `null`,
+		`txpool_contentFrom ["` + from + `"]`: `{"pending": {"5": {"hash": "` + pending + `", "nonce": "0x5"}},
+			"queued": {"7": {"hash": "` + queued + `", "nonce": "0x7"}}}`,
+	})
+
+	state, tx, err := s.rpc.TransactionPoolState(pending)
+	s.Require().NoError(err)
+	s.Require().Equal(TxPending, state)
+	s.Require().True(tx.IsPending())
+
+	state, tx, err = s.rpc.TransactionPoolState(queued)
+	s.Require().NoError(err)
+	s.Require().Equal(TxQueued, state)
+	s.Require().Empty(tx.BlockHash)
+	s.Require().Nil(tx.BlockNumber)
+	s.Require().Nil(tx.TransactionIndex)
+
+	state, tx, err = s.rpc.TransactionPoolState(mined)
+	s.Require().NoError(err)
+	s.Require().Equal(TxMined, state)
+	s.Require().False(tx.IsPending())
+	s.Require().Equal(537369, *tx.BlockNumber)
+
+	state, tx, err = s.rpc.TransactionPoolState(unknown)
+	s.Require().NoError(err)
+	s.Require().Equal(TxUnknown, state)
+	s.Require().Nil(tx)
+	s.Require().Equal("queued", TxQueued.String())
+}
@@ -3,9 +3,12 @@ package flashxroute
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"math/big"
+	"strings"
 	"unsafe"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +21,7 @@ type Syncing struct {
 	StartingBlock int
 	CurrentBlock  int
 	HighestBlock  int
+	Fields        map[string]string // other fields reported by the node as is, e.g. geth snap sync healedBytecodes and syncedAccounts
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -26,13 +30,44 @@ func (s *Syncing) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, proxy); err != nil {
 		return err
 	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
 
-	proxy.IsSyncing = true
-	*s = *(*Syncing)(unsafe.Pointer(proxy))
+	*s = Syncing{IsSyncing: true, StartingBlock: int(proxy.StartingBlock), CurrentBlock: int(proxy.CurrentBlock), HighestBlock: int(proxy.HighestBlock)}
+	for name, value := range fields {
+		switch name {
+		case "startingBlock", "currentBlock", "highestBlock":
+			continue
+		}
+		if s.Fields == nil {
+			s.Fields = make(map[string]string, len(fields))
+		}
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			text = string(value)
+		}
+		s.Fields[name] = text
+	}
 
 	return nil
 }
 
+// Field returns numeric value of a field in Fields, false when it's missing or not a number
+func (s Syncing) Field(name string) (uint64, bool) {
+	value, ok := s.Fields[name]
+	if !ok {
+		return 0, false
+	}
+	number, err := ParseUint64(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
 // T - input transaction object
 type T struct {
 	From     string
@@ -94,10 +129,20 @@ func (t *Transaction) UnmarshalJSON(data []byte) error {
 	}
 
 	*t = *(*Transaction)(unsafe.Pointer(proxy))
+	if t.IsPending() {
+		// some nodes answer "", "0x" or a zero hash instead of null for the block of pending transactions
+		t.BlockHash, t.BlockNumber, t.TransactionIndex = "", nil, nil
+	}
 
 	return nil
 }
 
+// IsPending reports whether the transaction isn't mined yet, it may be pending or queued in the mempool, see
+// TransactionPoolState
+func (t Transaction) IsPending() bool {
+	return t.BlockNumber == nil || strings.Trim(StripHexPrefix(t.BlockHash), "0") == ""
+}
+
 // Log - log object
 type Log struct {
 	Removed          bool
@@ -158,6 +203,54 @@ func (t *TransactionReceipt) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ReceiptStatus - execution outcome of a transaction receipt
+type ReceiptStatus int
+
+// Receipt statuses, ReceiptUnknown for pre-Byzantium receipts carrying a state root instead of a status
+const (
+	ReceiptUnknown ReceiptStatus = iota
+	ReceiptSuccess
+	ReceiptFailed
+)
+
+// String returns name of the status
+func (s ReceiptStatus) String() string {
+	switch s {
+	case ReceiptUnknown:
+		return "unknown"
+	case ReceiptSuccess:
+		return "success"
+	case ReceiptFailed:
+		return "failed"
+	}
+
+	return fmt.Sprintf("ReceiptStatus(%d)", int(s))
+}
+
+// ExecutionStatus decodes Status of a post-Byzantium (EIP-658) receipt: 1 succeeded and 0 failed. Pre-Byzantium
+// receipts only have the post-transaction state Root, they don't tell whether the execution failed.
+func (t TransactionReceipt) ExecutionStatus() ReceiptStatus {
+	if t.Status == "" {
+		return ReceiptUnknown
+	}
+	status, err := ParseInt(t.Status)
+	switch {
+	case err != nil:
+		return ReceiptUnknown
+	case status == 1:
+		return ReceiptSuccess
+	case status == 0:
+		return ReceiptFailed
+	}
+
+	return ReceiptUnknown
+}
+
+// Succeeded reports whether the receipt status tells the transaction succeeded
+func (t TransactionReceipt) Succeeded() bool {
+	return t.ExecutionStatus() == ReceiptSuccess
+}
+
 // Block - block object
 type Block struct {
 	Number           int
@@ -176,8 +269,37 @@ type Block struct {
 	GasLimit         int
 	GasUsed          int
 	Timestamp        int
+	BaseFeePerGas    big.Int // zero before London
 	Uncles           []string
 	Transactions     []Transaction
+	WithdrawalsRoot  string       // empty before Shanghai
+	Withdrawals      []Withdrawal // validator withdrawals credited at the end of the block, since Shanghai
+}
+
+// Withdrawal - EIP-4895 withdrawal of the beacon chain credited to Address
+type Withdrawal struct {
+	Index          int
+	ValidatorIndex int
+	Address        string
+	Amount         int // gwei
+}
+
+// AmountWei returns the withdrawn amount in wei
+func (w Withdrawal) AmountWei() *big.Int {
+	return GweiToWei(int64(w.Amount))
+}
+
+// WithdrawalsTo returns the wei credited to address by the withdrawals of the block, withdrawals change balances
+// without a transaction so they must be accounted for when diffing balances of validators and builders
+func (b Block) WithdrawalsTo(address string) *big.Int {
+	total := new(big.Int)
+	for _, withdrawal := range b.Withdrawals {
+		if strings.EqualFold(withdrawal.Address, address) {
+			total.Add(total, withdrawal.AmountWei())
+		}
+	}
+
+	return total
 }
 
 type proxySyncing struct {
@@ -227,10 +349,37 @@ type proxyTransactionReceipt struct {
 	Status            string `json:"status,omitempty"`
 }
 
+// quantity returns the hex string of a numeric json value. Unless strict, null, "" and "0x" decode to zero and
+// unquoted JSON numbers, which some providers return, are read as decimal, see WithStrictDecoding.
+func quantity(data []byte, strict bool) (string, error) {
+	value := string(bytes.Trim(data, `"`))
+
+	switch {
+	case string(data) == "null" || value == "" || value == "0x" || value == "0X":
+		if strict {
+			return "", fmt.Errorf("%w: %s", ErrEmptyHex, data)
+		}
+		return "0x0", nil
+	case len(data) > 0 && data[0] != '"':
+		number, ok := new(big.Int).SetString(value, 10)
+		if strict || !ok {
+			return "", fmt.Errorf("%w: %s", ErrInvalidHex, data)
+		}
+		return BigToHex(*number), nil
+	}
+
+	return value, nil
+}
+
 type hexInt int
 
 func (i *hexInt) UnmarshalJSON(data []byte) error {
-	result, err := ParseInt(string(bytes.Trim(data, `"`)))
+	value, err := quantity(data, false)
+	if err != nil {
+		return err
+	}
+
+	result, err := ParseInt(value)
 	*i = hexInt(result)
 
 	return err
@@ -239,7 +388,12 @@ func (i *hexInt) UnmarshalJSON(data []byte) error {
 type hexBig big.Int
 
 func (i *hexBig) UnmarshalJSON(data []byte) error {
-	result, err := ParseBigInt(string(bytes.Trim(data, `"`)))
+	value, err := quantity(data, false)
+	if err != nil {
+		return err
+	}
+
+	result, err := ParseBigInt(value)
 	*i = hexBig(result)
 
 	return err
@@ -266,8 +420,18 @@ type proxyBlockWithTransactions struct {
 	GasLimit         hexInt             `json:"gasLimit"`
 	GasUsed          hexInt             `json:"gasUsed"`
 	Timestamp        hexInt             `json:"timestamp"`
+	BaseFeePerGas    hexBig             `json:"baseFeePerGas"`
 	Uncles           []string           `json:"uncles"`
 	Transactions     []proxyTransaction `json:"transactions"`
+	WithdrawalsRoot  string             `json:"withdrawalsRoot"`
+	Withdrawals      []proxyWithdrawal  `json:"withdrawals"`
+}
+
+type proxyWithdrawal struct {
+	Index          hexInt `json:"index"`
+	ValidatorIndex hexInt `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         hexInt `json:"amount"`
 }
 
 func (proxy *proxyBlockWithTransactions) toBlock() Block {
@@ -275,24 +439,27 @@ func (proxy *proxyBlockWithTransactions) toBlock() Block {
 }
 
 type proxyBlockWithoutTransactions struct {
-	Number           hexInt   `json:"number"`
-	Hash             string   `json:"hash"`
-	ParentHash       string   `json:"parentHash"`
-	Nonce            string   `json:"nonce"`
-	Sha3Uncles       string   `json:"sha3Uncles"`
-	LogsBloom        string   `json:"logsBloom"`
-	TransactionsRoot string   `json:"transactionsRoot"`
-	StateRoot        string   `json:"stateRoot"`
-	Miner            string   `json:"miner"`
-	Difficulty       hexBig   `json:"difficulty"`
-	TotalDifficulty  hexBig   `json:"totalDifficulty"`
-	ExtraData        string   `json:"extraData"`
-	Size             hexInt   `json:"size"`
-	GasLimit         hexInt   `json:"gasLimit"`
-	GasUsed          hexInt   `json:"gasUsed"`
-	Timestamp        hexInt   `json:"timestamp"`
-	Uncles           []string `json:"uncles"`
-	Transactions     []string `json:"transactions"`
+	Number           hexInt            `json:"number"`
+	Hash             string            `json:"hash"`
+	ParentHash       string            `json:"parentHash"`
+	Nonce            string            `json:"nonce"`
+	Sha3Uncles       string            `json:"sha3Uncles"`
+	LogsBloom        string            `json:"logsBloom"`
+	TransactionsRoot string            `json:"transactionsRoot"`
+	StateRoot        string            `json:"stateRoot"`
+	Miner            string            `json:"miner"`
+	Difficulty       hexBig            `json:"difficulty"`
+	TotalDifficulty  hexBig            `json:"totalDifficulty"`
+	ExtraData        string            `json:"extraData"`
+	Size             hexInt            `json:"size"`
+	GasLimit         hexInt            `json:"gasLimit"`
+	GasUsed          hexInt            `json:"gasUsed"`
+	Timestamp        hexInt            `json:"timestamp"`
+	BaseFeePerGas    hexBig            `json:"baseFeePerGas"`
+	Uncles           []string          `json:"uncles"`
+	Transactions     []string          `json:"transactions"`
+	WithdrawalsRoot  string            `json:"withdrawalsRoot"`
+	Withdrawals      []proxyWithdrawal `json:"withdrawals"`
 }
 
 func (proxy *proxyBlockWithoutTransactions) toBlock() Block {
@@ -313,7 +480,10 @@ func (proxy *proxyBlockWithoutTransactions) toBlock() Block {
 		GasLimit:         int(proxy.GasLimit),
 		GasUsed:          int(proxy.GasUsed),
 		Timestamp:        int(proxy.Timestamp),
+		BaseFeePerGas:    big.Int(proxy.BaseFeePerGas),
 		Uncles:           proxy.Uncles,
+		WithdrawalsRoot:  proxy.WithdrawalsRoot,
+		Withdrawals:      *(*[]Withdrawal)(unsafe.Pointer(&proxy.Withdrawals)),
 	}
 
 	block.Transactions = make([]Transaction, len(proxy.Transactions))
@@ -349,6 +519,15 @@ type BloxrouteBrmSimulateBundleRequest struct {
 	Timestamp        uint64  `json:"timestamp,omitempty"`           // [Optional] Simulation timestamp, an integer in unix epoch format. Default value is None.
 }
 
+// SimulateBlockOptions - parameters of BloxrouteSimulateBlockWithOptions and BloxrouteSimulateRawBlock
+type SimulateBlockOptions struct {
+	MaxTx              int                              // maximum number of tx to include (default: 0 - all transactions)
+	StateBlock         string                           // state block tag, number or hash to simulate on (default: parent hash of the block)
+	Timestamp          int64                            // simulation timestamp (default: none)
+	IncludeCoinbaseTxs bool                             // keep tx from and to the coinbase, skipped by default
+	Filter             func(tx *types.Transaction) bool // return false to skip a tx, not applied to raw tx
+}
+
 type BloxrouteSimulateBundleResult struct {
 	GasUsed           int64  `json:"gasUsed"`           // 63197,
 	TxHash            string `json:"txHash"`            // "0xe2df005210bdc204a34ff03211606e5d8036740c686e9fe4e266ae91cf4d12df",
@@ -432,7 +611,7 @@ type BloxrouteSendTransactionRequest struct {
 	NonceMonitoring      bool       `json:"nonce_monitoring,omitempty"`   /* [Optional, default: False] A boolean flag indicating if Tx Nonce Monitoring should be enabled for the transaction.
                                                                                  This parameter only effects Cloud-API requests.
 	                                                                         *Currently only available for users testing the Beta version, but will soon be available to all. */
-	BlockchainNetwork    string     `json:""blockchain_network,omitempty` /* [Optional, default: Mainnet] Blockchain network name. Use with Cloud-API when working with BSC.
+	BlockchainNetwork    string     `json:"blockchain_network,omitempty"` /* [Optional, default: Mainnet] Blockchain network name. Use with Cloud-API when working with BSC.
                                                                                  Available options are: Mainnet for ETH Mainnet, BSC-Mainnet for BSC Mainnet, and Polygon-Mainnet for Polygon Mainnet. */
 	ValidatorsOnly       bool       `json:"validators_only,omitempty"`    // [Optional, default: False] Support for semi private transactions in all networks. See section Semi-Private Transaction for more info.
 }
@@ -456,3 +635,65 @@ type BloxrouteSendPrivateTransactionRequest struct {
                                                                                 all: all builders
                                                                             Traders can refer to List of External Builders page for a full list. */
 }
+
+// BlockHeader - header fields of a block, see EthGetBlockHeaderByNumber
+type BlockHeader struct {
+	Number           int
+	Hash             string
+	ParentHash       string
+	Nonce            string
+	Sha3Uncles       string
+	LogsBloom        string
+	TransactionsRoot string
+	StateRoot        string
+	ReceiptsRoot     string
+	MixHash          string
+	Miner            string
+	Difficulty       big.Int
+	ExtraData        string
+	GasLimit         int
+	GasUsed          int
+	Timestamp        int
+	BaseFeePerGas    big.Int // zero before London
+}
+
+type proxyBlockHeader struct {
+	Number           hexInt `json:"number"`
+	Hash             string `json:"hash"`
+	ParentHash       string `json:"parentHash"`
+	Nonce            string `json:"nonce"`
+	Sha3Uncles       string `json:"sha3Uncles"`
+	LogsBloom        string `json:"logsBloom"`
+	TransactionsRoot string `json:"transactionsRoot"`
+	StateRoot        string `json:"stateRoot"`
+	ReceiptsRoot     string `json:"receiptsRoot"`
+	MixHash          string `json:"mixHash"`
+	Miner            string `json:"miner"`
+	Difficulty       hexBig `json:"difficulty"`
+	ExtraData        string `json:"extraData"`
+	GasLimit         hexInt `json:"gasLimit"`
+	GasUsed          hexInt `json:"gasUsed"`
+	Timestamp        hexInt `json:"timestamp"`
+	BaseFeePerGas    hexBig `json:"baseFeePerGas"`
+}
+
+func (proxy *proxyBlockHeader) toHeader() BlockHeader {
+	return *(*BlockHeader)(unsafe.Pointer(proxy))
+}
+
+// BlockTag - block parameter of a call: a named block or a hex block number, see BlockNumberTag
+type BlockTag string
+
+// Named blocks
+const (
+	BlockLatest    BlockTag = "latest"
+	BlockPending   BlockTag = "pending"
+	BlockSafe      BlockTag = "safe"
+	BlockFinalized BlockTag = "finalized"
+	BlockEarliest  BlockTag = "earliest"
+)
+
+// BlockNumberTag returns the tag of block number
+func BlockNumberTag(number int) BlockTag {
+	return BlockTag(IntToHex(number))
+}
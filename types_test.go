@@ -2,6 +2,7 @@ package flashxroute
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -52,6 +53,26 @@ func TestSyncingUnmarshal(t *testing.T) {
 	require.Equal(t, 900, syncing.StartingBlock)
 	require.Equal(t, 902, syncing.CurrentBlock)
 	require.Equal(t, 1108, syncing.HighestBlock)
+	require.Nil(t, syncing.Fields)
+
+	data = []byte(`{
+		"startingBlock": "0x0",
+		"currentBlock": "0x10",
+		"highestBlock": "0x20",
+		"healedBytecodes": "0x5",
+		"syncedAccounts": "0x2a",
+		"syncMode": "snap"
+	}`)
+
+	err = json.Unmarshal(data, syncing)
+	require.Nil(t, err)
+	require.Equal(t, 16, syncing.CurrentBlock)
+	require.Equal(t, map[string]string{"healedBytecodes": "0x5", "syncedAccounts": "0x2a", "syncMode": "snap"}, syncing.Fields)
+	accounts, ok := syncing.Field("syncedAccounts")
+	require.True(t, ok)
+	require.Equal(t, uint64(42), accounts)
+	_, ok = syncing.Field("syncMode")
+	require.False(t, ok)
 }
 
 func TestTransactionUnmarshal(t *testing.T) {
@@ -172,3 +193,69 @@ func TestTransactionReceiptUnmarshal(t *testing.T) {
 	require.Equal(t, 6, receipt.Logs[0].LogIndex)
 	require.Equal(t, false, receipt.Logs[0].Removed)
 }
+
+func TestReceiptExecutionStatus(t *testing.T) {
+	require.Equal(t, ReceiptSuccess, TransactionReceipt{Status: "0x1"}.ExecutionStatus())
+	require.Equal(t, ReceiptFailed, TransactionReceipt{Status: "0x0"}.ExecutionStatus())
+	require.False(t, TransactionReceipt{Status: "0x0"}.Succeeded())
+
+	// pre-Byzantium
+	receipt := TransactionReceipt{Root: "0x55b68780caee96e686eb398371bb679574d4b995614ae94243da4886059a47ee"}
+	require.Equal(t, ReceiptUnknown, receipt.ExecutionStatus())
+	require.False(t, receipt.Succeeded())
+	require.Equal(t, "failed", ReceiptFailed.String())
+}
+
+func TestLenientQuantityUnmarshal(t *testing.T) {
+	test := struct {
+		Gas     hexInt  `json:"gas"`
+		Value   hexBig  `json:"value"`
+		Nonce   hexInt  `json:"nonce"`
+		Index   *hexInt `json:"index"`
+		Balance hexBig  `json:"balance"`
+	}{}
+
+	data := []byte(`{"gas": 21000, "value": null, "nonce": "", "index": null, "balance": "0x"}`)
+	require.Nil(t, json.Unmarshal(data, &test))
+	require.Equal(t, hexInt(21000), test.Gas)
+	require.Equal(t, hexBig{}, test.Value)
+	require.Equal(t, hexInt(0), test.Nonce)
+	require.Nil(t, test.Index)
+	require.Equal(t, hexBig{}, test.Balance)
+
+	require.Nil(t, json.Unmarshal([]byte(`{"value": 23949082357483433297453}`), &test))
+	b, _ := new(big.Int).SetString("23949082357483433297453", 10)
+	require.Equal(t, hexBig(*b), test.Value)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"gas": 1.5}`), &test), ErrInvalidHex)
+}
+
+func (s *FlashXRouteTestSuite) TestStrictDecoding() {
+	strict := New(s.rpc.url, WithStrictDecoding(true))
+	for _, receipt := range []string{
+		`{"gasUsed": 21000}`,
+		`{"gasUsed": null}`,
+		`{"gasUsed": ""}`,
+		`{"gasUsed": "0x"}`,
+		`{"gasUsed": "0x5208", "logs": [{"logIndex": 1}]}`,
+	} {
+		s.registerResponse(receipt, func([]byte) {})
+		_, err := strict.EthGetTransactionReceipt("0x1")
+		s.Require().NotNil(err, receipt)
+		s.Require().True(errors.Is(err, ErrInvalidHex) || errors.Is(err, ErrEmptyHex), receipt)
+
+		// other clients stay lenient
+		_, err = s.rpc.EthGetTransactionReceipt("0x1")
+		s.Require().Nil(err, receipt)
+	}
+
+	s.registerResponse(`{"gasUsed": "0x5208", "logs": [{"logIndex": "0x1"}]}`, func([]byte) {})
+	receipt, err := strict.EthGetTransactionReceipt("0x1")
+	s.Require().Nil(err)
+	s.Require().Equal(21000, receipt.GasUsed)
+	s.Require().Equal(1, receipt.Logs[0].LogIndex)
+
+	s.registerMethods(map[string]string{"eth_feeHistory": `{"oldestBlock": 16, "baseFeePerGas": ["0x1"]}`})
+	_, err = strict.EthFeeHistory(1, "latest", nil)
+	s.Require().ErrorIs(err, ErrInvalidHex)
+}
@@ -0,0 +1,74 @@
+package flashxroute
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/pkg/errors"
+)
+
+// ErrNoHardwareWallet is returned when no hardware wallet of the requested kind is connected
+var ErrNoHardwareWallet = errors.New("no hardware wallet found")
+
+// NewLedgerSigner opens the first connected Ledger and derives the account at path, e.g. "m/44'/60'/0'/0/0".
+// An empty path uses the default ethereum derivation path. The signer only signs transactions, Flashbots requests
+// with it fail with ErrTextSigningUnsupported.
+func NewLedgerSigner(path string) (*WalletSigner, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, err
+	}
+
+	return newHardwareSigner(hub, path)
+}
+
+// NewTrezorSigner opens the first connected Trezor and derives the account at path, e.g. "m/44'/60'/0'/0/0".
+// An empty path uses the default ethereum derivation path. Like NewLedgerSigner it only signs transactions.
+func NewTrezorSigner(path string) (*WalletSigner, error) {
+	hub, err := usbwallet.NewTrezorHubWithWebUSB()
+	if err != nil {
+		return nil, err
+	}
+	if len(hub.Wallets()) == 0 {
+		// older trezor firmwares only talk HID
+		if hub, err = usbwallet.NewTrezorHubWithHID(); err != nil {
+			return nil, err
+		}
+	}
+
+	return newHardwareSigner(hub, path)
+}
+
+// walletHub - wallets of a driver, e.g. *usbwallet.Hub
+type walletHub interface {
+	Wallets() []accounts.Wallet
+}
+
+// newHardwareSigner opens the first wallet of hub and derives the account at path
+func newHardwareSigner(hub walletHub, path string) (*WalletSigner, error) {
+	derivationPath := accounts.DefaultBaseDerivationPath
+	if path != "" {
+		var err error
+		if derivationPath, err = accounts.ParseDerivationPath(path); err != nil {
+			return nil, err
+		}
+	}
+
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, ErrNoHardwareWallet
+	}
+
+	wallet := wallets[0]
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("open %s: %w", wallet.URL(), err)
+	}
+	account, err := wallet.Derive(derivationPath, true)
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("derive %s: %w", derivationPath, err)
+	}
+
+	return &WalletSigner{wallet: wallet, account: account, txOnly: true}, nil
+}
@@ -0,0 +1,46 @@
+package flashxroute
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testHub - walletHub of fixed wallets
+type testHub []accounts.Wallet
+
+func (h testHub) Wallets() []accounts.Wallet {
+	return h
+}
+
+func TestNewHardwareSigner(t *testing.T) {
+	_, err := newHardwareSigner(testHub{}, "")
+	require.ErrorIs(t, err, ErrNoHardwareWallet)
+
+	key, _ := crypto.GenerateKey()
+	_, err = newHardwareSigner(testHub{&testWallet{key: key}}, "m/invalid")
+	require.NotNil(t, err)
+
+	failure := errors.New("locked")
+	_, err = newHardwareSigner(testHub{&testWallet{key: key, openErr: failure}}, "")
+	require.ErrorIs(t, err, failure)
+
+	wallet := &testWallet{key: key, deriveErr: failure}
+	_, err = newHardwareSigner(testHub{wallet}, "m/44'/60'/0'/0/1")
+	require.ErrorIs(t, err, failure)
+	require.True(t, wallet.closed)
+
+	signer, err := newHardwareSigner(testHub{&testWallet{key: key}}, "")
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	// drivers can't sign personal messages, so Flashbots calls fail before sending anything
+	require.False(t, signer.SignsText())
+	_, err = FlashbotsSignature(signer, []byte(`{"method":"eth_sendBundle"}`))
+	require.ErrorIs(t, err, ErrTextSigningUnsupported)
+	_, err = New("http://127.0.0.1:1").CallWithFlashbotsSigner("eth_sendBundle", signer)
+	require.ErrorIs(t, err, ErrTextSigningUnsupported)
+}